@@ -0,0 +1,73 @@
+package caches
+
+import (
+	"context"
+)
+
+const (
+	// BitCountIndexByte counts the BitCount and BitPosSpan range in bytes.
+	BitCountIndexByte = "BYTE"
+	// BitCountIndexBit counts the BitCount and BitPosSpan range in bits, it requires redis-server version >= 7.0.
+	BitCountIndexBit = "BIT"
+)
+
+// BitCount provides the range of the BitCount function.
+type BitCount struct {
+	// Start and End are inclusive and can be negative, -1 being the last byte or bit.
+	Start, End int64
+
+	// Unit can be `BYTE` or `BIT`, `BYTE` when empty.
+	Unit string
+}
+
+// BitmapCommand defines bit operations on string values, the strings being used as bit arrays.
+// Bits are numbered from the most significant bit of the first byte,
+// and the strings are padded with zeros when a bit beyond their end is written.
+// Bit offsets must be positive and less than 2^32, the size of the largest string value.
+type BitmapCommand interface {
+	// SetBit sets or clears the bit at offset in the string value stored at key.
+	// value must be 0 or 1. Returns the original bit value stored at offset.
+	SetBit(ctx context.Context, key string, offset int64, value int) Result[int64]
+
+	// GetBit returns the bit value at offset in the string value stored at key.
+	// Returns 0 when the key does not exist or offset is beyond the end of the string.
+	GetBit(ctx context.Context, key string, offset int64) Result[int64]
+
+	// BitCount counts the number of bits set to 1 in the string value stored at key.
+	// bitCount restricts the count to a range, the whole string is counted when it is nil.
+	BitCount(ctx context.Context, key string, bitCount *BitCount) Result[int64]
+
+	// BitPos returns the position of the first bit set to bit, 0 or 1, in the string value stored at key.
+	// pos can provide the start and the end of the searched range in bytes.
+	// Returns -1 if no bit is found, but when looking for a 0 without an end,
+	// the string is considered padded with zeros and the position after its end is returned.
+	BitPos(ctx context.Context, key string, bit int64, pos ...int64) Result[int64]
+
+	// BitPosSpan is like BitPos with a start and an end counted in span, `BYTE` or `BIT`.
+	BitPosSpan(ctx context.Context, key string, bit int8, start, end int64, span string) Result[int64]
+
+	// BitOpAnd stores the bitwise AND of the strings stored at keys in destKey.
+	// Shorter strings and missing keys are considered padded with zeros.
+	// Returns the length of the string stored in destKey, equal to the longest input string.
+	BitOpAnd(ctx context.Context, destKey string, keys ...string) Result[int64]
+
+	// BitOpOr stores the bitwise OR of the strings stored at keys in destKey.
+	BitOpOr(ctx context.Context, destKey string, keys ...string) Result[int64]
+
+	// BitOpXor stores the bitwise XOR of the strings stored at keys in destKey.
+	BitOpXor(ctx context.Context, destKey string, keys ...string) Result[int64]
+
+	// BitOpNot stores the bitwise NOT of the string stored at key in destKey.
+	BitOpNot(ctx context.Context, destKey string, key string) Result[int64]
+
+	// BitField runs the GET, SET and INCRBY operations of args on the integers
+	// stored at arbitrary bit offsets in the string value stored at key, such as
+	// `"SET", "u8", 0, 200, "INCRBY", "i5", "#1", 10, "GET", "u4", 0`.
+	// A type is `i` or `u` followed by a width of up to 64 bits signed or 63 bits unsigned,
+	// an offset prefixed with `#` is multiplied by the width of the type.
+	// `"OVERFLOW", "WRAP"`, `"SAT"` or `"FAIL"` sets the overflow behavior of the following SET and INCRBY operations,
+	// WRAP by default.
+	// Returns the result of each operation, the previous value for SET and the new value for INCRBY,
+	// nil when the operation failed with OVERFLOW FAIL.
+	BitField(ctx context.Context, key string, args ...any) Result[[]*int64]
+}
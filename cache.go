@@ -0,0 +1,129 @@
+package caches
+
+import "strings"
+
+// Capabilities is a set of optional features a provider supports.
+// Optional command families and options that not every backend can honor
+// are reported here so callers can degrade gracefully instead of failing.
+type Capabilities uint64
+
+const (
+	// CapKeepTTL means SetArgs.KeepTTL and Set with KeepTTL preserve the existing expiration.
+	CapKeepTTL Capabilities = 1 << iota
+
+	// CapSetGet means SetArgs.Get returns the previous value stored at key.
+	CapSetGet
+
+	// CapZRangeByLex means ZRangeArgs.ByLex is honored with `[`, `(`, `-` and `+` bounds.
+	CapZRangeByLex
+
+	// CapZScoreExclusive means score bounds prefixed with `(` are treated as exclusive.
+	CapZScoreExclusive
+
+	// CapZStoreWeights means ZStore.Weights are applied by ZInter* and ZUnion* commands.
+	CapZStoreWeights
+
+	// CapStreams means the provider implements StreamCommand and its commands can be run.
+	CapStreams
+
+	// CapHashFieldTTL means the HExpire family, HTTL, HPersist, HGetEx and HSetEx
+	// of HashCommand are supported.
+	CapHashFieldTTL
+
+	// CapHyperLogLog means the provider implements HyperLogLogCommand.
+	CapHyperLogLog
+
+	// CapGeo means the provider implements GeoCommand.
+	CapGeo
+
+	// CapBitmap means the provider implements BitmapCommand.
+	CapBitmap
+
+	// CapCompare means the provider implements CompareCommand.
+	CapCompare
+
+	// CapRateLimit means the provider implements RateLimitCommand.
+	CapRateLimit
+
+	// CapScript means the provider implements ScriptCommand.
+	CapScript
+
+	// CapLua means ScriptCommand runs Lua scripts, without registering a Go function for them.
+	CapLua
+
+	// CapPipeline means the provider implements PipelineCommand.
+	CapPipeline
+
+	// CapTx means the provider implements TxCommand.
+	CapTx
+
+	// CapPubSub means the provider implements PubSub.
+	CapPubSub
+)
+
+var capabilityNames = []struct {
+	cap  Capabilities
+	name string
+}{
+	{CapKeepTTL, "KeepTTL"},
+	{CapSetGet, "SetGet"},
+	{CapZRangeByLex, "ZRangeByLex"},
+	{CapZScoreExclusive, "ZScoreExclusive"},
+	{CapZStoreWeights, "ZStoreWeights"},
+	{CapStreams, "Streams"},
+	{CapHashFieldTTL, "HashFieldTTL"},
+	{CapHyperLogLog, "HyperLogLog"},
+	{CapGeo, "Geo"},
+	{CapBitmap, "Bitmap"},
+	{CapCompare, "Compare"},
+	{CapRateLimit, "RateLimit"},
+	{CapScript, "Script"},
+	{CapLua, "Lua"},
+	{CapPipeline, "Pipeline"},
+	{CapTx, "Tx"},
+	{CapPubSub, "PubSub"},
+}
+
+// Has reports whether all the given capabilities are supported.
+func (c Capabilities) Has(caps Capabilities) bool {
+	return c&caps == caps
+}
+
+// String returns the names of the capabilities joined by `|`.
+func (c Capabilities) String() string {
+	names := make([]string, 0, len(capabilityNames))
+	for _, n := range capabilityNames {
+		if c.Has(n.cap) {
+			names = append(names, n.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Capable is implemented by providers that report their optional features.
+type Capable interface {
+	// Capabilities returns the optional features supported by the provider.
+	Capabilities() Capabilities
+}
+
+// Cache aggregates all command groups implemented by every provider,
+// so that service code can accept a single value.
+type Cache interface {
+	Capable
+
+	KeyCommand
+	StringCommand
+	ListCommand
+	HashCommand
+	SetCommand
+	SortedSetCommand
+}
+
+// CapabilitiesOf returns the capabilities reported by v,
+// or no capabilities when v does not implement Capable.
+func CapabilitiesOf(v any) Capabilities {
+	if c, ok := v.(Capable); ok {
+		return c.Capabilities()
+	}
+	return 0
+}
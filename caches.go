@@ -6,4 +6,10 @@ import (
 
 const Nil = error.Nil
 
+// ErrTxFailed is returned when a transaction is aborted because a watched key changed.
+const ErrTxFailed = error.TxFailed
+
+// ErrNoScript is returned by EvalSha when no script matches the digest.
+const ErrNoScript = error.NoScript
+
 const KeepTTL = -1
@@ -0,0 +1,24 @@
+package caches
+
+import (
+	"context"
+	"time"
+)
+
+// CompareCommand defines operations on string values which only apply when the key holds a given value,
+// or does not exist. The comparison and the operation are run atomically, so that a client can safely
+// update a key it wrote itself, such as a lock it holds, without affecting a value written meanwhile by another one.
+type CompareCommand interface {
+	// CompareAndDelete deletes key only if it holds value.
+	// Returns true if the key was deleted, false if it does not exist or holds another value.
+	CompareAndDelete(ctx context.Context, key string, value any) Result[bool]
+
+	// CompareAndExpire sets a timeout on key, with a millisecond precision, only if it holds value.
+	// Returns true if the timeout was set, false if the key does not exist or holds another value.
+	CompareAndExpire(ctx context.Context, key string, value any, expiration time.Duration) Result[bool]
+
+	// AcquireWithToken sets key to value with expiration, zero meaning no expiration, only if key does not exist,
+	// and increments the integer stored at tokenKey in the same atomic operation.
+	// Returns the incremented token, or Nil if key exists, in which case tokenKey is left unchanged.
+	AcquireWithToken(ctx context.Context, key string, value any, expiration time.Duration, tokenKey string) Result[int64]
+}
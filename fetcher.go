@@ -0,0 +1,152 @@
+package caches
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// fetcherVersion is the first byte of the values written by Fetcher.
+const fetcherVersion = 1
+
+// FetcherOptions configures a Fetcher.
+type FetcherOptions struct {
+	// Beta scales how early values are recomputed, 1 by default.
+	// Values greater than 1 favor earlier recomputations, values lower than 1 later ones.
+	Beta float64
+
+	// Grace keeps values for Grace after their TTL, during which they are served stale
+	// while being recomputed in the background. Zero disables stale reads.
+	Grace time.Duration
+}
+
+// FetcherClient is the set of commands used by a Fetcher, implemented by the providers.
+type FetcherClient interface {
+	StringCommand
+
+	// PTTL returns the remaining time to live of a key, as KeyCommand.PTTL.
+	PTTL(ctx context.Context, key string) Result[time.Duration]
+}
+
+// Fetcher reads values through the cache and recomputes them before they expire,
+// following the XFetch algorithm: the closer a value is to its expiration, and the longer it
+// took to compute, the more likely a read is to trigger its recomputation in the background.
+//
+// Values are stored with the time it took to compute them, so keys read by a Fetcher
+// must only be written by it. Values written otherwise are recomputed as if missing.
+type Fetcher struct {
+	cmd   FetcherClient
+	beta  float64
+	grace time.Duration
+
+	group loadGroup
+}
+
+// NewFetcher returns a Fetcher reading and writing values with cmd.
+func NewFetcher(cmd FetcherClient, opts *FetcherOptions) *Fetcher {
+	if cmd == nil {
+		panic("cmd is nil")
+	}
+
+	if opts == nil {
+		opts = &FetcherOptions{}
+	}
+
+	beta := opts.Beta
+	if beta <= 0 {
+		beta = 1
+	}
+
+	return &Fetcher{
+		cmd:   cmd,
+		beta:  beta,
+		grace: opts.Grace,
+	}
+}
+
+// Fetch returns the value of key.
+// A missing value is computed with compute and cached for ttl, while an existing one may trigger
+// its recomputation in the background, before ttl is over or during the grace period.
+// Concurrent computations of the same key within the process are collapsed into one.
+// Errors and panics of compute are returned for missing values, and ignored for background recomputations.
+//
+// compute runs with a context that is not canceled with ctx, since its result is shared with the other callers.
+func (f *Fetcher) Fetch(ctx context.Context, key string, ttl time.Duration, compute LoadFunc) Result[[]byte] {
+	data, err := f.cmd.Get(ctx, key).Result()
+	if err != nil && err != Nil {
+		return NewResult[[]byte](nil, err)
+	}
+
+	val, delta, ok := decodeFetched(data)
+	if err == Nil || !ok {
+		return f.group.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+			return f.compute(ctx, key, ttl, compute)
+		}).wait(ctx)
+	}
+
+	pttl, err := f.cmd.PTTL(ctx, key).Result()
+	if err != nil {
+		return NewResult[[]byte](nil, err)
+	}
+
+	// -1 is a value without expiration, and -2 a value which expired since it was read
+	if pttl >= 0 && f.shouldRecompute(pttl-f.grace, delta) {
+		f.group.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+			return f.compute(ctx, key, ttl, compute)
+		})
+	}
+	return NewResult(val, nil)
+}
+
+// shouldRecompute reports whether a value expiring in remaining, and which took delta to compute,
+// is recomputed: XFetch recomputes it when -delta * beta * ln(rand()) >= remaining.
+func (f *Fetcher) shouldRecompute(remaining, delta time.Duration) bool {
+	if remaining <= 0 {
+		return true
+	}
+
+	early := -float64(delta) * f.beta * math.Log(1-rand.Float64())
+	return early >= float64(remaining)
+}
+
+// compute runs compute, measuring its duration, and caches its result for ttl and the grace period.
+func (f *Fetcher) compute(ctx context.Context, key string, ttl time.Duration, compute LoadFunc) ([]byte, error) {
+	start := time.Now()
+	val, err := compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration := ttl
+	if ttl > 0 {
+		expiration += f.grace
+	}
+
+	data := encodeFetched(val, time.Since(start))
+	_ = f.cmd.SetArgs(ctx, key, data, SetArgs{TTL: expiration}).Err()
+	return val, nil
+}
+
+// encodeFetched prepends the compute duration of val, in milliseconds, to val.
+func encodeFetched(val []byte, delta time.Duration) []byte {
+	data := make([]byte, 0, 1+binary.MaxVarintLen64+len(val))
+	data = append(data, fetcherVersion)
+	data = binary.AppendUvarint(data, uint64(delta.Milliseconds()))
+	return append(data, val...)
+}
+
+// decodeFetched returns the value and compute duration stored in data,
+// and false when data was not written by a Fetcher.
+func decodeFetched(data []byte) ([]byte, time.Duration, bool) {
+	if len(data) == 0 || data[0] != fetcherVersion {
+		return nil, 0, false
+	}
+
+	ms, n := binary.Uvarint(data[1:])
+	if n <= 0 {
+		return nil, 0, false
+	}
+	return data[1+n:], time.Duration(ms) * time.Millisecond, true
+}
@@ -0,0 +1,96 @@
+package caches
+
+import (
+	"context"
+)
+
+// GeoLocation is a member of a geospatial index.
+type GeoLocation struct {
+	Name string
+
+	// Longitude, Latitude, Dist and GeoHash are only set by the searches asking for them.
+	// Dist is in the unit of the search shape.
+	Longitude, Latitude, Dist float64
+	GeoHash                   int64
+}
+
+// GeoPos is the position of a member of a geospatial index.
+type GeoPos struct {
+	Longitude, Latitude float64
+}
+
+// GeoSearchQuery provides arguments for the GeoSearch function.
+type GeoSearchQuery struct {
+	// Member is the center of the search. When empty, the center is Longitude and Latitude.
+	Member string
+
+	Longitude float64
+	Latitude  float64
+
+	// Radius and its unit, `m`, `km`, `ft` or `mi`, `km` by default, when searching by radius.
+	Radius     float64
+	RadiusUnit string
+
+	// Width, height and unit of the box, `km` by default, when searching by box, which is the case when Radius is 0.
+	BoxWidth  float64
+	BoxHeight float64
+	BoxUnit   string
+
+	// Sort can be `ASC` or `DESC` to sort the members by distance to the center, or empty.
+	Sort string
+
+	// Count limits the number of members, the closest ones unless CountAny is set,
+	// in which case the search stops as soon as Count members are found.
+	Count    int
+	CountAny bool
+}
+
+// GeoSearchLocationQuery provides arguments for the GeoSearchLocation function.
+type GeoSearchLocationQuery struct {
+	GeoSearchQuery
+
+	// WithCoord, WithDist and WithHash return the position, distance to the center and geohash score of the members.
+	WithCoord bool
+	WithDist  bool
+	WithHash  bool
+}
+
+// GeoSearchStoreQuery provides arguments for the GeoSearchStore function.
+type GeoSearchStoreQuery struct {
+	GeoSearchQuery
+
+	// StoreDist stores the distance of the members to the center, in the unit of the shape,
+	// as their score instead of their geohash.
+	StoreDist bool
+}
+
+// GeoCommand defines operations on geospatial indexes.
+// A geospatial index is a sorted set whose scores are the 52 bit geohashes of the positions of its members,
+// so it can also be read and updated with SortedSetCommand.
+// Distances are computed on a sphere, units being `m`, `km`, `ft` or `mi`.
+type GeoCommand interface {
+	// GeoAdd adds the locations to the index stored at key, updating the position of existing members.
+	// Longitudes must be in [-180, 180] and latitudes in [-85.05112878, 85.05112878].
+	// Returns the number of members added.
+	GeoAdd(ctx context.Context, key string, geoLocation ...*GeoLocation) Result[int64]
+
+	// GeoPos returns the positions of members, nil for the missing members.
+	GeoPos(ctx context.Context, key string, members ...string) Result[[]*GeoPos]
+
+	// GeoDist returns the distance between two members in unit, `km` by default.
+	// Returns Nil if a member does not exist.
+	GeoDist(ctx context.Context, key string, member1, member2, unit string) Result[float64]
+
+	// GeoHash returns the standard 11 characters geohashes of members, empty for the missing members.
+	GeoHash(ctx context.Context, key string, members ...string) Result[[]string]
+
+	// GeoSearch returns the members within the radius or the box of q.
+	GeoSearch(ctx context.Context, key string, q *GeoSearchQuery) Result[[]string]
+
+	// GeoSearchLocation returns the members within the radius or the box of q, with the fields it asks for.
+	GeoSearchLocation(ctx context.Context, key string, q *GeoSearchLocationQuery) Result[[]GeoLocation]
+
+	// GeoSearchStore stores the members within the radius or the box of q in the sorted set store,
+	// replacing it, and returns their number.
+	GeoSearchStore(ctx context.Context, key, store string, q *GeoSearchStoreQuery) Result[int64]
+}
@@ -1,6 +1,9 @@
 package caches
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // HashCommand defines operations for Redis hash data structure.
 // Hashes are field-value maps where both field and value are strings.
@@ -14,6 +17,34 @@ type HashCommand interface {
 	// Returns true if the field exists, false otherwise.
 	HExists(ctx context.Context, key string, field string) Result[bool]
 
+	// HExpire sets a timeout on fields of a hash using seconds.
+	// After the timeout expires, the fields will be automatically deleted, and the hash with its last field.
+	// Returns for each field -2 if the field does not exist, 1 if the timeout was set,
+	// or 2 if the field was deleted because the timeout is 0.
+	HExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HExpireNX sets a timeout on fields of a hash only if they have no existing expiration.
+	// Returns the same codes as HExpire, or 0 for the fields whose timeout was not set.
+	HExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HExpireXX sets a timeout on fields of a hash only if they have an existing expiration.
+	// Returns the same codes as HExpire, or 0 for the fields whose timeout was not set.
+	HExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HExpireGT sets a timeout on fields of a hash only if the new expiration is greater than the current one.
+	// A field without expiration is treated as having an infinite timeout.
+	// Returns the same codes as HExpire, or 0 for the fields whose timeout was not set.
+	HExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HExpireLT sets a timeout on fields of a hash only if the new expiration is less than the current one.
+	// A field without expiration is treated as having an infinite timeout.
+	// Returns the same codes as HExpire, or 0 for the fields whose timeout was not set.
+	HExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HExpireAt sets an expiration timestamp on fields of a hash, in seconds.
+	// Returns the same codes as HExpire, 2 meaning the timestamp is in the past.
+	HExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) Result[[]int64]
+
 	// HGet returns the value of a field in a hash.
 	// Returns nil if the field does not exist.
 	HGet(ctx context.Context, key string, field string) Result[[]byte]
@@ -22,6 +53,11 @@ type HashCommand interface {
 	// Returns an empty map if the key does not exist.
 	HGetAll(ctx context.Context, key string) Result[map[string][]byte]
 
+	// HGetEx returns the values of fields in a hash and updates their expiration.
+	// A positive expiration sets the timeout of the fields, 0 removes it, and KeepTTL leaves it unchanged.
+	// For each field that does not exist, nil is returned in the corresponding position.
+	HGetEx(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[][]byte]
+
 	// HIncrBy increments the integer value of a hash field by the given number.
 	// If the field does not exist, it is set to 0 before performing the operation.
 	// Returns the value of the field after the increment.
@@ -50,6 +86,35 @@ type HashCommand interface {
 	// If a field already exists, its value is overwritten.
 	HMSet(ctx context.Context, key string, values map[string]any) StatusResult
 
+	// HPExpire sets a timeout on fields of a hash using milliseconds.
+	// Returns the same codes as HExpire.
+	HPExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HPExpireNX is like HExpireNX, using milliseconds.
+	HPExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HPExpireXX is like HExpireXX, using milliseconds.
+	HPExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HPExpireGT is like HExpireGT, using milliseconds.
+	HPExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HPExpireLT is like HExpireLT, using milliseconds.
+	HPExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64]
+
+	// HPExpireAt sets an expiration timestamp on fields of a hash, in milliseconds.
+	// Returns the same codes as HExpire, 2 meaning the timestamp is in the past.
+	HPExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) Result[[]int64]
+
+	// HPersist removes the expiration timeout from fields of a hash.
+	// Returns for each field -2 if the field does not exist, -1 if it has no expiration,
+	// or 1 if its timeout was removed.
+	HPersist(ctx context.Context, key string, fields ...string) Result[[]int64]
+
+	// HPTTL returns the remaining time to live of fields of a hash in milliseconds.
+	// Returns for each field -2 if the field does not exist, or -1 if it has no associated expiration.
+	HPTTL(ctx context.Context, key string, fields ...string) Result[[]time.Duration]
+
 	// HScan iterates over fields and values of a hash.
 	// cursor is the cursor to start iteration from (0 to start).
 	// match is a glob-style pattern to filter fields (empty string for no filter).
@@ -63,11 +128,21 @@ type HashCommand interface {
 	// Returns the number of fields that were added (not including updated fields).
 	HSet(ctx context.Context, key string, values map[string]any) Result[int64]
 
+	// HSetEx sets the values of fields in a hash together with their expiration.
+	// mode can be `FNX` to only set the fields if none of them exists, `FXX` if all of them exist, or empty.
+	// A positive expiration sets the timeout of the fields, 0 removes it, and KeepTTL leaves it unchanged.
+	// Returns true if the fields were set, false if mode prevented it.
+	HSetEx(ctx context.Context, key string, mode string, expiration time.Duration, values map[string]any) Result[bool]
+
 	// HSetNX sets the value of a field in a hash only if the field does not exist.
 	// If the field already exists, this operation has no effect.
 	// Returns true if the field was set, false if the field already existed.
 	HSetNX(ctx context.Context, key string, field string, value any) Result[bool]
 
+	// HTTL returns the remaining time to live of fields of a hash in seconds.
+	// Returns for each field -2 if the field does not exist, or -1 if it has no associated expiration.
+	HTTL(ctx context.Context, key string, fields ...string) Result[[]time.Duration]
+
 	// HVals returns all values in a hash.
 	// Returns an empty slice if the key does not exist.
 	HVals(ctx context.Context, key string) Result[[][]byte]
@@ -0,0 +1,22 @@
+package caches
+
+import (
+	"context"
+)
+
+// HyperLogLogCommand defines operations on HyperLogLogs, which estimate the number of unique elements
+// added to them with a standard error of 0.81% in at most 12KB, stored as string values.
+type HyperLogLogCommand interface {
+	// PFAdd adds the elements to the HyperLogLog stored at key, creating it if the key does not exist.
+	// Returns 1 if the estimated cardinality changed or the key was created, 0 otherwise.
+	PFAdd(ctx context.Context, key string, els ...any) Result[int64]
+
+	// PFCount returns the estimated number of unique elements added to the HyperLogLogs stored at keys,
+	// counting the union of the HyperLogLogs when there are several keys.
+	// Returns 0 if no key exists, and an error if a key does not hold a HyperLogLog.
+	PFCount(ctx context.Context, keys ...string) Result[int64]
+
+	// PFMerge merges the HyperLogLogs stored at keys into the one stored at dest,
+	// creating dest if it does not exist.
+	PFMerge(ctx context.Context, dest string, keys ...string) StatusResult
+}
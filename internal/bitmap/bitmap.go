@@ -0,0 +1,471 @@
+// Package bitmap implements the Redis bit operations on string values,
+// for providers storing strings as plain bytes.
+//
+// The functions never modify the slices they are given.
+package bitmap
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// MaxOffset is the number of bits of the largest string value, as in Redis.
+const MaxOffset = 1 << 32
+
+var (
+	ErrOffset   = errors.New("bitmap: bit offset is not an integer or out of range")
+	ErrBit      = errors.New("bitmap: bit is not an integer or out of range")
+	ErrType     = errors.New("bitmap: invalid bitfield type, use something like i16 u8, signed up to 64 bits and unsigned up to 63 bits")
+	ErrValue    = errors.New("bitmap: value is not an integer or out of range")
+	ErrOverflow = errors.New("bitmap: invalid OVERFLOW type, use WRAP, SAT or FAIL")
+	ErrSyntax   = errors.New("bitmap: syntax error")
+	ErrNotArity = errors.New("bitmap: BITOP NOT must be called with a single source key")
+)
+
+// CheckOffset returns ErrOffset if offset is not a valid bit offset.
+func CheckOffset(offset int64) error {
+	if offset < 0 || offset >= MaxOffset {
+		return ErrOffset
+	}
+	return nil
+}
+
+// grow returns a copy of b at least n bytes long.
+func grow(b []byte, n int64) []byte {
+	out := make([]byte, max(int64(len(b)), n))
+	copy(out, b)
+	return out
+}
+
+// GetBit returns the bit at offset, 0 beyond the end of b.
+func GetBit(b []byte, offset int64) int64 {
+	i := offset >> 3
+	if i >= int64(len(b)) {
+		return 0
+	}
+	return int64(b[i]>>(7-offset&7)) & 1
+}
+
+// SetBit returns a copy of b with the bit at offset set to value, grown as needed,
+// and the previous bit.
+func SetBit(b []byte, offset int64, value int) ([]byte, int64, error) {
+	if err := CheckOffset(offset); err != nil {
+		return nil, 0, err
+	}
+	if value != 0 && value != 1 {
+		return nil, 0, ErrBit
+	}
+
+	prev := GetBit(b, offset)
+	out := grow(b, offset>>3+1)
+	mask := byte(1) << (7 - offset&7)
+	if value == 1 {
+		out[offset>>3] |= mask
+	} else {
+		out[offset>>3] &^= mask
+	}
+	return out, prev, nil
+}
+
+// ParseUnit reports whether the unit of a range, BYTE, BIT or empty for BYTE, is BIT.
+func ParseUnit(unit string) (bool, error) {
+	switch strings.ToUpper(unit) {
+	case "", "BYTE":
+		return false, nil
+	case "BIT":
+		return true, nil
+	}
+	return false, ErrSyntax
+}
+
+// bitRange converts a Redis start and end, which can be negative, to bit offsets.
+// Returns false if the range is empty.
+func bitRange(b []byte, start, end int64, bitUnit bool) (int64, int64, bool) {
+	total := int64(len(b))
+	if bitUnit {
+		total *= 8
+	}
+
+	if start < 0 {
+		start += total
+	}
+	if end < 0 {
+		end += total
+	}
+	start, end = max(start, 0), max(end, 0)
+	end = min(end, total-1)
+	if start > end {
+		return 0, 0, false
+	}
+
+	if bitUnit {
+		return start, end, true
+	}
+	return start * 8, end*8 + 7, true
+}
+
+// Count returns the number of bits set to 1 between start and end,
+// counted in bytes, or in bits when bitUnit is set.
+func Count(b []byte, start, end int64, bitUnit bool) int64 {
+	if start < 0 && end < 0 && start > end {
+		return 0
+	}
+
+	first, last, ok := bitRange(b, start, end, bitUnit)
+	if !ok {
+		return 0
+	}
+
+	var n int64
+	for i := first; i <= last; {
+		if i&7 == 0 && i+7 <= last {
+			n += int64(bits.OnesCount8(b[i>>3]))
+			i += 8
+			continue
+		}
+		n += GetBit(b, i)
+		i++
+	}
+	return n
+}
+
+// Pos returns the position of the first bit set to bit between start and end,
+// counted in bytes, or in bits when bitUnit is set, and -1 if there is none.
+// When looking for a clear bit without an end, the string is considered padded with zeros.
+func Pos(b []byte, bit, start, end int64, hasEnd, bitUnit bool) (int64, error) {
+	if bit != 0 && bit != 1 {
+		return 0, ErrBit
+	}
+
+	first, last, ok := bitRange(b, start, end, bitUnit)
+	if !ok {
+		return -1, nil
+	}
+
+	for i := first; i <= last; i++ {
+		if GetBit(b, i) == bit {
+			return i, nil
+		}
+	}
+	if bit == 0 && !hasEnd {
+		return last + 1, nil
+	}
+	return -1, nil
+}
+
+// Op returns the result of the bitwise operation op, AND, OR, XOR or NOT, on srcs.
+// Shorter sources are considered padded with zeros.
+func Op(op string, srcs [][]byte) ([]byte, error) {
+	op = strings.ToUpper(op)
+	switch op {
+	case "AND", "OR", "XOR":
+	case "NOT":
+		if len(srcs) != 1 {
+			return nil, ErrNotArity
+		}
+	default:
+		return nil, ErrSyntax
+	}
+
+	var n int
+	for _, src := range srcs {
+		n = max(n, len(src))
+	}
+
+	out := make([]byte, n)
+	for i := range out {
+		var v byte
+		for j, src := range srcs {
+			var c byte
+			if i < len(src) {
+				c = src[i]
+			}
+
+			switch {
+			case j == 0:
+				v = c
+			case op == "AND":
+				v &= c
+			case op == "OR":
+				v |= c
+			case op == "XOR":
+				v ^= c
+			}
+		}
+		if op == "NOT" {
+			v = ^v
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// Overflow is the overflow behavior of the BITFIELD SET and INCRBY operations.
+type Overflow int
+
+const (
+	OverflowWrap Overflow = iota
+	OverflowSat
+	OverflowFail
+)
+
+// Field is a BITFIELD GET, SET or INCRBY operation.
+type Field struct {
+	Op       string
+	Signed   bool
+	Bits     int
+	Offset   int64
+	Value    int64
+	Overflow Overflow
+}
+
+// toString formats a BITFIELD argument.
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseType parses a field type such as i8 or u16.
+func parseType(s string) (bool, int, error) {
+	if len(s) < 2 || (s[0] != 'i' && s[0] != 'I' && s[0] != 'u' && s[0] != 'U') {
+		return false, 0, ErrType
+	}
+
+	signed := s[0] == 'i' || s[0] == 'I'
+	n, err := strconv.Atoi(s[1:])
+	if err != nil || n < 1 || (signed && n > 64) || (!signed && n > 63) {
+		return false, 0, ErrType
+	}
+	return signed, n, nil
+}
+
+// parseOffset parses a field offset, multiplied by the field width when prefixed with #.
+func parseOffset(s string, width int) (int64, error) {
+	mul := int64(1)
+	if strings.HasPrefix(s, "#") {
+		s, mul = s[1:], int64(width)
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 || n > (MaxOffset-int64(width))/mul {
+		return 0, ErrOffset
+	}
+	return n * mul, nil
+}
+
+// ParseFields parses the arguments of BITFIELD.
+func ParseFields(args []any) ([]Field, error) {
+	var fields []Field
+	overflow := OverflowWrap
+
+	for i := 0; i < len(args); i++ {
+		op := strings.ToUpper(toString(args[i]))
+		arity := 2
+		switch op {
+		case "OVERFLOW":
+			arity = 1
+		case "SET", "INCRBY":
+			arity = 3
+		case "GET":
+		default:
+			return nil, ErrSyntax
+		}
+		if i+arity >= len(args) {
+			return nil, ErrSyntax
+		}
+
+		if op == "OVERFLOW" {
+			switch strings.ToUpper(toString(args[i+1])) {
+			case "WRAP":
+				overflow = OverflowWrap
+			case "SAT":
+				overflow = OverflowSat
+			case "FAIL":
+				overflow = OverflowFail
+			default:
+				return nil, ErrOverflow
+			}
+			i++
+			continue
+		}
+
+		f := Field{Op: op, Overflow: overflow}
+		var err error
+		if f.Signed, f.Bits, err = parseType(toString(args[i+1])); err != nil {
+			return nil, err
+		}
+		if f.Offset, err = parseOffset(toString(args[i+2]), f.Bits); err != nil {
+			return nil, err
+		}
+		if arity == 3 {
+			if f.Value, err = strconv.ParseInt(toString(args[i+3]), 10, 64); err != nil {
+				return nil, ErrValue
+			}
+		}
+
+		fields = append(fields, f)
+		i += arity
+	}
+	return fields, nil
+}
+
+// getField returns the unsigned value of the width bits at offset.
+func getField(b []byte, offset int64, width int) uint64 {
+	var v uint64
+	for j := 0; j < width; j++ {
+		v = v<<1 | uint64(GetBit(b, offset+int64(j)))
+	}
+	return v
+}
+
+// setField sets the width bits at offset to v, b being large enough.
+func setField(b []byte, offset int64, width int, v uint64) {
+	for j := 0; j < width; j++ {
+		i := offset + int64(j)
+		mask := byte(1) << (7 - i&7)
+		if v>>(width-1-j)&1 == 1 {
+			b[i>>3] |= mask
+		} else {
+			b[i>>3] &^= mask
+		}
+	}
+}
+
+// checkUnsigned returns the result of value + incr on width unsigned bits,
+// and false if it overflows with OverflowFail.
+func checkUnsigned(value uint64, incr int64, width int, overflow Overflow) (uint64, bool) {
+	maxVal := uint64(1)<<width - 1
+	maxIncr := int64(maxVal - value)
+	minIncr := -int64(value)
+
+	switch {
+	case value > maxVal || incr > maxIncr:
+		if overflow == OverflowSat {
+			return maxVal, true
+		}
+	case incr < 0 && incr < minIncr:
+		if overflow == OverflowSat {
+			return 0, true
+		}
+	default:
+		return value + uint64(incr), true
+	}
+
+	if overflow == OverflowFail {
+		return 0, false
+	}
+	return (value + uint64(incr)) & maxVal, true
+}
+
+// checkSigned returns the result of value + incr on width signed bits,
+// and false if it overflows with OverflowFail.
+func checkSigned(value, incr int64, width int, overflow Overflow) (int64, bool) {
+	maxVal := int64(1<<(width-1) - 1)
+	if width == 64 {
+		maxVal = 1<<63 - 1
+	}
+	minVal := -maxVal - 1
+	maxIncr := maxVal - value
+	minIncr := minVal - value
+
+	switch {
+	case value > maxVal || (width != 64 && incr > maxIncr) || (value >= 0 && incr > 0 && incr > maxIncr):
+		if overflow == OverflowSat {
+			return maxVal, true
+		}
+	case value < minVal || (width != 64 && incr < minIncr) || (value < 0 && incr < 0 && incr < minIncr):
+		if overflow == OverflowSat {
+			return minVal, true
+		}
+	default:
+		return value + incr, true
+	}
+
+	if overflow == OverflowFail {
+		return 0, false
+	}
+	return signExtend(uint64(value)+uint64(incr), width), true
+}
+
+// signExtend interprets the low width bits of v as a signed integer.
+func signExtend(v uint64, width int) int64 {
+	if width < 64 {
+		if v&(1<<(width-1)) != 0 {
+			v |= ^uint64(0) << width
+		} else {
+			v &^= ^uint64(0) << width
+		}
+	}
+	return int64(v)
+}
+
+// ApplyFields runs the BITFIELD operations fields on b.
+// Returns the updated copy of b, grown to fit every SET and INCRBY operation even when it fails,
+// or nil if there are only GET operations, and the result of each operation,
+// which is nil when it failed with OverflowFail.
+func ApplyFields(b []byte, fields []Field) ([]byte, []*int64) {
+	var size int64 = -1
+	for _, f := range fields {
+		if f.Op != "GET" {
+			size = max(size, (f.Offset+int64(f.Bits)+7)>>3)
+		}
+	}
+
+	out := b
+	if size >= 0 {
+		out = grow(b, size)
+	}
+
+	res := make([]*int64, len(fields))
+	for i, f := range fields {
+		raw := getField(out, f.Offset, f.Bits)
+		cur := int64(raw)
+		if f.Signed {
+			cur = signExtend(raw, f.Bits)
+		}
+
+		var val, stored int64
+		var ok bool
+		switch {
+		case f.Op == "GET":
+			val, ok = cur, true
+		case f.Signed && f.Op == "SET":
+			stored, ok = checkSigned(f.Value, 0, f.Bits, f.Overflow)
+			val = cur
+		case f.Signed:
+			stored, ok = checkSigned(cur, f.Value, f.Bits, f.Overflow)
+			val = stored
+		case f.Op == "SET":
+			var u uint64
+			u, ok = checkUnsigned(uint64(f.Value), 0, f.Bits, f.Overflow)
+			stored, val = int64(u), cur
+		default:
+			var u uint64
+			u, ok = checkUnsigned(raw, f.Value, f.Bits, f.Overflow)
+			stored = int64(u)
+			val = stored
+		}
+		if !ok {
+			continue
+		}
+
+		if f.Op != "GET" {
+			setField(out, f.Offset, f.Bits, uint64(stored))
+		}
+		res[i] = &val
+	}
+
+	if size < 0 {
+		return nil, res
+	}
+	return out, res
+}
@@ -7,3 +7,7 @@ func (e CachesError) Error() string {
 }
 
 const Nil = CachesError("caches: nil")
+
+const TxFailed = CachesError("caches: transaction failed")
+
+const NoScript = CachesError("caches: no matching script")
@@ -0,0 +1,262 @@
+// Package geo implements the geospatial indexes of Redis on top of sorted sets,
+// for providers without native geo commands.
+//
+// Positions are stored as the 52 bit geohash scores of Redis, and distances computed the same way,
+// so that the results of a provider match the ones of Redis.
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/rockcookies/go-caches"
+)
+
+const (
+	lonMin = -180.0
+	lonMax = 180.0
+	latMin = -85.05112878
+	latMax = 85.05112878
+
+	// step is the number of bits of each coordinate in a score.
+	step = 26
+
+	// earthRadius is the radius of the Earth in meters used by Redis.
+	earthRadius = 6372797.560856
+
+	base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+var (
+	ErrUnit        = errors.New("geo: unsupported unit provided. please use M, KM, FT, MI")
+	ErrMember      = errors.New("geo: could not decode requested zset member")
+	ErrShape       = errors.New("geo: height, width or radius cannot be negative")
+	ErrInvalidSort = errors.New("geo: sort must be ASC or DESC")
+	ErrCount       = errors.New("geo: COUNT must be > 0")
+)
+
+// interleave spreads the bits of x and y, x on the even bits and y on the odd ones.
+func interleave(x, y uint32) uint64 {
+	spread := func(v uint64) uint64 {
+		v = (v | v<<16) & 0x0000ffff0000ffff
+		v = (v | v<<8) & 0x00ff00ff00ff00ff
+		v = (v | v<<4) & 0x0f0f0f0f0f0f0f0f
+		v = (v | v<<2) & 0x3333333333333333
+		v = (v | v<<1) & 0x5555555555555555
+		return v
+	}
+	return spread(uint64(x)) | spread(uint64(y))<<1
+}
+
+// deinterleave reverses interleave.
+func deinterleave(v uint64) (uint32, uint32) {
+	squash := func(v uint64) uint32 {
+		v &= 0x5555555555555555
+		v = (v | v>>1) & 0x3333333333333333
+		v = (v | v>>2) & 0x0f0f0f0f0f0f0f0f
+		v = (v | v>>4) & 0x00ff00ff00ff00ff
+		v = (v | v>>8) & 0x0000ffff0000ffff
+		v = (v | v>>16) & 0x00000000ffffffff
+		return uint32(v)
+	}
+	return squash(v), squash(v >> 1)
+}
+
+// encode returns the geohash of lon, lat within the given latitude range.
+func encode(lon, lat, minLat, maxLat float64) uint64 {
+	latOffset := (lat - minLat) / (maxLat - minLat) * (1 << step)
+	lonOffset := (lon - lonMin) / (lonMax - lonMin) * (1 << step)
+	return interleave(uint32(latOffset), uint32(lonOffset))
+}
+
+// CheckCoord returns an error if lon, lat cannot be indexed.
+func CheckCoord(lon, lat float64) error {
+	if lon < lonMin || lon > lonMax || lat < latMin || lat > latMax {
+		return fmt.Errorf("geo: invalid longitude,latitude pair %f,%f", lon, lat)
+	}
+	return nil
+}
+
+// Score returns the sorted set score of lon, lat.
+func Score(lon, lat float64) (float64, error) {
+	if err := CheckCoord(lon, lat); err != nil {
+		return 0, err
+	}
+	return float64(encode(lon, lat, latMin, latMax)), nil
+}
+
+// Decode returns the position of a score, the center of its geohash cell.
+func Decode(score float64) (lon, lat float64) {
+	ilat, ilon := deinterleave(uint64(score))
+	center := func(i uint32, min, max float64) float64 {
+		lo := min + float64(i)/(1<<step)*(max-min)
+		hi := min + float64(i+1)/(1<<step)*(max-min)
+		return math.Max(min, math.Min(max, (lo+hi)/2))
+	}
+	return center(ilon, lonMin, lonMax), center(ilat, latMin, latMax)
+}
+
+// Hash returns the standard 11 characters geohash of a score.
+func Hash(score float64) string {
+	lon, lat := Decode(score)
+	bits := encode(lon, lat, -90, 90)
+
+	var b strings.Builder
+	for i := 0; i < 11; i++ {
+		idx := uint64(0)
+		if i < 10 {
+			idx = bits >> (52 - (i+1)*5) & 0x1f
+		}
+		b.WriteByte(base32[idx])
+	}
+	return b.String()
+}
+
+// latDistance returns the distance in meters between two latitudes.
+func latDistance(lat1, lat2 float64) float64 {
+	return earthRadius * math.Abs(rad(lat2)-rad(lat1))
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Distance returns the distance in meters between two positions, with the haversine formula.
+func Distance(lon1, lat1, lon2, lat2 float64) float64 {
+	v := math.Sin((rad(lon2) - rad(lon1)) / 2)
+	if v == 0 {
+		return latDistance(lat1, lat2)
+	}
+
+	u := math.Sin((rad(lat2) - rad(lat1)) / 2)
+	a := u*u + math.Cos(rad(lat1))*math.Cos(rad(lat2))*v*v
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// Unit returns the number of meters of a unit, km when empty.
+func Unit(unit string) (float64, error) {
+	switch strings.ToLower(unit) {
+	case "m":
+		return 1, nil
+	case "", "km":
+		return 1000, nil
+	case "ft":
+		return 0.3048, nil
+	case "mi":
+		return 1609.34, nil
+	}
+	return 0, ErrUnit
+}
+
+// Round rounds a distance as Redis replies it.
+func Round(dist float64) float64 {
+	return math.Round(dist*1e4) / 1e4
+}
+
+// Point is a member of a geo sorted set.
+type Point struct {
+	Member string
+	Score  float64
+
+	// Lon and Lat are decoded from Score.
+	Lon, Lat float64
+
+	// Dist is the distance to the center of a search, in the unit of its shape.
+	Dist float64
+}
+
+// NewPoint returns the point of a member.
+func NewPoint(member string, score float64) Point {
+	lon, lat := Decode(score)
+	return Point{Member: member, Score: score, Lon: lon, Lat: lat}
+}
+
+// Search returns the points within the shape of q, centered on lon, lat,
+// sorted and limited as required by q.
+// The points are expected in the order of the sorted set, which is kept when q has no sort order.
+func Search(points []Point, lon, lat float64, q *caches.GeoSearchQuery) ([]Point, error) {
+	byBox := q.Radius <= 0
+	unit := q.RadiusUnit
+	if byBox {
+		unit = q.BoxUnit
+	}
+	conv, err := Unit(unit)
+	if err != nil {
+		return nil, err
+	}
+	if q.Radius < 0 || q.BoxWidth < 0 || q.BoxHeight < 0 {
+		return nil, ErrShape
+	}
+	if q.Count < 0 {
+		return nil, ErrCount
+	}
+
+	sortOrder := strings.ToUpper(q.Sort)
+	switch sortOrder {
+	case "", "ASC", "DESC":
+	default:
+		return nil, ErrInvalidSort
+	}
+	if sortOrder == "" && q.Count > 0 && !q.CountAny {
+		sortOrder = "ASC"
+	}
+
+	var found []Point
+	for _, pt := range points {
+		var dist float64
+		if byBox {
+			if latDistance(pt.Lat, lat) > q.BoxHeight*conv/2 {
+				continue
+			}
+			if Distance(pt.Lon, pt.Lat, lon, pt.Lat) > q.BoxWidth*conv/2 {
+				continue
+			}
+			dist = Distance(lon, lat, pt.Lon, pt.Lat)
+		} else {
+			dist = Distance(lon, lat, pt.Lon, pt.Lat)
+			if dist > q.Radius*conv {
+				continue
+			}
+		}
+
+		pt.Dist = dist / conv
+		found = append(found, pt)
+		if q.CountAny && q.Count > 0 && len(found) == q.Count {
+			break
+		}
+	}
+
+	if sortOrder != "" {
+		sort.SliceStable(found, func(i, j int) bool {
+			if sortOrder == "DESC" {
+				return found[i].Dist > found[j].Dist
+			}
+			return found[i].Dist < found[j].Dist
+		})
+	}
+	if q.Count > 0 && len(found) > q.Count {
+		found = found[:q.Count]
+	}
+	return found, nil
+}
+
+// Locations returns the locations of points with the fields required by q.
+func Locations(points []Point, q *caches.GeoSearchLocationQuery) []caches.GeoLocation {
+	locs := make([]caches.GeoLocation, len(points))
+	for i, pt := range points {
+		locs[i].Name = pt.Member
+		if q.WithCoord {
+			locs[i].Longitude, locs[i].Latitude = pt.Lon, pt.Lat
+		}
+		if q.WithDist {
+			locs[i].Dist = Round(pt.Dist)
+		}
+		if q.WithHash {
+			locs[i].GeoHash = int64(pt.Score)
+		}
+	}
+	return locs
+}
@@ -0,0 +1,85 @@
+package glob
+
+// Match reports whether s matches the Redis glob-style pattern.
+//
+// Supported syntax: `*`, `?`, `[abc]`, `[^abc]`, `[a-z]` and `\` to escape special characters.
+func Match(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if Match(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+			s = s[1:]
+			pattern = pattern[1:]
+		case '[':
+			if len(s) == 0 {
+				return false
+			}
+			end := 1
+			negate := len(pattern) > 1 && pattern[1] == '^'
+			if negate {
+				end++
+			}
+			matched := false
+			for end < len(pattern) && pattern[end] != ']' {
+				switch {
+				case pattern[end] == '\\' && end+1 < len(pattern):
+					end++
+					if pattern[end] == s[0] {
+						matched = true
+					}
+				case end+2 < len(pattern) && pattern[end+1] == '-':
+					lo, hi := pattern[end], pattern[end+2]
+					if lo > hi {
+						lo, hi = hi, lo
+					}
+					if s[0] >= lo && s[0] <= hi {
+						matched = true
+					}
+					end += 2
+				case pattern[end] == s[0]:
+					matched = true
+				}
+				end++
+			}
+			if negate {
+				matched = !matched
+			}
+			if !matched {
+				return false
+			}
+			if end < len(pattern) {
+				end++
+			}
+			s = s[1:]
+			pattern = pattern[end:]
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+			s = s[1:]
+			pattern = pattern[1:]
+		}
+	}
+
+	return len(s) == 0
+}
@@ -0,0 +1,303 @@
+// Package hll implements the HyperLogLog of Redis, for providers storing it as a plain string.
+//
+// The elements are hashed and the registers encoded as in Redis, so that the counts of a provider
+// match the ones of Redis for the same elements, and its values can be copied to Redis.
+package hll
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	precision = 14
+	registers = 1 << precision
+	regMask   = registers - 1
+	regBits   = 6
+	regMax    = 1<<regBits - 1
+	q         = 64 - precision
+
+	headerSize = 16
+	denseSize  = headerSize + (registers*regBits+7)/8
+
+	encodingDense  = 0
+	encodingSparse = 1
+
+	// sparseMaxBytes is the largest sparse encoding before switching to the dense one,
+	// the default hll-sparse-max-bytes of Redis.
+	sparseMaxBytes = 3000
+
+	sparseValMax    = 32
+	sparseValMaxLen = 4
+	sparseZeroMax   = 64
+	sparseXZeroMax  = registers
+
+	alphaInf = 0.721347520444481703680
+)
+
+var magic = []byte("HYLL")
+
+// ErrInvalid is returned when a string value is not a valid HyperLogLog.
+var ErrInvalid = errors.New("hll: key is not a valid HyperLogLog string value")
+
+// HLL is a HyperLogLog with the registers of Redis.
+type HLL struct {
+	regs [registers]uint8
+}
+
+// New returns an empty HLL.
+func New() *HLL {
+	return &HLL{}
+}
+
+// Parse decodes an HLL stored in the dense or sparse encoding of Redis.
+func Parse(b []byte) (*HLL, error) {
+	if len(b) < headerSize || string(b[:4]) != string(magic) {
+		return nil, ErrInvalid
+	}
+
+	h := New()
+	switch b[4] {
+	case encodingDense:
+		if len(b) != denseSize {
+			return nil, ErrInvalid
+		}
+		regs := b[headerSize:]
+		for i := range h.regs {
+			h.regs[i] = getDense(regs, i)
+		}
+	case encodingSparse:
+		idx := 0
+		for p := headerSize; p < len(b); p++ {
+			op := b[p]
+			switch {
+			case op&0xc0 == 0x00:
+				// ZERO: 00xxxxxx
+				idx += int(op&0x3f) + 1
+			case op&0xc0 == 0x40:
+				// XZERO: 01xxxxxx yyyyyyyy
+				if p+1 >= len(b) {
+					return nil, ErrInvalid
+				}
+				p++
+				idx += int(op&0x3f)<<8 | int(b[p]) + 1
+			default:
+				// VAL: 1vvvvvxx
+				val := op>>2&0x1f + 1
+				n := int(op&0x03) + 1
+				if idx+n > registers {
+					return nil, ErrInvalid
+				}
+				for j := 0; j < n; j++ {
+					h.regs[idx+j] = val
+				}
+				idx += n
+			}
+			if idx > registers {
+				return nil, ErrInvalid
+			}
+		}
+		if idx != registers {
+			return nil, ErrInvalid
+		}
+	default:
+		return nil, ErrInvalid
+	}
+	return h, nil
+}
+
+// getDense returns register i of the packed 6 bit registers.
+func getDense(regs []byte, i int) uint8 {
+	pos := i * regBits / 8
+	fb := uint(i * regBits & 7)
+	v := regs[pos] >> fb
+	if pos+1 < len(regs) {
+		v |= regs[pos+1] << (8 - fb)
+	}
+	return v & regMax
+}
+
+// setDense sets register i of the packed 6 bit registers.
+func setDense(regs []byte, i int, v uint8) {
+	pos := i * regBits / 8
+	fb := uint(i * regBits & 7)
+	regs[pos] &^= regMax << fb
+	regs[pos] |= v << fb
+	if pos+1 < len(regs) {
+		regs[pos+1] &^= regMax >> (8 - fb)
+		regs[pos+1] |= v >> (8 - fb)
+	}
+}
+
+// murmurHash64A is the hash function of the Redis HyperLogLog.
+func murmurHash64A(key []byte, seed uint64) uint64 {
+	const m = 0xc6a4a7935bd1e995
+	const r = 47
+
+	h := seed ^ uint64(len(key))*m
+	for len(key) >= 8 {
+		k := binary.LittleEndian.Uint64(key)
+		k *= m
+		k ^= k >> r
+		k *= m
+		h ^= k
+		h *= m
+		key = key[8:]
+	}
+
+	if len(key) > 0 {
+		for i := len(key) - 1; i >= 0; i-- {
+			h ^= uint64(key[i]) << (8 * i)
+		}
+		h *= m
+	}
+
+	h ^= h >> r
+	h *= m
+	h ^= h >> r
+	return h
+}
+
+// patLen returns the register of elem and the length of the run of zeros of its hash, plus one.
+func patLen(elem []byte) (int, uint8) {
+	hash := murmurHash64A(elem, 0xadc83b19)
+	idx := int(hash & regMask)
+	hash >>= precision
+	hash |= 1 << q
+
+	count := uint8(1)
+	for bit := uint64(1); hash&bit == 0; bit <<= 1 {
+		count++
+	}
+	return idx, count
+}
+
+// Add adds elem to the HLL and reports whether a register changed.
+func (h *HLL) Add(elem []byte) bool {
+	idx, count := patLen(elem)
+	if count > h.regs[idx] {
+		h.regs[idx] = count
+		return true
+	}
+	return false
+}
+
+// Merge merges the registers of o into h.
+func (h *HLL) Merge(o *HLL) {
+	for i, v := range o.regs {
+		h.regs[i] = max(h.regs[i], v)
+	}
+}
+
+// sigma is the sigma function of the estimator of Ertl used by Redis.
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if prev == z {
+			return z
+		}
+	}
+}
+
+// tau is the tau function of the estimator of Ertl used by Redis.
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= math.Pow(1-x, 2) * y
+		if prev == z {
+			return z / 3
+		}
+	}
+}
+
+// Count returns the estimated cardinality of the HLL.
+func (h *HLL) Count() int64 {
+	var histo [64]int
+	for _, v := range h.regs {
+		histo[v]++
+	}
+
+	m := float64(registers)
+	z := m * tau((m-float64(histo[q+1]))/m)
+	for j := q; j >= 1; j-- {
+		z += float64(histo[j])
+		z *= 0.5
+	}
+	z += m * sigma(float64(histo[0])/m)
+	return int64(math.Round(alphaInf * m * m / z))
+}
+
+// header returns the header of an encoding, the cached cardinality being marked as invalid.
+func header(encoding byte, size int) []byte {
+	b := make([]byte, headerSize, size)
+	copy(b, magic)
+	b[4] = encoding
+	b[headerSize-1] = 1 << 7
+	return b
+}
+
+// Bytes encodes the HLL in the sparse encoding of Redis when it is small enough, and the dense one otherwise.
+func (h *HLL) Bytes() []byte {
+	if b := h.sparse(); b != nil {
+		return b
+	}
+
+	b := header(encodingDense, denseSize)[:denseSize]
+	for i, v := range h.regs {
+		setDense(b[headerSize:], i, v)
+	}
+	return b
+}
+
+// sparse returns the sparse encoding of the HLL, or nil if it does not fit.
+func (h *HLL) sparse() []byte {
+	b := header(encodingSparse, headerSize+16)
+	for i := 0; i < registers; {
+		v := h.regs[i]
+		if v > sparseValMax {
+			return nil
+		}
+
+		n := 1
+		for i+n < registers && h.regs[i+n] == v {
+			n++
+		}
+		i += n
+
+		for n > 0 {
+			switch {
+			case v != 0:
+				l := min(n, sparseValMaxLen)
+				b = append(b, 0x80|(v-1)<<2|byte(l-1))
+				n -= l
+			case n > sparseZeroMax:
+				l := min(n, sparseXZeroMax)
+				b = append(b, 0x40|byte((l-1)>>8), byte(l-1))
+				n -= l
+			default:
+				b = append(b, byte(n-1))
+				n = 0
+			}
+		}
+		if len(b) > sparseMaxBytes {
+			return nil
+		}
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+// Package str implements the Redis string range and LCS commands,
+// for providers storing strings as plain bytes.
+//
+// The functions never modify the slices they are given.
+package str
+
+import (
+	"errors"
+
+	"github.com/rockcookies/go-caches"
+)
+
+// MaxLen is the length of the largest string value, as in Redis.
+const MaxLen = 512 << 20
+
+var (
+	ErrOffset = errors.New("str: offset is out of range")
+	ErrLen    = errors.New("str: string exceeds maximum allowed size (proto-max-bulk-len)")
+	ErrLenIdx = errors.New("str: if you want both the length and indexes, please just use IDX")
+)
+
+// CheckLen returns ErrLen if a string of n bytes exceeds MaxLen.
+func CheckLen(n int64) error {
+	if n > MaxLen {
+		return ErrLen
+	}
+	return nil
+}
+
+// Append returns a copy of b followed by value.
+func Append(b []byte, value string) ([]byte, error) {
+	if err := CheckLen(int64(len(b)) + int64(len(value))); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(b)+len(value))
+	out = append(out, b...)
+	return append(out, value...), nil
+}
+
+// GetRange returns the bytes of b between start and end, both included.
+// Negative offsets are counted from the end of b.
+func GetRange(b []byte, start, end int64) []byte {
+	n := int64(len(b))
+	if start < 0 && end < 0 && start > end {
+		return []byte{}
+	}
+	if start < 0 {
+		start += n
+	}
+	if end < 0 {
+		end += n
+	}
+	start = max(start, 0)
+	end = min(max(end, 0), n-1)
+	if n == 0 || start > end {
+		return []byte{}
+	}
+	return b[start : end+1 : end+1]
+}
+
+// SetRange returns a copy of b overwritten from offset with value, padded with zero bytes as needed.
+func SetRange(b []byte, offset int64, value string) ([]byte, error) {
+	if offset < 0 {
+		return nil, ErrOffset
+	}
+	if err := CheckLen(offset + int64(len(value))); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, max(int64(len(b)), offset+int64(len(value))))
+	copy(out, b)
+	copy(out[offset:], value)
+	return out, nil
+}
+
+// LCS returns the longest common subsequence of a and b with the options of q.
+// The matching ranges are found by walking the strings backwards, so they are listed as Redis does.
+func LCS(a, b []byte, q *caches.LCSQuery) (*caches.LCSMatch, error) {
+	if q.Len && q.Idx {
+		return nil, ErrLenIdx
+	}
+
+	// lengths[i][j] is the length of the LCS of a[:i] and b[:j]
+	alen, blen := len(a), len(b)
+	lengths := make([][]int, alen+1)
+	for i := range lengths {
+		lengths[i] = make([]int, blen+1)
+	}
+	for i := 1; i <= alen; i++ {
+		for j := 1; j <= blen; j++ {
+			if a[i-1] == b[j-1] {
+				lengths[i][j] = lengths[i-1][j-1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i-1][j], lengths[i][j-1])
+			}
+		}
+	}
+
+	size := lengths[alen][blen]
+	if q.Len {
+		return &caches.LCSMatch{Len: int64(size)}, nil
+	}
+
+	match := make([]byte, size)
+	var matches []caches.LCSMatchedPosition
+
+	// The current range is reset by setting aStart to alen
+	aStart, aEnd, bStart, bEnd := alen, 0, 0, 0
+	for i, j, idx := alen, blen, size; i > 0 && j > 0; {
+		emit := false
+		if a[i-1] == b[j-1] {
+			match[idx-1] = a[i-1]
+			if aStart == alen {
+				aStart, aEnd, bStart, bEnd = i-1, i-1, j-1, j-1
+			} else if aStart == i && bStart == j {
+				aStart--
+				bStart--
+			} else {
+				emit = true
+			}
+			// The range ends with the first byte of either string
+			if aStart == 0 || bStart == 0 {
+				emit = true
+			}
+			idx--
+			i--
+			j--
+		} else {
+			if lengths[i-1][j] > lengths[i][j-1] {
+				i--
+			} else {
+				j--
+			}
+			if aStart != alen {
+				emit = true
+			}
+		}
+
+		if emit {
+			matchLen := aEnd - aStart + 1
+			if q.Idx && (q.MinMatchLen == 0 || matchLen >= q.MinMatchLen) {
+				pos := caches.LCSMatchedPosition{
+					Key1: caches.LCSPosition{Start: int64(aStart), End: int64(aEnd)},
+					Key2: caches.LCSPosition{Start: int64(bStart), End: int64(bEnd)},
+				}
+				if q.WithMatchLen {
+					pos.MatchLen = int64(matchLen)
+				}
+				matches = append(matches, pos)
+			}
+			aStart = alen
+		}
+	}
+
+	if q.Idx {
+		if matches == nil {
+			matches = []caches.LCSMatchedPosition{}
+		}
+		return &caches.LCSMatch{Matches: matches, Len: int64(size)}, nil
+	}
+	return &caches.LCSMatch{MatchString: string(match)}, nil
+}
@@ -18,8 +18,13 @@ type KeyScanResult struct {
 // This includes key creation, deletion, expiration, scanning, and metadata operations.
 type KeyCommand interface {
 	// DBSize returns the number of keys in the current database.
+	// When the provider has a key prefix, only the keys under that prefix are counted.
 	DBSize(ctx context.Context) Result[int64]
 
+	// DBSizePrefix returns the number of keys starting with prefix.
+	// The provider key prefix is prepended, so an empty prefix counts all the keys of the provider.
+	DBSizePrefix(ctx context.Context, prefix string) Result[int64]
+
 	// Del deletes one or more keys.
 	// Non-existing keys are ignored.
 	// Returns the number of keys that were deleted.
@@ -76,9 +81,16 @@ type KeyCommand interface {
 	PExpireTime(ctx context.Context, key string) Result[time.Duration]
 
 	// FlushAll deletes all keys from the current database.
+	// When the provider has a key prefix, only the keys under that prefix are deleted.
 	// This operation is irreversible.
 	FlushAll(ctx context.Context) StatusResult
 
+	// FlushPrefix deletes all keys starting with prefix.
+	// The provider key prefix is prepended, so an empty prefix deletes all the keys of the provider.
+	// Returns the number of keys that were deleted.
+	// This operation is irreversible.
+	FlushPrefix(ctx context.Context, prefix string) Result[int64]
+
 	// Persist removes the expiration timeout from a key, making it persistent.
 	// Returns true if the timeout was removed, false if the key does not exist or has no expiration.
 	Persist(ctx context.Context, key string) Result[bool]
@@ -1,6 +1,9 @@
 package caches
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // LInsertPosition represents the position for list insert operation.
 type LInsertPosition string
@@ -12,9 +15,67 @@ const (
 	LInsertAfter LInsertPosition = "AFTER"
 )
 
+// ListDirection designates an end of a list.
+type ListDirection string
+
+const (
+	// ListLeft designates the head of the list.
+	ListLeft ListDirection = "LEFT"
+	// ListRight designates the tail of the list.
+	ListRight ListDirection = "RIGHT"
+)
+
+// ListPopResult holds an element popped from one of several lists.
+type ListPopResult struct {
+	// Key is the list the element was popped from.
+	Key string
+	// Element is the popped element.
+	Element []byte
+}
+
+// ListMPopResult holds the elements popped from one of several lists.
+type ListMPopResult struct {
+	// Key is the list the elements were popped from.
+	Key string
+	// Elements are the popped elements.
+	Elements [][]byte
+}
+
+// LPosArgs represents the optional arguments of LPos and LPosCount.
+type LPosArgs struct {
+	// Rank selects the match to return: 1 is the first match, 2 the second and so on.
+	// Negative ranks search from the tail of the list. Zero is the same as 1.
+	Rank int64
+	// MaxLen limits the number of compared elements. Zero means no limit.
+	MaxLen int64
+}
+
 // ListCommand defines operations for Redis list data structure.
 // Lists are sequences of strings sorted by insertion order.
 type ListCommand interface {
+	// BLMove atomically pops an element from the srcpos end of the list stored at source,
+	// and pushes it to the destpos end of the list stored at destination.
+	// When source is empty, it blocks until an element is pushed, the timeout expires or ctx is done.
+	// A zero timeout blocks indefinitely. Returns Nil when the timeout expires.
+	BLMove(ctx context.Context, source, destination string, srcpos, destpos ListDirection, timeout time.Duration) Result[[]byte]
+
+	// BLPop removes and returns the first element of the first non-empty list among keys,
+	// which are checked in the given order.
+	// When all lists are empty, it blocks until an element is pushed, the timeout expires or ctx is done.
+	// A zero timeout blocks indefinitely. Returns Nil when the timeout expires.
+	BLPop(ctx context.Context, timeout time.Duration, keys ...string) Result[ListPopResult]
+
+	// BRPop removes and returns the last element of the first non-empty list among keys,
+	// which are checked in the given order.
+	// When all lists are empty, it blocks until an element is pushed, the timeout expires or ctx is done.
+	// A zero timeout blocks indefinitely. Returns Nil when the timeout expires.
+	BRPop(ctx context.Context, timeout time.Duration, keys ...string) Result[ListPopResult]
+
+	// BRPopLPush is the blocking variant of RPopLPush.
+	// When source is empty, it blocks until an element is pushed, the timeout expires or ctx is done.
+	// A zero timeout blocks indefinitely. Returns Nil when the timeout expires.
+	BRPopLPush(ctx context.Context, source, destination string, timeout time.Duration) Result[[]byte]
+
 	// LIndex returns the element at index in the list stored at key.
 	// The index is zero-based, so 0 means the first element, 1 the second element and so on.
 	// Negative indices can be used to designate elements starting at the tail of the list.
@@ -28,17 +89,38 @@ type ListCommand interface {
 	// If key does not exist, it is interpreted as an empty list and 0 is returned.
 	LLen(ctx context.Context, key string) Result[int64]
 
+	// LMove atomically pops an element from the srcpos end of the list stored at source,
+	// and pushes it to the destpos end of the list stored at destination.
+	// Returns Nil when source does not exist.
+	LMove(ctx context.Context, source, destination string, srcpos, destpos ListDirection) Result[[]byte]
+
+	// LMPop pops up to count elements from the direction end of the first non-empty list among keys.
+	// Returns Nil when all lists are empty.
+	LMPop(ctx context.Context, direction ListDirection, count int64, keys ...string) Result[ListMPopResult]
+
 	// LPop removes and returns the first element of the list stored at key.
 	LPop(ctx context.Context, key string) Result[[]byte]
 
 	// LPopCount removes and returns the first count elements of the list stored at key.
 	LPopCount(ctx context.Context, key string, count int) Result[[][]byte]
 
+	// LPos returns the index of the first element equal to element in the list stored at key.
+	// Returns Nil when no element matches.
+	LPos(ctx context.Context, key string, element string, args LPosArgs) Result[int64]
+
+	// LPosCount returns the indexes of up to count elements equal to element in the list stored at key.
+	// A zero count returns all matches.
+	LPosCount(ctx context.Context, key string, element string, count int64, args LPosArgs) Result[[]int64]
+
 	// LPush inserts all the specified values at the head of the list stored at key.
 	// If key does not exist, it is created as empty list before performing the push operations.
 	// Returns the length of the list after the push operations.
 	LPush(ctx context.Context, key string, elements ...any) Result[int64]
 
+	// LPushX inserts all the specified values at the head of the list stored at key, only if key already exists.
+	// Returns the length of the list after the push operations, or 0 when key does not exist.
+	LPushX(ctx context.Context, key string, elements ...any) Result[int64]
+
 	// LRange returns the specified elements of the list stored at key.
 	// The offsets start and stop are zero-based indexes.
 	// These offsets can be negative numbers indicating offsets starting at the end of the list.
@@ -67,10 +149,15 @@ type ListCommand interface {
 
 	// RPopLPush atomically returns and removes the last element (tail) of the list stored at source,
 	// and pushes the element at the first element (head) of the list stored at destination.
+	// Prefer LMove, which supersedes it.
 	RPopLPush(ctx context.Context, source, destination string) Result[[]byte]
 
 	// RPush inserts all the specified values at the tail of the list stored at key.
 	// If key does not exist, it is created as empty list before performing the push operations.
 	// Returns the length of the list after the push operations.
 	RPush(ctx context.Context, key string, elements ...any) Result[int64]
+
+	// RPushX inserts all the specified values at the tail of the list stored at key, only if key already exists.
+	// Returns the length of the list after the push operations, or 0 when key does not exist.
+	RPushX(ctx context.Context, key string, elements ...any) Result[int64]
 }
@@ -0,0 +1,71 @@
+package caches
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// loadGroup runs loads in the background, one at a time per key,
+// sharing the result of a load with the callers asking for the same key while it is in progress.
+type loadGroup struct {
+	mu    sync.Mutex
+	calls map[string]*loadCall
+}
+
+// loadCall is a load in progress.
+type loadCall struct {
+	done chan struct{}
+	val  []byte
+	err  error
+}
+
+// do starts load for key unless a load for key is already in progress, and returns the call.
+// load runs with a context that is not canceled with ctx, since its result is shared.
+// A panic of load is recovered and returned to the callers as an error wrapping ErrLoadPanic.
+func (g *loadGroup) do(ctx context.Context, key string, load LoadFunc) *loadCall {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if call, ok := g.calls[key]; ok {
+		return call
+	}
+
+	if g.calls == nil {
+		g.calls = make(map[string]*loadCall)
+	}
+	call := &loadCall{done: make(chan struct{})}
+	g.calls[key] = call
+
+	go func() {
+		defer func() {
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+
+		call.val, call.err = call.run(context.WithoutCancel(ctx), load)
+	}()
+	return call
+}
+
+// run runs load, turning its panic into an error since load runs in its own goroutine.
+func (c *loadCall) run(ctx context.Context, load LoadFunc) (val []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			val, err = nil, fmt.Errorf("%w: %v", ErrLoadPanic, r)
+		}
+	}()
+	return load(ctx)
+}
+
+// wait waits for the call to complete and returns its result, or the error of ctx when it is done first.
+func (c *loadCall) wait(ctx context.Context) Result[[]byte] {
+	select {
+	case <-c.done:
+		return NewResult(c.val, c.err)
+	case <-ctx.Done():
+		return NewResult[[]byte](nil, ctx.Err())
+	}
+}
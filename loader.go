@@ -0,0 +1,91 @@
+package caches
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+)
+
+// negativeValue is stored in place of a value that does not exist when negative caching is enabled.
+var negativeValue = []byte("\x00caches:negative\x00")
+
+// LoadFunc loads the value of a key missing from the cache.
+// Returning Nil reports that the value does not exist.
+type LoadFunc func(ctx context.Context) ([]byte, error)
+
+// ErrLoadPanic is wrapped by the error returned when a LoadFunc panics.
+// The panic is recovered, as the LoadFunc runs in a background goroutine.
+var ErrLoadPanic = errors.New("caches: load panicked")
+
+// LoaderOptions configures a Loader.
+type LoaderOptions struct {
+	// NegativeTTL is the expiration of the values reported as not existing by a LoadFunc,
+	// which are cached with a marker value so that the next reads do not load them again.
+	// The marker is only understood by Loader, StringCommand.Get returns it as is.
+	// Zero disables negative caching.
+	NegativeTTL time.Duration
+}
+
+// Loader reads values through the cache, loading and caching the missing ones.
+// Concurrent misses for the same key are collapsed into a single load,
+// within the process running the Loader.
+type Loader struct {
+	cmd         StringCommand
+	negativeTTL time.Duration
+
+	group loadGroup
+}
+
+// NewLoader returns a Loader reading and writing values with cmd.
+func NewLoader(cmd StringCommand, opts *LoaderOptions) *Loader {
+	if cmd == nil {
+		panic("cmd is nil")
+	}
+
+	if opts == nil {
+		opts = &LoaderOptions{}
+	}
+
+	return &Loader{
+		cmd:         cmd,
+		negativeTTL: opts.NegativeTTL,
+	}
+}
+
+// GetOrLoad returns the value of key.
+// When the key does not exist, the value is loaded with load and cached for ttl, zero meaning no expiration.
+// Returns Nil when the value does not exist, whether load reported it or a negative result is cached.
+// Errors and panics of load are returned and not cached, and so are the errors reading the cache,
+// while a loaded value is returned even if it could not be cached.
+//
+// load runs with a context that is not canceled with ctx, since its result is shared with the other callers.
+func (l *Loader) GetOrLoad(ctx context.Context, key string, ttl time.Duration, load LoadFunc) Result[[]byte] {
+	val, err := l.cmd.Get(ctx, key).Result()
+	if err == nil {
+		if bytes.Equal(val, negativeValue) {
+			return NewResult[[]byte](nil, Nil)
+		}
+		return NewResult(val, nil)
+	}
+	if err != Nil {
+		return NewResult[[]byte](nil, err)
+	}
+
+	return l.group.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return l.load(ctx, key, ttl, load)
+	}).wait(ctx)
+}
+
+// load runs load and caches its result.
+func (l *Loader) load(ctx context.Context, key string, ttl time.Duration, load LoadFunc) ([]byte, error) {
+	val, err := load(ctx)
+	switch {
+	case err == nil:
+		_ = l.cmd.SetArgs(ctx, key, val, SetArgs{TTL: ttl}).Err()
+	case errors.Is(err, Nil) && l.negativeTTL > 0:
+		val = nil
+		_ = l.cmd.SetArgs(ctx, key, negativeValue, SetArgs{TTL: l.negativeTTL}).Err()
+	}
+	return val, err
+}
@@ -0,0 +1,148 @@
+// Package lock provides distributed locks with fencing tokens on top of the cache commands.
+//
+// A lock is a key set to a random value identifying its holder with caches.CompareCommand.AcquireWithToken,
+// which also increments its fencing token. It is refreshed and released with caches.CompareCommand
+// so that a holder whose lock expired cannot affect the lock of the next holder.
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var (
+	// ErrNotAcquired is returned by Acquire when the lock is held by someone else.
+	ErrNotAcquired = errors.New("lock: not acquired")
+
+	// ErrNotHeld is returned by Refresh and Release when the lock expired or was acquired by someone else.
+	ErrNotHeld = errors.New("lock: not held")
+)
+
+// Client is the set of commands used by locks, implemented by the providers.
+type Client interface {
+	caches.CompareCommand
+}
+
+// Options configures a Locker.
+type Options struct {
+	// Prefix is prepended to the lock names to build their keys, "lock:" by default.
+	Prefix string
+}
+
+// Locker acquires locks.
+type Locker struct {
+	client Client
+	prefix string
+}
+
+// New returns a Locker storing its locks with client.
+func New(client Client, opts *Options) *Locker {
+	if client == nil {
+		panic("client is nil")
+	}
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	prefix := strings.TrimSpace(opts.Prefix)
+	if prefix == "" {
+		prefix = "lock:"
+	}
+
+	return &Locker{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Acquire acquires the lock name for ttl, without waiting.
+// Returns ErrNotAcquired if the lock is held by someone else.
+//
+// The lock is given a fencing token, incremented along with the acquisition of the lock, that the resources
+// protected by the lock can use to reject the requests of a former holder. Tokens are stored without expiration
+// under the key of the lock suffixed with ":fence", and only increase while that key is kept: a token
+// deleted or lost by the backend, such as an evicted key or a redis failover, starts over.
+//
+// The key of the lock is the prefix and name enclosed in braces, a Redis Cluster hash tag
+// that keeps the lock and its token in the same slot so they can be updated together.
+func (l *Locker) Acquire(ctx context.Context, name string, ttl time.Duration) (*Lock, error) {
+	value, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	key := "{" + l.prefix + name + "}"
+	token, err := l.client.AcquireWithToken(ctx, key, value, ttl, key+":fence").Result()
+	if err == caches.Nil {
+		return nil, ErrNotAcquired
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &Lock{
+		client: l.client,
+		key:    key,
+		value:  value,
+		token:  token,
+	}, nil
+}
+
+// Lock is an acquired lock.
+type Lock struct {
+	client Client
+	key    string
+	value  string
+	token  int64
+}
+
+// Key returns the key storing the lock.
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Token returns the fencing token of the lock.
+func (l *Lock) Token() int64 {
+	return l.token
+}
+
+// Refresh extends the lock to expire in ttl.
+// Returns ErrNotHeld if the lock expired or was acquired by someone else.
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	ok, err := l.client.CompareAndExpire(ctx, l.key, l.value, ttl).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// Release releases the lock.
+// Returns ErrNotHeld if the lock expired or was acquired by someone else.
+func (l *Lock) Release(ctx context.Context) error {
+	ok, err := l.client.CompareAndDelete(ctx, l.key, l.value).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// randomValue returns a random value identifying a holder of a lock.
+func randomValue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
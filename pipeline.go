@@ -0,0 +1,1277 @@
+package caches
+
+import (
+	"context"
+	"time"
+)
+
+// Pipeliner queues commands and sends them to the backend as a single batch.
+// Results returned by queued commands are only populated once Exec returns.
+type Pipeliner interface {
+	Cache
+
+	// Len returns the number of queued commands.
+	Len() int
+
+	// Exec sends all queued commands and empties the queue.
+	// It returns the first error reported by any command, if any.
+	Exec(ctx context.Context) error
+
+	// Discard drops all queued commands without executing them.
+	Discard()
+}
+
+// PipelineCommand is implemented by providers that support pipelining.
+type PipelineCommand interface {
+	// Pipeline returns a new Pipeliner bound to the provider.
+	Pipeline() Pipeliner
+
+	// Pipelined queues the commands issued by fn and executes them.
+	// Nothing is executed when fn returns an error.
+	Pipelined(ctx context.Context, fn func(pipe Pipeliner) error) error
+}
+
+// PipelineExecFunc runs the queued commands against c.
+// Providers typically bind c to a single backend transaction
+// so that the whole batch is applied at once.
+type PipelineExecFunc func(ctx context.Context, run func(c Cache)) error
+
+// NewPipeline creates a Pipeliner that queues commands in memory and replays
+// them through exec. This is intended to be used by providers without native
+// pipelining support.
+func NewPipeline(caps Capabilities, exec PipelineExecFunc) Pipeliner {
+	return &pipeline{
+		caps: caps,
+		exec: exec,
+	}
+}
+
+type pipelineCmd struct {
+	run  func(c Cache) error
+	fail func(err error)
+}
+
+type pipeline struct {
+	caps Capabilities
+	exec PipelineExecFunc
+	cmds []pipelineCmd
+}
+
+func queue[T any](p *pipeline, fn func(c Cache) Result[T]) Result[T] {
+	var zero T
+	res := NewResult(zero, nil)
+	p.cmds = append(p.cmds, pipelineCmd{
+		run: func(c Cache) error {
+			val, err := fn(c).Result()
+			res.SetVal(val)
+			res.SetErr(err)
+			return err
+		},
+		fail: res.SetErr,
+	})
+	return res
+}
+
+func queueStatus(p *pipeline, fn func(c Cache) StatusResult) StatusResult {
+	res := &statusResult{NewResult([]byte(nil), nil)}
+	p.cmds = append(p.cmds, pipelineCmd{
+		run: func(c Cache) error {
+			val, err := fn(c).Bytes()
+			res.value = val
+			res.SetErr(err)
+			return err
+		},
+		fail: res.SetErr,
+	})
+	return res
+}
+
+// Capabilities implements Capable.
+func (p *pipeline) Capabilities() Capabilities {
+	return p.caps
+}
+
+// Len implements Pipeliner.
+func (p *pipeline) Len() int {
+	return len(p.cmds)
+}
+
+// Discard implements Pipeliner.
+func (p *pipeline) Discard() {
+	p.cmds = nil
+}
+
+// Exec implements Pipeliner.
+func (p *pipeline) Exec(ctx context.Context) error {
+	cmds := p.cmds
+	p.cmds = nil
+	if len(cmds) == 0 {
+		return nil
+	}
+
+	var first error
+	err := p.exec(ctx, func(c Cache) {
+		first = nil
+		for _, cmd := range cmds {
+			if err := cmd.run(c); err != nil && first == nil {
+				first = err
+			}
+		}
+	})
+	if err != nil {
+		for _, cmd := range cmds {
+			cmd.fail(err)
+		}
+		return err
+	}
+
+	return first
+}
+
+// DBSize implements KeyCommand.
+func (p *pipeline) DBSize(ctx context.Context) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.DBSize(ctx)
+	})
+}
+
+// DBSizePrefix implements KeyCommand.
+func (p *pipeline) DBSizePrefix(ctx context.Context, prefix string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.DBSizePrefix(ctx, prefix)
+	})
+}
+
+// Del implements KeyCommand.
+func (p *pipeline) Del(ctx context.Context, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.Del(ctx, keys...)
+	})
+}
+
+// Exists implements KeyCommand.
+func (p *pipeline) Exists(ctx context.Context, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.Exists(ctx, keys...)
+	})
+}
+
+// Expire implements KeyCommand.
+func (p *pipeline) Expire(ctx context.Context, key string, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.Expire(ctx, key, expiration)
+	})
+}
+
+// ExpireNX implements KeyCommand.
+func (p *pipeline) ExpireNX(ctx context.Context, key string, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.ExpireNX(ctx, key, expiration)
+	})
+}
+
+// ExpireXX implements KeyCommand.
+func (p *pipeline) ExpireXX(ctx context.Context, key string, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.ExpireXX(ctx, key, expiration)
+	})
+}
+
+// ExpireGT implements KeyCommand.
+func (p *pipeline) ExpireGT(ctx context.Context, key string, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.ExpireGT(ctx, key, expiration)
+	})
+}
+
+// ExpireLT implements KeyCommand.
+func (p *pipeline) ExpireLT(ctx context.Context, key string, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.ExpireLT(ctx, key, expiration)
+	})
+}
+
+// ExpireAt implements KeyCommand.
+func (p *pipeline) ExpireAt(ctx context.Context, key string, tm time.Time) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.ExpireAt(ctx, key, tm)
+	})
+}
+
+// ExpireTime implements KeyCommand.
+func (p *pipeline) ExpireTime(ctx context.Context, key string) Result[time.Duration] {
+	return queue(p, func(c Cache) Result[time.Duration] {
+		return c.ExpireTime(ctx, key)
+	})
+}
+
+// PExpire implements KeyCommand.
+func (p *pipeline) PExpire(ctx context.Context, key string, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.PExpire(ctx, key, expiration)
+	})
+}
+
+// PExpireAt implements KeyCommand.
+func (p *pipeline) PExpireAt(ctx context.Context, key string, tm time.Time) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.PExpireAt(ctx, key, tm)
+	})
+}
+
+// PExpireTime implements KeyCommand.
+func (p *pipeline) PExpireTime(ctx context.Context, key string) Result[time.Duration] {
+	return queue(p, func(c Cache) Result[time.Duration] {
+		return c.PExpireTime(ctx, key)
+	})
+}
+
+// FlushAll implements KeyCommand.
+func (p *pipeline) FlushAll(ctx context.Context) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.FlushAll(ctx)
+	})
+}
+
+// FlushPrefix implements KeyCommand.
+func (p *pipeline) FlushPrefix(ctx context.Context, prefix string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.FlushPrefix(ctx, prefix)
+	})
+}
+
+// Persist implements KeyCommand.
+func (p *pipeline) Persist(ctx context.Context, key string) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.Persist(ctx, key)
+	})
+}
+
+// Keys implements KeyCommand.
+func (p *pipeline) Keys(ctx context.Context, pattern string) Result[[]string] {
+	return queue(p, func(c Cache) Result[[]string] {
+		return c.Keys(ctx, pattern)
+	})
+}
+
+// Rename implements KeyCommand.
+func (p *pipeline) Rename(ctx context.Context, key string, newKey string) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.Rename(ctx, key, newKey)
+	})
+}
+
+// RenameNX implements KeyCommand.
+func (p *pipeline) RenameNX(ctx context.Context, key string, newKey string) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.RenameNX(ctx, key, newKey)
+	})
+}
+
+// TTL implements KeyCommand.
+func (p *pipeline) TTL(ctx context.Context, key string) Result[time.Duration] {
+	return queue(p, func(c Cache) Result[time.Duration] {
+		return c.TTL(ctx, key)
+	})
+}
+
+// PTTL implements KeyCommand.
+func (p *pipeline) PTTL(ctx context.Context, key string) Result[time.Duration] {
+	return queue(p, func(c Cache) Result[time.Duration] {
+		return c.PTTL(ctx, key)
+	})
+}
+
+// Type implements KeyCommand.
+func (p *pipeline) Type(ctx context.Context, key string) Result[string] {
+	return queue(p, func(c Cache) Result[string] {
+		return c.Type(ctx, key)
+	})
+}
+
+// RandomKey implements KeyCommand.
+func (p *pipeline) RandomKey(ctx context.Context) Result[string] {
+	return queue(p, func(c Cache) Result[string] {
+		return c.RandomKey(ctx)
+	})
+}
+
+// Scan implements KeyCommand.
+func (p *pipeline) Scan(ctx context.Context, cursor uint64, match string, count int64) Result[KeyScanResult] {
+	return queue(p, func(c Cache) Result[KeyScanResult] {
+		return c.Scan(ctx, cursor, match, count)
+	})
+}
+
+// Append implements StringCommand.
+func (p *pipeline) Append(ctx context.Context, key string, value string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.Append(ctx, key, value)
+	})
+}
+
+// Decr implements StringCommand.
+func (p *pipeline) Decr(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.Decr(ctx, key)
+	})
+}
+
+// DecrBy implements StringCommand.
+func (p *pipeline) DecrBy(ctx context.Context, key string, value int64) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.DecrBy(ctx, key, value)
+	})
+}
+
+// Get implements StringCommand.
+func (p *pipeline) Get(ctx context.Context, key string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.Get(ctx, key)
+	})
+}
+
+// GetDel implements StringCommand.
+func (p *pipeline) GetDel(ctx context.Context, key string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.GetDel(ctx, key)
+	})
+}
+
+// GetEx implements StringCommand.
+func (p *pipeline) GetEx(ctx context.Context, key string, args GetExArgs) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.GetEx(ctx, key, args)
+	})
+}
+
+// GetRange implements StringCommand.
+func (p *pipeline) GetRange(ctx context.Context, key string, start, end int64) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.GetRange(ctx, key, start, end)
+	})
+}
+
+// Incr implements StringCommand.
+func (p *pipeline) Incr(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.Incr(ctx, key)
+	})
+}
+
+// IncrBy implements StringCommand.
+func (p *pipeline) IncrBy(ctx context.Context, key string, value int64) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.IncrBy(ctx, key, value)
+	})
+}
+
+// IncrByFloat implements StringCommand.
+func (p *pipeline) IncrByFloat(ctx context.Context, key string, value float64) Result[float64] {
+	return queue(p, func(c Cache) Result[float64] {
+		return c.IncrByFloat(ctx, key, value)
+	})
+}
+
+// LCS implements StringCommand.
+func (p *pipeline) LCS(ctx context.Context, q *LCSQuery) Result[*LCSMatch] {
+	return queue(p, func(c Cache) Result[*LCSMatch] {
+		return c.LCS(ctx, q)
+	})
+}
+
+// Set implements StringCommand.
+func (p *pipeline) Set(ctx context.Context, key string, value any, expiration time.Duration) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.Set(ctx, key, value, expiration)
+	})
+}
+
+// SetArgs implements StringCommand.
+func (p *pipeline) SetArgs(ctx context.Context, key string, value any, args SetArgs) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.SetArgs(ctx, key, value, args)
+	})
+}
+
+// SetNX implements StringCommand.
+func (p *pipeline) SetNX(ctx context.Context, key string, value any, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.SetNX(ctx, key, value, expiration)
+	})
+}
+
+// SetRange implements StringCommand.
+func (p *pipeline) SetRange(ctx context.Context, key string, offset int64, value string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SetRange(ctx, key, offset, value)
+	})
+}
+
+// SetXX implements StringCommand.
+func (p *pipeline) SetXX(ctx context.Context, key string, value any, expiration time.Duration) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.SetXX(ctx, key, value, expiration)
+	})
+}
+
+// StrLen implements StringCommand.
+func (p *pipeline) StrLen(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.StrLen(ctx, key)
+	})
+}
+
+// MGet implements StringCommand.
+func (p *pipeline) MGet(ctx context.Context, keys ...string) Result[map[string][]byte] {
+	return queue(p, func(c Cache) Result[map[string][]byte] {
+		return c.MGet(ctx, keys...)
+	})
+}
+
+// MSet implements StringCommand.
+func (p *pipeline) MSet(ctx context.Context, values map[string]any) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.MSet(ctx, values)
+	})
+}
+
+// MSetNX implements StringCommand.
+func (p *pipeline) MSetNX(ctx context.Context, values map[string]any) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.MSetNX(ctx, values)
+	})
+}
+
+// BLMove implements ListCommand.
+func (p *pipeline) BLMove(ctx context.Context, source, destination string, srcpos, destpos ListDirection, timeout time.Duration) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.BLMove(ctx, source, destination, srcpos, destpos, timeout)
+	})
+}
+
+// BLPop implements ListCommand.
+func (p *pipeline) BLPop(ctx context.Context, timeout time.Duration, keys ...string) Result[ListPopResult] {
+	return queue(p, func(c Cache) Result[ListPopResult] {
+		return c.BLPop(ctx, timeout, keys...)
+	})
+}
+
+// BRPop implements ListCommand.
+func (p *pipeline) BRPop(ctx context.Context, timeout time.Duration, keys ...string) Result[ListPopResult] {
+	return queue(p, func(c Cache) Result[ListPopResult] {
+		return c.BRPop(ctx, timeout, keys...)
+	})
+}
+
+// BRPopLPush implements ListCommand.
+func (p *pipeline) BRPopLPush(ctx context.Context, source, destination string, timeout time.Duration) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.BRPopLPush(ctx, source, destination, timeout)
+	})
+}
+
+// LIndex implements ListCommand.
+func (p *pipeline) LIndex(ctx context.Context, key string, index int64) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.LIndex(ctx, key, index)
+	})
+}
+
+// LInsert implements ListCommand.
+func (p *pipeline) LInsert(ctx context.Context, key string, position LInsertPosition, pivot, element any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.LInsert(ctx, key, position, pivot, element)
+	})
+}
+
+// LLen implements ListCommand.
+func (p *pipeline) LLen(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.LLen(ctx, key)
+	})
+}
+
+// LMove implements ListCommand.
+func (p *pipeline) LMove(ctx context.Context, source, destination string, srcpos, destpos ListDirection) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.LMove(ctx, source, destination, srcpos, destpos)
+	})
+}
+
+// LMPop implements ListCommand.
+func (p *pipeline) LMPop(ctx context.Context, direction ListDirection, count int64, keys ...string) Result[ListMPopResult] {
+	return queue(p, func(c Cache) Result[ListMPopResult] {
+		return c.LMPop(ctx, direction, count, keys...)
+	})
+}
+
+// LPop implements ListCommand.
+func (p *pipeline) LPop(ctx context.Context, key string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.LPop(ctx, key)
+	})
+}
+
+// LPopCount implements ListCommand.
+func (p *pipeline) LPopCount(ctx context.Context, key string, count int) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.LPopCount(ctx, key, count)
+	})
+}
+
+// LPos implements ListCommand.
+func (p *pipeline) LPos(ctx context.Context, key string, element string, args LPosArgs) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.LPos(ctx, key, element, args)
+	})
+}
+
+// LPosCount implements ListCommand.
+func (p *pipeline) LPosCount(ctx context.Context, key string, element string, count int64, args LPosArgs) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.LPosCount(ctx, key, element, count, args)
+	})
+}
+
+// LPush implements ListCommand.
+func (p *pipeline) LPush(ctx context.Context, key string, elements ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.LPush(ctx, key, elements...)
+	})
+}
+
+// LPushX implements ListCommand.
+func (p *pipeline) LPushX(ctx context.Context, key string, elements ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.LPushX(ctx, key, elements...)
+	})
+}
+
+// LRange implements ListCommand.
+func (p *pipeline) LRange(ctx context.Context, key string, start, stop int64) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.LRange(ctx, key, start, stop)
+	})
+}
+
+// LRem implements ListCommand.
+func (p *pipeline) LRem(ctx context.Context, key string, count int64, element any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.LRem(ctx, key, count, element)
+	})
+}
+
+// LSet implements ListCommand.
+func (p *pipeline) LSet(ctx context.Context, key string, index int64, element any) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.LSet(ctx, key, index, element)
+	})
+}
+
+// LTrim implements ListCommand.
+func (p *pipeline) LTrim(ctx context.Context, key string, start, stop int64) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.LTrim(ctx, key, start, stop)
+	})
+}
+
+// RPop implements ListCommand.
+func (p *pipeline) RPop(ctx context.Context, key string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.RPop(ctx, key)
+	})
+}
+
+// RPopCount implements ListCommand.
+func (p *pipeline) RPopCount(ctx context.Context, key string, count int) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.RPopCount(ctx, key, count)
+	})
+}
+
+// RPopLPush implements ListCommand.
+func (p *pipeline) RPopLPush(ctx context.Context, source, destination string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.RPopLPush(ctx, source, destination)
+	})
+}
+
+// RPush implements ListCommand.
+func (p *pipeline) RPush(ctx context.Context, key string, elements ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.RPush(ctx, key, elements...)
+	})
+}
+
+// RPushX implements ListCommand.
+func (p *pipeline) RPushX(ctx context.Context, key string, elements ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.RPushX(ctx, key, elements...)
+	})
+}
+
+// HDel implements HashCommand.
+func (p *pipeline) HDel(ctx context.Context, key string, fields ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.HDel(ctx, key, fields...)
+	})
+}
+
+// HExists implements HashCommand.
+func (p *pipeline) HExists(ctx context.Context, key string, field string) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.HExists(ctx, key, field)
+	})
+}
+
+// HExpire implements HashCommand.
+func (p *pipeline) HExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HExpire(ctx, key, expiration, fields...)
+	})
+}
+
+// HExpireNX implements HashCommand.
+func (p *pipeline) HExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HExpireNX(ctx, key, expiration, fields...)
+	})
+}
+
+// HExpireXX implements HashCommand.
+func (p *pipeline) HExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HExpireXX(ctx, key, expiration, fields...)
+	})
+}
+
+// HExpireGT implements HashCommand.
+func (p *pipeline) HExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HExpireGT(ctx, key, expiration, fields...)
+	})
+}
+
+// HExpireLT implements HashCommand.
+func (p *pipeline) HExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HExpireLT(ctx, key, expiration, fields...)
+	})
+}
+
+// HExpireAt implements HashCommand.
+func (p *pipeline) HExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HExpireAt(ctx, key, tm, fields...)
+	})
+}
+
+// HGet implements HashCommand.
+func (p *pipeline) HGet(ctx context.Context, key string, field string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.HGet(ctx, key, field)
+	})
+}
+
+// HGetAll implements HashCommand.
+func (p *pipeline) HGetAll(ctx context.Context, key string) Result[map[string][]byte] {
+	return queue(p, func(c Cache) Result[map[string][]byte] {
+		return c.HGetAll(ctx, key)
+	})
+}
+
+// HGetEx implements HashCommand.
+func (p *pipeline) HGetEx(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.HGetEx(ctx, key, expiration, fields...)
+	})
+}
+
+// HIncrBy implements HashCommand.
+func (p *pipeline) HIncrBy(ctx context.Context, key string, field string, increment int64) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.HIncrBy(ctx, key, field, increment)
+	})
+}
+
+// HIncrByFloat implements HashCommand.
+func (p *pipeline) HIncrByFloat(ctx context.Context, key string, field string, increment float64) Result[float64] {
+	return queue(p, func(c Cache) Result[float64] {
+		return c.HIncrByFloat(ctx, key, field, increment)
+	})
+}
+
+// HKeys implements HashCommand.
+func (p *pipeline) HKeys(ctx context.Context, key string) Result[[]string] {
+	return queue(p, func(c Cache) Result[[]string] {
+		return c.HKeys(ctx, key)
+	})
+}
+
+// HLen implements HashCommand.
+func (p *pipeline) HLen(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.HLen(ctx, key)
+	})
+}
+
+// HMGet implements HashCommand.
+func (p *pipeline) HMGet(ctx context.Context, key string, fields ...string) Result[map[string][]byte] {
+	return queue(p, func(c Cache) Result[map[string][]byte] {
+		return c.HMGet(ctx, key, fields...)
+	})
+}
+
+// HMSet implements HashCommand.
+func (p *pipeline) HMSet(ctx context.Context, key string, values map[string]any) StatusResult {
+	return queueStatus(p, func(c Cache) StatusResult {
+		return c.HMSet(ctx, key, values)
+	})
+}
+
+// HPExpire implements HashCommand.
+func (p *pipeline) HPExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPExpire(ctx, key, expiration, fields...)
+	})
+}
+
+// HPExpireNX implements HashCommand.
+func (p *pipeline) HPExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPExpireNX(ctx, key, expiration, fields...)
+	})
+}
+
+// HPExpireXX implements HashCommand.
+func (p *pipeline) HPExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPExpireXX(ctx, key, expiration, fields...)
+	})
+}
+
+// HPExpireGT implements HashCommand.
+func (p *pipeline) HPExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPExpireGT(ctx, key, expiration, fields...)
+	})
+}
+
+// HPExpireLT implements HashCommand.
+func (p *pipeline) HPExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPExpireLT(ctx, key, expiration, fields...)
+	})
+}
+
+// HPExpireAt implements HashCommand.
+func (p *pipeline) HPExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPExpireAt(ctx, key, tm, fields...)
+	})
+}
+
+// HPersist implements HashCommand.
+func (p *pipeline) HPersist(ctx context.Context, key string, fields ...string) Result[[]int64] {
+	return queue(p, func(c Cache) Result[[]int64] {
+		return c.HPersist(ctx, key, fields...)
+	})
+}
+
+// HPTTL implements HashCommand.
+func (p *pipeline) HPTTL(ctx context.Context, key string, fields ...string) Result[[]time.Duration] {
+	return queue(p, func(c Cache) Result[[]time.Duration] {
+		return c.HPTTL(ctx, key, fields...)
+	})
+}
+
+// HScan implements HashCommand.
+func (p *pipeline) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) Result[HScanResult] {
+	return queue(p, func(c Cache) Result[HScanResult] {
+		return c.HScan(ctx, key, cursor, match, count)
+	})
+}
+
+// HSet implements HashCommand.
+func (p *pipeline) HSet(ctx context.Context, key string, values map[string]any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.HSet(ctx, key, values)
+	})
+}
+
+// HSetEx implements HashCommand.
+func (p *pipeline) HSetEx(ctx context.Context, key string, mode string, expiration time.Duration, values map[string]any) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.HSetEx(ctx, key, mode, expiration, values)
+	})
+}
+
+// HSetNX implements HashCommand.
+func (p *pipeline) HSetNX(ctx context.Context, key string, field string, value any) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.HSetNX(ctx, key, field, value)
+	})
+}
+
+// HTTL implements HashCommand.
+func (p *pipeline) HTTL(ctx context.Context, key string, fields ...string) Result[[]time.Duration] {
+	return queue(p, func(c Cache) Result[[]time.Duration] {
+		return c.HTTL(ctx, key, fields...)
+	})
+}
+
+// HVals implements HashCommand.
+func (p *pipeline) HVals(ctx context.Context, key string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.HVals(ctx, key)
+	})
+}
+
+// SAdd implements SetCommand.
+func (p *pipeline) SAdd(ctx context.Context, key string, members ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SAdd(ctx, key, members...)
+	})
+}
+
+// SCard implements SetCommand.
+func (p *pipeline) SCard(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SCard(ctx, key)
+	})
+}
+
+// SDiff implements SetCommand.
+func (p *pipeline) SDiff(ctx context.Context, keys ...string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.SDiff(ctx, keys...)
+	})
+}
+
+// SDiffStore implements SetCommand.
+func (p *pipeline) SDiffStore(ctx context.Context, destination string, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SDiffStore(ctx, destination, keys...)
+	})
+}
+
+// SInter implements SetCommand.
+func (p *pipeline) SInter(ctx context.Context, keys ...string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.SInter(ctx, keys...)
+	})
+}
+
+// SInterStore implements SetCommand.
+func (p *pipeline) SInterStore(ctx context.Context, destination string, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SInterStore(ctx, destination, keys...)
+	})
+}
+
+// SIsMember implements SetCommand.
+func (p *pipeline) SIsMember(ctx context.Context, key string, member any) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.SIsMember(ctx, key, member)
+	})
+}
+
+// SMembers implements SetCommand.
+func (p *pipeline) SMembers(ctx context.Context, key string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.SMembers(ctx, key)
+	})
+}
+
+// SMove implements SetCommand.
+func (p *pipeline) SMove(ctx context.Context, source, destination string, member any) Result[bool] {
+	return queue(p, func(c Cache) Result[bool] {
+		return c.SMove(ctx, source, destination, member)
+	})
+}
+
+// SPop implements SetCommand.
+func (p *pipeline) SPop(ctx context.Context, key string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.SPop(ctx, key)
+	})
+}
+
+// SPopN implements SetCommand.
+func (p *pipeline) SPopN(ctx context.Context, key string, count int64) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.SPopN(ctx, key, count)
+	})
+}
+
+// SRandMember implements SetCommand.
+func (p *pipeline) SRandMember(ctx context.Context, key string) Result[[]byte] {
+	return queue(p, func(c Cache) Result[[]byte] {
+		return c.SRandMember(ctx, key)
+	})
+}
+
+// SRandMemberN implements SetCommand.
+func (p *pipeline) SRandMemberN(ctx context.Context, key string, count int64) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.SRandMemberN(ctx, key, count)
+	})
+}
+
+// SRem implements SetCommand.
+func (p *pipeline) SRem(ctx context.Context, key string, members ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SRem(ctx, key, members...)
+	})
+}
+
+// SScan implements SetCommand.
+func (p *pipeline) SScan(ctx context.Context, key string, cursor uint64, match string, count int64) Result[ScanResult] {
+	return queue(p, func(c Cache) Result[ScanResult] {
+		return c.SScan(ctx, key, cursor, match, count)
+	})
+}
+
+// SUnion implements SetCommand.
+func (p *pipeline) SUnion(ctx context.Context, keys ...string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.SUnion(ctx, keys...)
+	})
+}
+
+// SUnionStore implements SetCommand.
+func (p *pipeline) SUnionStore(ctx context.Context, destination string, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.SUnionStore(ctx, destination, keys...)
+	})
+}
+
+// BZPopMax implements SortedSetCommand.
+func (p *pipeline) BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) Result[ZPopResult] {
+	return queue(p, func(c Cache) Result[ZPopResult] {
+		return c.BZPopMax(ctx, timeout, keys...)
+	})
+}
+
+// BZPopMin implements SortedSetCommand.
+func (p *pipeline) BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) Result[ZPopResult] {
+	return queue(p, func(c Cache) Result[ZPopResult] {
+		return c.BZPopMin(ctx, timeout, keys...)
+	})
+}
+
+// ZAdd implements SortedSetCommand.
+func (p *pipeline) ZAdd(ctx context.Context, key string, members ...ZMember) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZAdd(ctx, key, members...)
+	})
+}
+
+// ZAddArgs implements SortedSetCommand.
+func (p *pipeline) ZAddArgs(ctx context.Context, key string, mode string, ch bool, members ...ZMember) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZAddArgs(ctx, key, mode, ch, members...)
+	})
+}
+
+// ZCard implements SortedSetCommand.
+func (p *pipeline) ZCard(ctx context.Context, key string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZCard(ctx, key)
+	})
+}
+
+// ZCount implements SortedSetCommand.
+func (p *pipeline) ZCount(ctx context.Context, key string, min, max string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZCount(ctx, key, min, max)
+	})
+}
+
+// ZDiff implements SortedSetCommand.
+func (p *pipeline) ZDiff(ctx context.Context, keys ...string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZDiff(ctx, keys...)
+	})
+}
+
+// ZDiffWithScores implements SortedSetCommand.
+func (p *pipeline) ZDiffWithScores(ctx context.Context, keys ...string) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZDiffWithScores(ctx, keys...)
+	})
+}
+
+// ZDiffStore implements SortedSetCommand.
+func (p *pipeline) ZDiffStore(ctx context.Context, destination string, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZDiffStore(ctx, destination, keys...)
+	})
+}
+
+// ZIncrBy implements SortedSetCommand.
+func (p *pipeline) ZIncrBy(ctx context.Context, key string, increment float64, member string) Result[float64] {
+	return queue(p, func(c Cache) Result[float64] {
+		return c.ZIncrBy(ctx, key, increment, member)
+	})
+}
+
+// ZInter implements SortedSetCommand.
+func (p *pipeline) ZInter(ctx context.Context, store ZStore) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZInter(ctx, store)
+	})
+}
+
+// ZInterWithScores implements SortedSetCommand.
+func (p *pipeline) ZInterWithScores(ctx context.Context, store ZStore) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZInterWithScores(ctx, store)
+	})
+}
+
+// ZInterStore implements SortedSetCommand.
+func (p *pipeline) ZInterStore(ctx context.Context, destination string, store ZStore) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZInterStore(ctx, destination, store)
+	})
+}
+
+// ZInterCard implements SortedSetCommand.
+func (p *pipeline) ZInterCard(ctx context.Context, limit int64, keys ...string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZInterCard(ctx, limit, keys...)
+	})
+}
+
+// ZLexCount implements SortedSetCommand.
+func (p *pipeline) ZLexCount(ctx context.Context, key string, min, max string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZLexCount(ctx, key, min, max)
+	})
+}
+
+// ZMScore implements SortedSetCommand.
+func (p *pipeline) ZMScore(ctx context.Context, key string, members ...string) Result[[]*float64] {
+	return queue(p, func(c Cache) Result[[]*float64] {
+		return c.ZMScore(ctx, key, members...)
+	})
+}
+
+// ZMPop implements SortedSetCommand.
+func (p *pipeline) ZMPop(ctx context.Context, order string, count int64, keys ...string) Result[ZMPopResult] {
+	return queue(p, func(c Cache) Result[ZMPopResult] {
+		return c.ZMPop(ctx, order, count, keys...)
+	})
+}
+
+// ZPopMax implements SortedSetCommand.
+func (p *pipeline) ZPopMax(ctx context.Context, key string, count int64) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZPopMax(ctx, key, count)
+	})
+}
+
+// ZPopMin implements SortedSetCommand.
+func (p *pipeline) ZPopMin(ctx context.Context, key string, count int64) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZPopMin(ctx, key, count)
+	})
+}
+
+// ZRandMember implements SortedSetCommand.
+func (p *pipeline) ZRandMember(ctx context.Context, key string, count int64) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRandMember(ctx, key, count)
+	})
+}
+
+// ZRandMemberWithScores implements SortedSetCommand.
+func (p *pipeline) ZRandMemberWithScores(ctx context.Context, key string, count int64) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZRandMemberWithScores(ctx, key, count)
+	})
+}
+
+// ZRange implements SortedSetCommand.
+func (p *pipeline) ZRange(ctx context.Context, key string, start, stop int64) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRange(ctx, key, start, stop)
+	})
+}
+
+// ZRangeWithScores implements SortedSetCommand.
+func (p *pipeline) ZRangeWithScores(ctx context.Context, key string, start, stop int64) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZRangeWithScores(ctx, key, start, stop)
+	})
+}
+
+// ZRangeArgs implements SortedSetCommand.
+func (p *pipeline) ZRangeArgs(ctx context.Context, key string, args ZRangeArgs) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRangeArgs(ctx, key, args)
+	})
+}
+
+// ZRangeArgsWithScores implements SortedSetCommand.
+func (p *pipeline) ZRangeArgsWithScores(ctx context.Context, key string, args ZRangeArgs) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZRangeArgsWithScores(ctx, key, args)
+	})
+}
+
+// ZRangeStore implements SortedSetCommand.
+func (p *pipeline) ZRangeStore(ctx context.Context, destination, key string, args ZRangeArgs) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRangeStore(ctx, destination, key, args)
+	})
+}
+
+// ZRangeByLex implements SortedSetCommand.
+func (p *pipeline) ZRangeByLex(ctx context.Context, key string, min, max string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRangeByLex(ctx, key, min, max)
+	})
+}
+
+// ZRangeByScore implements SortedSetCommand.
+func (p *pipeline) ZRangeByScore(ctx context.Context, key string, min, max string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRangeByScore(ctx, key, min, max)
+	})
+}
+
+// ZRangeByScoreWithScores implements SortedSetCommand.
+func (p *pipeline) ZRangeByScoreWithScores(ctx context.Context, key string, min, max string) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZRangeByScoreWithScores(ctx, key, min, max)
+	})
+}
+
+// ZRank implements SortedSetCommand.
+func (p *pipeline) ZRank(ctx context.Context, key string, member string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRank(ctx, key, member)
+	})
+}
+
+// ZRankWithScore implements SortedSetCommand.
+func (p *pipeline) ZRankWithScore(ctx context.Context, key string, member string) Result[ZRankScore] {
+	return queue(p, func(c Cache) Result[ZRankScore] {
+		return c.ZRankWithScore(ctx, key, member)
+	})
+}
+
+// ZRem implements SortedSetCommand.
+func (p *pipeline) ZRem(ctx context.Context, key string, members ...any) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRem(ctx, key, members...)
+	})
+}
+
+// ZRemRangeByRank implements SortedSetCommand.
+func (p *pipeline) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRemRangeByRank(ctx, key, start, stop)
+	})
+}
+
+// ZRemRangeByLex implements SortedSetCommand.
+func (p *pipeline) ZRemRangeByLex(ctx context.Context, key string, min, max string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRemRangeByLex(ctx, key, min, max)
+	})
+}
+
+// ZRemRangeByScore implements SortedSetCommand.
+func (p *pipeline) ZRemRangeByScore(ctx context.Context, key string, min, max string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRemRangeByScore(ctx, key, min, max)
+	})
+}
+
+// ZRevRange implements SortedSetCommand.
+func (p *pipeline) ZRevRange(ctx context.Context, key string, start, stop int64) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRevRange(ctx, key, start, stop)
+	})
+}
+
+// ZRevRangeWithScores implements SortedSetCommand.
+func (p *pipeline) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZRevRangeWithScores(ctx, key, start, stop)
+	})
+}
+
+// ZRevRangeByLex implements SortedSetCommand.
+func (p *pipeline) ZRevRangeByLex(ctx context.Context, key string, max, min string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRevRangeByLex(ctx, key, max, min)
+	})
+}
+
+// ZRevRangeByScore implements SortedSetCommand.
+func (p *pipeline) ZRevRangeByScore(ctx context.Context, key string, max, min string) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZRevRangeByScore(ctx, key, max, min)
+	})
+}
+
+// ZRevRangeByScoreWithScores implements SortedSetCommand.
+func (p *pipeline) ZRevRangeByScoreWithScores(ctx context.Context, key string, max, min string) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZRevRangeByScoreWithScores(ctx, key, max, min)
+	})
+}
+
+// ZRevRank implements SortedSetCommand.
+func (p *pipeline) ZRevRank(ctx context.Context, key string, member string) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZRevRank(ctx, key, member)
+	})
+}
+
+// ZRevRankWithScore implements SortedSetCommand.
+func (p *pipeline) ZRevRankWithScore(ctx context.Context, key string, member string) Result[ZRankScore] {
+	return queue(p, func(c Cache) Result[ZRankScore] {
+		return c.ZRevRankWithScore(ctx, key, member)
+	})
+}
+
+// ZScan implements SortedSetCommand.
+func (p *pipeline) ZScan(ctx context.Context, key string, cursor uint64, match string, count int64) Result[ZScanResult] {
+	return queue(p, func(c Cache) Result[ZScanResult] {
+		return c.ZScan(ctx, key, cursor, match, count)
+	})
+}
+
+// ZScore implements SortedSetCommand.
+func (p *pipeline) ZScore(ctx context.Context, key string, member string) Result[float64] {
+	return queue(p, func(c Cache) Result[float64] {
+		return c.ZScore(ctx, key, member)
+	})
+}
+
+// ZUnion implements SortedSetCommand.
+func (p *pipeline) ZUnion(ctx context.Context, store ZStore) Result[[][]byte] {
+	return queue(p, func(c Cache) Result[[][]byte] {
+		return c.ZUnion(ctx, store)
+	})
+}
+
+// ZUnionWithScores implements SortedSetCommand.
+func (p *pipeline) ZUnionWithScores(ctx context.Context, store ZStore) Result[[]ZMember] {
+	return queue(p, func(c Cache) Result[[]ZMember] {
+		return c.ZUnionWithScores(ctx, store)
+	})
+}
+
+// ZUnionStore implements SortedSetCommand.
+func (p *pipeline) ZUnionStore(ctx context.Context, destination string, store ZStore) Result[int64] {
+	return queue(p, func(c Cache) Result[int64] {
+		return c.ZUnionStore(ctx, destination, store)
+	})
+}
@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/bitmap"
+)
+
+var _ caches.BitmapCommand = (*Provider)(nil)
+
+// SetBit implements caches.BitmapCommand.
+func (p *Provider) SetBit(ctx context.Context, key string, offset int64, value int) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		b, prev, err := bitmap.SetBit(cur, offset, value)
+		if err != nil {
+			return 0, err
+		}
+		setString(tx, key, b, true)
+		return prev, nil
+	})
+
+	return newResult(val, err)
+}
+
+// GetBit implements caches.BitmapCommand.
+func (p *Provider) GetBit(ctx context.Context, key string, offset int64) caches.Result[int64] {
+	if err := bitmap.CheckOffset(offset); err != nil {
+		return newResult(int64(0), err)
+	}
+
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		return bitmap.GetBit(cur, offset), err
+	})
+
+	return newResult(val, err)
+}
+
+// BitCount implements caches.BitmapCommand.
+func (p *Provider) BitCount(ctx context.Context, key string, bitCount *caches.BitCount) caches.Result[int64] {
+	start, end, unit := int64(0), int64(-1), ""
+	if bitCount != nil {
+		start, end, unit = bitCount.Start, bitCount.End, bitCount.Unit
+	}
+	bitUnit, err := bitmap.ParseUnit(unit)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		return bitmap.Count(cur, start, end, bitUnit), err
+	})
+
+	return newResult(val, err)
+}
+
+// bitPos returns the position of the first bit set to bit in the string stored at key.
+func (p *Provider) bitPos(ctx context.Context, key string, bit, start, end int64, hasEnd, bitUnit bool) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, it, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		if it == nil {
+			if bit != 0 && bit != 1 {
+				return 0, bitmap.ErrBit
+			}
+			// A missing key is an empty string, padded with zeros
+			return -bit, nil
+		}
+		return bitmap.Pos(cur, bit, start, end, hasEnd, bitUnit)
+	})
+
+	return newResult(val, err)
+}
+
+// BitPos implements caches.BitmapCommand.
+func (p *Provider) BitPos(ctx context.Context, key string, bit int64, pos ...int64) caches.Result[int64] {
+	start, end := int64(0), int64(-1)
+	switch len(pos) {
+	case 0:
+	case 1:
+		start = pos[0]
+	case 2:
+		start, end = pos[0], pos[1]
+	default:
+		return newResult(int64(0), bitmap.ErrSyntax)
+	}
+
+	return p.bitPos(ctx, key, bit, start, end, len(pos) == 2, false)
+}
+
+// BitPosSpan implements caches.BitmapCommand.
+func (p *Provider) BitPosSpan(ctx context.Context, key string, bit int8, start, end int64, span string) caches.Result[int64] {
+	bitUnit, err := bitmap.ParseUnit(span)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	return p.bitPos(ctx, key, int64(bit), start, end, true, bitUnit)
+}
+
+// bitOp stores the result of the bitwise operation op on the strings stored at keys in destKey.
+func (p *Provider) bitOp(ctx context.Context, op string, destKey string, keys []string) caches.Result[int64] {
+	destKey = p.prefix + destKey
+	keys = prefixKeys(p.prefix, keys)
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		srcs := make([][]byte, len(keys))
+		for i, key := range keys {
+			cur, _, err := lookup[[]byte](tx, key)
+			if err != nil {
+				return 0, err
+			}
+			srcs[i] = cur
+		}
+
+		b, err := bitmap.Op(op, srcs)
+		if err != nil {
+			return 0, err
+		}
+
+		// An empty result deletes the destination key, as an empty string is not stored
+		if len(b) == 0 {
+			tx.del(destKey)
+		} else {
+			setString(tx, destKey, b, false)
+		}
+		return int64(len(b)), nil
+	})
+
+	return newResult(val, err)
+}
+
+// BitOpAnd implements caches.BitmapCommand.
+func (p *Provider) BitOpAnd(ctx context.Context, destKey string, keys ...string) caches.Result[int64] {
+	return p.bitOp(ctx, "AND", destKey, keys)
+}
+
+// BitOpOr implements caches.BitmapCommand.
+func (p *Provider) BitOpOr(ctx context.Context, destKey string, keys ...string) caches.Result[int64] {
+	return p.bitOp(ctx, "OR", destKey, keys)
+}
+
+// BitOpXor implements caches.BitmapCommand.
+func (p *Provider) BitOpXor(ctx context.Context, destKey string, keys ...string) caches.Result[int64] {
+	return p.bitOp(ctx, "XOR", destKey, keys)
+}
+
+// BitOpNot implements caches.BitmapCommand.
+func (p *Provider) BitOpNot(ctx context.Context, destKey string, key string) caches.Result[int64] {
+	return p.bitOp(ctx, "NOT", destKey, []string{key})
+}
+
+// BitField implements caches.BitmapCommand.
+func (p *Provider) BitField(ctx context.Context, key string, args ...any) caches.Result[[]*int64] {
+	fields, err := bitmap.ParseFields(args)
+	if err != nil {
+		return newResult([]*int64(nil), err)
+	}
+
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]*int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		b, res := bitmap.ApplyFields(cur, fields)
+		if b != nil {
+			setString(tx, key, b, true)
+		}
+		return res, nil
+	})
+
+	return newResult(val, err)
+}
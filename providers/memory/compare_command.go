@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.CompareCommand = (*Provider)(nil)
+
+// compareString returns the item of key when it holds value, or nil.
+func compareString(tx *tx, key string, value any) (*item, error) {
+	want, err := toBytes(value)
+	if err != nil {
+		return nil, err
+	}
+
+	val, it, err := lookup[[]byte](tx, key)
+	if err != nil || it == nil || !bytes.Equal(val, want) {
+		return nil, err
+	}
+	return it, nil
+}
+
+// CompareAndDelete implements caches.CompareCommand.
+func (p *Provider) CompareAndDelete(ctx context.Context, key string, value any) caches.Result[bool] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		it, err := compareString(tx, key, value)
+		if it == nil {
+			return false, err
+		}
+		return tx.del(key), nil
+	})
+	return newResult(val, err)
+}
+
+// CompareAndExpire implements caches.CompareCommand.
+func (p *Provider) CompareAndExpire(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	ms := formatMs(expiration)
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		it, err := compareString(tx, key, value)
+		if it == nil {
+			return false, err
+		}
+
+		it.expireAt = tx.now.Add(time.Duration(ms) * time.Millisecond)
+		tx.touch(it)
+		return true, nil
+	})
+	return newResult(val, err)
+}
+
+// AcquireWithToken implements caches.CompareCommand.
+func (p *Provider) AcquireWithToken(ctx context.Context, key string, value any, expiration time.Duration, tokenKey string) caches.Result[int64] {
+	key, tokenKey = p.prefix+key, p.prefix+tokenKey
+	data, err := toBytes(value)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	ms := formatMs(expiration)
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		if tx.get(key) != nil {
+			return 0, caches.Nil
+		}
+
+		// The token is checked before the key is set, so that an invalid token leaves both unchanged
+		cur, it, err := lookup[[]byte](tx, tokenKey)
+		if err != nil {
+			return 0, err
+		}
+		var token int64
+		if it != nil {
+			token, err = strconv.ParseInt(string(cur), 10, 64)
+			if err != nil {
+				return 0, errNotInteger
+			}
+		}
+		token++
+		setString(tx, tokenKey, strconv.AppendInt(nil, token, 10), true)
+
+		it = setString(tx, key, data, false)
+		if ms > 0 {
+			it.expireAt = tx.now.Add(time.Duration(ms) * time.Millisecond)
+		}
+		return token, nil
+	})
+	return newResult(val, err)
+}
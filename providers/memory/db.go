@@ -0,0 +1,217 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// errKeyType is returned when an operation is run against a key holding
+// a value of another type.
+var errKeyType = errors.New("memory: key type mismatch")
+
+type (
+	// listValue holds the elements of a list key.
+	listValue struct {
+		elems [][]byte
+	}
+
+	// hashValue holds the fields of a hash key, and the expiration time of the fields having one.
+	hashValue struct {
+		fields  map[string][]byte
+		expires map[string]time.Time
+	}
+
+	// setValue holds the members of a set key.
+	setValue map[string]struct{}
+
+	// zsetValue holds the members of a sorted set key with their scores.
+	zsetValue map[string]float64
+)
+
+// item is a single key stored in the database.
+type item struct {
+	value    any
+	expireAt time.Time
+	version  uint64
+}
+
+func (it *item) expired(now time.Time) bool {
+	if !it.expireAt.IsZero() && !now.Before(it.expireAt) {
+		return true
+	}
+
+	// A hash whose fields all expired no longer exists
+	hash, ok := it.value.(*hashValue)
+	return ok && hash.expired(now)
+}
+
+// db is the in-memory keyspace shared by a Provider.
+type db struct {
+	mu      sync.RWMutex
+	items   map[string]*item
+	version uint64
+	waiter  *waiter
+}
+
+func newDB() *db {
+	return &db{
+		items:  make(map[string]*item),
+		waiter: newWaiter(),
+	}
+}
+
+// store runs callbacks within a transaction.
+// It is implemented by db and by txStore, which reuses an open transaction.
+type store interface {
+	view(ctx context.Context, f func(tx *tx) error) error
+	update(ctx context.Context, f func(tx *tx) error) error
+}
+
+// view runs f with the read lock held.
+func (d *db) view(ctx context.Context, f func(tx *tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return f(&tx{db: d, now: time.Now()})
+}
+
+// update runs f with the write lock held, and wakes up blocked commands once it is released.
+func (d *db) update(ctx context.Context, f func(tx *tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := func() error {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		return f(&tx{db: d, now: time.Now(), writable: true})
+	}()
+
+	if err == nil {
+		d.waiter.notify()
+	}
+	return err
+}
+
+// txStore runs every callback within the same writable transaction.
+type txStore struct {
+	tx *tx
+}
+
+func (s txStore) view(ctx context.Context, f func(tx *tx) error) error {
+	return f(s.tx)
+}
+
+func (s txStore) update(ctx context.Context, f func(tx *tx) error) error {
+	return f(s.tx)
+}
+
+// tx is a view of the database used while its lock is held.
+type tx struct {
+	db       *db
+	now      time.Time
+	writable bool
+}
+
+// get returns the live item stored under key or nil.
+// Expired items, and the expired fields of hashes, are removed when the transaction is writable.
+func (t *tx) get(key string) *item {
+	it, ok := t.db.items[key]
+	if !ok {
+		return nil
+	}
+
+	if it.expired(t.now) {
+		if t.writable {
+			delete(t.db.items, key)
+		}
+		return nil
+	}
+
+	if hash, ok := it.value.(*hashValue); ok && t.writable {
+		hash.purge(t.now)
+	}
+
+	return it
+}
+
+// put stores value under key, replacing any previous item and its expiration.
+func (t *tx) put(key string, value any) *item {
+	it := &item{value: value}
+	t.db.items[key] = it
+	t.touch(it)
+	return it
+}
+
+// touch marks the item as modified.
+func (t *tx) touch(it *item) {
+	t.db.version++
+	it.version = t.db.version
+}
+
+// del removes key and reports whether it existed.
+func (t *tx) del(key string) bool {
+	if t.get(key) == nil {
+		return false
+	}
+	delete(t.db.items, key)
+	return true
+}
+
+// keys returns the sorted names of all live keys.
+func (t *tx) keys() []string {
+	keys := make([]string, 0, len(t.db.items))
+	for key, it := range t.db.items {
+		if !it.expired(t.now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// changed marks the collection stored under key as modified,
+// removing the key once the collection is empty.
+func (t *tx) changed(key string, it *item, size int) {
+	if size == 0 {
+		delete(t.db.items, key)
+		return
+	}
+	t.touch(it)
+}
+
+// lookup returns the value of type T stored under key.
+// The returned item is nil when the key does not exist.
+func lookup[T any](t *tx, key string) (val T, it *item, err error) {
+	it = t.get(key)
+	if it == nil {
+		return val, nil, nil
+	}
+
+	val, ok := it.value.(T)
+	if !ok {
+		return val, nil, errKeyType
+	}
+
+	return val, it, nil
+}
+
+// lookupOrCreate returns the value of type T stored under key,
+// creating it with create when the key does not exist.
+func lookupOrCreate[T any](t *tx, key string, create func() T) (T, *item, error) {
+	val, it, err := lookup[T](t, key)
+	if err != nil || it != nil {
+		return val, it, err
+	}
+
+	val = create()
+	return val, t.put(key, val), nil
+}
@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/geo"
+)
+
+var errGeoAddLocations = errors.New("memory: GEOADD requires at least one location")
+
+var _ caches.GeoCommand = (*Provider)(nil)
+
+// geoSearch returns the points of the sorted set stored at key within the shape of q.
+func geoSearch(tx *tx, key string, q *caches.GeoSearchQuery) ([]geo.Point, error) {
+	zset, it, err := lookup[zsetValue](tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if it == nil {
+		// The query is still validated when the key does not exist
+		return geo.Search(nil, 0, 0, q)
+	}
+
+	lon, lat := q.Longitude, q.Latitude
+	if q.Member != "" {
+		score, ok := zset[q.Member]
+		if !ok {
+			return nil, geo.ErrMember
+		}
+		lon, lat = geo.Decode(score)
+	} else if err := geo.CheckCoord(lon, lat); err != nil {
+		return nil, err
+	}
+
+	members := sortedZMembers(zset)
+	points := make([]geo.Point, len(members))
+	for i, m := range members {
+		points[i] = geo.NewPoint(string(m.Member), m.Score)
+	}
+	return geo.Search(points, lon, lat, q)
+}
+
+// GeoAdd implements caches.GeoCommand.
+func (p *Provider) GeoAdd(ctx context.Context, key string, geoLocation ...*caches.GeoLocation) caches.Result[int64] {
+	if len(geoLocation) == 0 {
+		return newResult(int64(0), errGeoAddLocations)
+	}
+
+	scores := make([]float64, len(geoLocation))
+	for i, loc := range geoLocation {
+		score, err := geo.Score(loc.Longitude, loc.Latitude)
+		if err != nil {
+			return newResult(int64(0), err)
+		}
+		scores[i] = score
+	}
+
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, it, err := lookupOrCreate(tx, key, newZSet)
+		if err != nil {
+			return 0, err
+		}
+
+		var count int64
+		for i, loc := range geoLocation {
+			if _, exists := zset[loc.Name]; !exists {
+				count++
+			}
+			zset[loc.Name] = scores[i]
+		}
+
+		tx.changed(key, it, len(zset))
+		return count, nil
+	})
+	return newResult(n, err)
+}
+
+// GeoPos implements caches.GeoCommand.
+func (p *Provider) GeoPos(ctx context.Context, key string, members ...string) caches.Result[[]*caches.GeoPos] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]*caches.GeoPos, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]*caches.GeoPos, len(members))
+		for i, member := range members {
+			if score, ok := zset[member]; ok {
+				lon, lat := geo.Decode(score)
+				result[i] = &caches.GeoPos{Longitude: lon, Latitude: lat}
+			}
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// GeoDist implements caches.GeoCommand.
+func (p *Provider) GeoDist(ctx context.Context, key string, member1, member2, unit string) caches.Result[float64] {
+	conv, err := geo.Unit(unit)
+	if err != nil {
+		return newResult(float64(0), err)
+	}
+
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (float64, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		score1, ok1 := zset[member1]
+		score2, ok2 := zset[member2]
+		if !ok1 || !ok2 {
+			return 0, caches.Nil
+		}
+
+		lon1, lat1 := geo.Decode(score1)
+		lon2, lat2 := geo.Decode(score2)
+		return geo.Round(geo.Distance(lon1, lat1, lon2, lat2) / conv), nil
+	})
+	return newResult(val, err)
+}
+
+// GeoHash implements caches.GeoCommand.
+func (p *Provider) GeoHash(ctx context.Context, key string, members ...string) caches.Result[[]string] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]string, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, len(members))
+		for i, member := range members {
+			if score, ok := zset[member]; ok {
+				result[i] = geo.Hash(score)
+			}
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// GeoSearch implements caches.GeoCommand.
+func (p *Provider) GeoSearch(ctx context.Context, key string, q *caches.GeoSearchQuery) caches.Result[[]string] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]string, error) {
+		points, err := geoSearch(tx, key, q)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, len(points))
+		for i, pt := range points {
+			result[i] = pt.Member
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// GeoSearchLocation implements caches.GeoCommand.
+func (p *Provider) GeoSearchLocation(ctx context.Context, key string, q *caches.GeoSearchLocationQuery) caches.Result[[]caches.GeoLocation] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]caches.GeoLocation, error) {
+		points, err := geoSearch(tx, key, &q.GeoSearchQuery)
+		if err != nil {
+			return nil, err
+		}
+		return geo.Locations(points, q), nil
+	})
+	return newResult(val, err)
+}
+
+// GeoSearchStore implements caches.GeoCommand.
+func (p *Provider) GeoSearchStore(ctx context.Context, key, store string, q *caches.GeoSearchStoreQuery) caches.Result[int64] {
+	key = p.prefix + key
+	store = p.prefix + store
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		points, err := geoSearch(tx, key, &q.GeoSearchQuery)
+		if err != nil {
+			return 0, err
+		}
+
+		zset := newZSet()
+		for _, pt := range points {
+			if q.StoreDist {
+				zset[pt.Member] = pt.Dist
+			} else {
+				zset[pt.Member] = pt.Score
+			}
+		}
+
+		tx.del(store)
+		if len(zset) > 0 {
+			tx.put(store, zset)
+		}
+		return int64(len(zset)), nil
+	})
+	return newResult(n, err)
+}
@@ -0,0 +1,5 @@
+module github.com/rockcookies/go-caches/providers/memory
+
+go 1.21.0
+
+require github.com/rockcookies/go-caches v0.0.1-beta.1
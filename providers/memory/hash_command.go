@@ -0,0 +1,688 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var errHSetExMode = errors.New("memory: HSETEX mode must be FNX or FXX")
+
+var _ caches.HashCommand = (*Provider)(nil)
+
+func newHash() *hashValue {
+	return &hashValue{fields: make(map[string][]byte)}
+}
+
+// get returns the value of field, unless it does not exist or expired.
+func (h *hashValue) get(field string, now time.Time) ([]byte, bool) {
+	v, ok := h.fields[field]
+	if !ok || h.fieldExpired(field, now) {
+		return nil, false
+	}
+	return v, true
+}
+
+// fieldExpired reports whether field has an expiration time which passed.
+func (h *hashValue) fieldExpired(field string, now time.Time) bool {
+	at, ok := h.expires[field]
+	return ok && !now.Before(at)
+}
+
+// expired reports whether all the fields of the hash expired.
+func (h *hashValue) expired(now time.Time) bool {
+	if len(h.expires) == 0 || len(h.expires) < len(h.fields) {
+		return false
+	}
+	for field := range h.fields {
+		if !h.fieldExpired(field, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// purge deletes the expired fields.
+// Read-only transactions cannot update the hash, they skip the expired fields instead.
+func (h *hashValue) purge(now time.Time) {
+	for field := range h.expires {
+		if h.fieldExpired(field, now) {
+			h.del(field)
+		}
+	}
+}
+
+// set sets the value of field, keeping its expiration time if keepTTL is true.
+func (h *hashValue) set(field string, value []byte, keepTTL bool) {
+	h.fields[field] = value
+	if !keepTTL {
+		delete(h.expires, field)
+	}
+}
+
+// expire sets the expiration time of field, or removes it when at is zero.
+func (h *hashValue) expire(field string, at time.Time) {
+	if at.IsZero() {
+		delete(h.expires, field)
+		return
+	}
+	if h.expires == nil {
+		h.expires = make(map[string]time.Time)
+	}
+	h.expires[field] = at
+}
+
+func (h *hashValue) del(field string) {
+	delete(h.fields, field)
+	delete(h.expires, field)
+}
+
+// sortedFields returns the names of the live fields of the hash in a stable order.
+func sortedFields(hash *hashValue, now time.Time) []string {
+	if hash == nil {
+		return nil
+	}
+
+	fields := make([]string, 0, len(hash.fields))
+	for field := range hash.fields {
+		if !hash.fieldExpired(field, now) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// HSet implements caches.HashCommand.
+func (p *Provider) HSet(ctx context.Context, key string, values map[string]any) caches.Result[int64] {
+	key = p.prefix + key
+	data, err := toBytesMap("", values)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		hash, it, err := lookupOrCreate(tx, key, newHash)
+		if err != nil {
+			return 0, err
+		}
+
+		var created int64
+		for field, value := range data {
+			if _, ok := hash.fields[field]; !ok {
+				created++
+			}
+			hash.set(field, value, false)
+		}
+
+		tx.changed(key, it, len(hash.fields))
+		return created, nil
+	})
+	return newResult(n, err)
+}
+
+// HGet implements caches.HashCommand.
+func (p *Provider) HGet(ctx context.Context, key, field string) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return nil, caches.Nil
+		}
+
+		v, ok := hash.get(field, tx.now)
+		if !ok {
+			return nil, caches.Nil
+		}
+		return v, nil
+	})
+	return newResult(val, err)
+}
+
+// HGetAll implements caches.HashCommand.
+func (p *Provider) HGetAll(ctx context.Context, key string) caches.Result[map[string][]byte] {
+	key = p.prefix + key
+	items, err := viewAndReturn(ctx, p.db, func(tx *tx) (map[string][]byte, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil || it == nil {
+			return map[string][]byte{}, err
+		}
+
+		result := make(map[string][]byte, len(hash.fields))
+		for k, v := range hash.fields {
+			if !hash.fieldExpired(k, tx.now) {
+				result[k] = v
+			}
+		}
+		return result, nil
+	})
+	return newResult(items, err)
+}
+
+// HDel implements caches.HashCommand.
+func (p *Provider) HDel(ctx context.Context, key string, fields ...string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil || it == nil {
+			return 0, err
+		}
+
+		var deleted int64
+		for _, field := range fields {
+			if _, ok := hash.fields[field]; ok {
+				hash.del(field)
+				deleted++
+			}
+		}
+
+		if deleted > 0 {
+			tx.changed(key, it, len(hash.fields))
+		}
+		return deleted, nil
+	})
+	return newResult(n, err)
+}
+
+// HExists implements caches.HashCommand.
+func (p *Provider) HExists(ctx context.Context, key, field string) caches.Result[bool] {
+	key = p.prefix + key
+	exists, err := viewAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil || it == nil {
+			return false, err
+		}
+
+		_, ok := hash.get(field, tx.now)
+		return ok, nil
+	})
+	return newResult(exists, err)
+}
+
+// hexpire sets the expiration time of fields to at, computed from the time of the transaction,
+// if the fields satisfy the condition of expType.
+func (p *Provider) hexpire(ctx context.Context, key string, at func(now time.Time) time.Time, expType expireType, fields []string) caches.Result[[]int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]int64, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		etime := at(tx.now)
+		result := make([]int64, len(fields))
+		changed := false
+		for i, field := range fields {
+			if it == nil {
+				result[i] = -2
+				continue
+			}
+			if _, ok := hash.fields[field]; !ok {
+				result[i] = -2
+				continue
+			}
+
+			cur, hasETime := hash.expires[field]
+			switch {
+			case expType == expireNX && hasETime,
+				expType == expireXX && !hasETime,
+				// A field without expiration is treated as an infinite TTL
+				expType == expireGT && (!hasETime || !etime.After(cur)),
+				expType == expireLT && hasETime && !etime.Before(cur):
+				result[i] = 0
+			case !etime.After(tx.now):
+				hash.del(field)
+				result[i] = 2
+				changed = true
+			default:
+				hash.expire(field, etime)
+				result[i] = 1
+				changed = true
+			}
+		}
+
+		// Nothing is written when no field is updated, so that watching transactions succeed
+		if changed {
+			tx.changed(key, it, len(hash.fields))
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// afterSeconds returns a function computing the expiration time after the given number of seconds.
+func afterSeconds(expiration time.Duration) func(now time.Time) time.Time {
+	secs := formatSec(expiration)
+	return func(now time.Time) time.Time {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+}
+
+// HExpire implements caches.HashCommand.
+func (p *Provider) HExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterSeconds(expiration), expire, fields)
+}
+
+// HExpireNX implements caches.HashCommand.
+func (p *Provider) HExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterSeconds(expiration), expireNX, fields)
+}
+
+// HExpireXX implements caches.HashCommand.
+func (p *Provider) HExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterSeconds(expiration), expireXX, fields)
+}
+
+// HExpireGT implements caches.HashCommand.
+func (p *Provider) HExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterSeconds(expiration), expireGT, fields)
+}
+
+// HExpireLT implements caches.HashCommand.
+func (p *Provider) HExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterSeconds(expiration), expireLT, fields)
+}
+
+// HExpireAt implements caches.HashCommand.
+func (p *Provider) HExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) caches.Result[[]int64] {
+	tm = tm.Truncate(time.Second)
+	return p.hexpire(ctx, key, func(time.Time) time.Time { return tm }, expire, fields)
+}
+
+// afterMilliseconds returns a function computing the expiration time after the given number of milliseconds.
+func afterMilliseconds(expiration time.Duration) func(now time.Time) time.Time {
+	ms := formatMs(expiration)
+	return func(now time.Time) time.Time {
+		return now.Add(time.Duration(ms) * time.Millisecond)
+	}
+}
+
+// HPExpire implements caches.HashCommand.
+func (p *Provider) HPExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMilliseconds(expiration), expire, fields)
+}
+
+// HPExpireNX implements caches.HashCommand.
+func (p *Provider) HPExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMilliseconds(expiration), expireNX, fields)
+}
+
+// HPExpireXX implements caches.HashCommand.
+func (p *Provider) HPExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMilliseconds(expiration), expireXX, fields)
+}
+
+// HPExpireGT implements caches.HashCommand.
+func (p *Provider) HPExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMilliseconds(expiration), expireGT, fields)
+}
+
+// HPExpireLT implements caches.HashCommand.
+func (p *Provider) HPExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMilliseconds(expiration), expireLT, fields)
+}
+
+// HPExpireAt implements caches.HashCommand.
+func (p *Provider) HPExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) caches.Result[[]int64] {
+	tm = tm.Truncate(time.Millisecond)
+	return p.hexpire(ctx, key, func(time.Time) time.Time { return tm }, expire, fields)
+}
+
+// httl returns the remaining time to live of fields, converted by conv.
+func (p *Provider) httl(ctx context.Context, key string, fields []string, conv func(ttl time.Duration) time.Duration) caches.Result[[]time.Duration] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]time.Duration, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]time.Duration, len(fields))
+		for i, field := range fields {
+			if it == nil {
+				result[i] = -2
+				continue
+			}
+			if _, ok := hash.get(field, tx.now); !ok {
+				result[i] = -2
+				continue
+			}
+
+			at, ok := hash.expires[field]
+			if !ok {
+				result[i] = -1
+				continue
+			}
+			result[i] = conv(at.Sub(tx.now))
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// HTTL implements caches.HashCommand.
+func (p *Provider) HTTL(ctx context.Context, key string, fields ...string) caches.Result[[]time.Duration] {
+	return p.httl(ctx, key, fields, func(ttl time.Duration) time.Duration {
+		// Redis rounds the remaining seconds up
+		return (ttl + time.Second - 1).Truncate(time.Second)
+	})
+}
+
+// HPTTL implements caches.HashCommand.
+func (p *Provider) HPTTL(ctx context.Context, key string, fields ...string) caches.Result[[]time.Duration] {
+	return p.httl(ctx, key, fields, func(ttl time.Duration) time.Duration {
+		return ttl.Truncate(time.Millisecond)
+	})
+}
+
+// HPersist implements caches.HashCommand.
+func (p *Provider) HPersist(ctx context.Context, key string, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]int64, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]int64, len(fields))
+		persisted := false
+		for i, field := range fields {
+			if it == nil {
+				result[i] = -2
+				continue
+			}
+
+			_, exists := hash.fields[field]
+			_, hasETime := hash.expires[field]
+			switch {
+			case !exists:
+				result[i] = -2
+			case !hasETime:
+				result[i] = -1
+			default:
+				hash.expire(field, time.Time{})
+				result[i] = 1
+				persisted = true
+			}
+		}
+
+		if persisted {
+			tx.touch(it)
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// expireAfter returns the expiration time of a field updated with expiration by HGetEx and HSetEx,
+// and whether it changes: KeepTTL keeps it, and 0 removes it.
+func expireAfter(expiration time.Duration, now time.Time) (time.Time, bool) {
+	switch {
+	case expiration < 0:
+		return time.Time{}, false
+	case expiration > 0:
+		return now.Add(time.Duration(formatMs(expiration)) * time.Millisecond), true
+	default:
+		return time.Time{}, true
+	}
+}
+
+// HGetEx implements caches.HashCommand.
+func (p *Provider) HGetEx(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil || it == nil {
+			return make([][]byte, len(fields)), err
+		}
+
+		etime, update := expireAfter(expiration, tx.now)
+		result := make([][]byte, len(fields))
+		for i, field := range fields {
+			v, ok := hash.get(field, tx.now)
+			if !ok {
+				continue
+			}
+
+			result[i] = v
+			if update {
+				hash.expire(field, etime)
+			}
+		}
+
+		if update {
+			tx.touch(it)
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// HSetEx implements caches.HashCommand.
+func (p *Provider) HSetEx(ctx context.Context, key string, mode string, expiration time.Duration, values map[string]any) caches.Result[bool] {
+	mode = strings.ToUpper(mode)
+	if mode != "" && mode != "FNX" && mode != "FXX" {
+		return newResult(false, errHSetExMode)
+	}
+	data, err := toBytesMap("", values)
+	if err != nil {
+		return newResult(false, err)
+	}
+
+	key = p.prefix + key
+	set, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return false, err
+		}
+		if it == nil {
+			hash = newHash()
+		}
+
+		for field := range data {
+			_, ok := hash.fields[field]
+			if mode == "FNX" && ok || mode == "FXX" && !ok {
+				return false, nil
+			}
+		}
+
+		etime, update := expireAfter(expiration, tx.now)
+		for field, value := range data {
+			hash.set(field, value, !update)
+			if update {
+				hash.expire(field, etime)
+			}
+		}
+
+		if it == nil {
+			it = tx.put(key, hash)
+		}
+		tx.changed(key, it, len(hash.fields))
+		return true, nil
+	})
+	return newResult(set, err)
+}
+
+// HIncrBy implements caches.HashCommand.
+func (p *Provider) HIncrBy(ctx context.Context, key, field string, incr int64) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		hash, it, err := lookupOrCreate(tx, key, newHash)
+		if err != nil {
+			return 0, err
+		}
+
+		var val int64
+		if cur, ok := hash.fields[field]; ok {
+			val, err = strconv.ParseInt(string(cur), 10, 64)
+			if err != nil {
+				return 0, errNotInteger
+			}
+		}
+
+		val += incr
+		hash.set(field, strconv.AppendInt(nil, val, 10), true)
+		tx.changed(key, it, len(hash.fields))
+		return val, nil
+	})
+	return newResult(n, err)
+}
+
+// HIncrByFloat implements caches.HashCommand.
+func (p *Provider) HIncrByFloat(ctx context.Context, key, field string, incr float64) caches.Result[float64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (float64, error) {
+		hash, it, err := lookupOrCreate(tx, key, newHash)
+		if err != nil {
+			return 0, err
+		}
+
+		var val float64
+		if cur, ok := hash.fields[field]; ok {
+			val, err = strconv.ParseFloat(string(cur), 64)
+			if err != nil {
+				return 0, errNotFloat
+			}
+		}
+
+		val += incr
+		hash.set(field, strconv.AppendFloat(nil, val, 'f', -1, 64), true)
+		tx.changed(key, it, len(hash.fields))
+		return val, nil
+	})
+	return newResult(n, err)
+}
+
+// HKeys implements caches.HashCommand.
+func (p *Provider) HKeys(ctx context.Context, key string) caches.Result[[]string] {
+	key = p.prefix + key
+	keys, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]string, error) {
+		hash, _, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		return sortedFields(hash, tx.now), nil
+	})
+	return newResult(keys, err)
+}
+
+// HLen implements caches.HashCommand.
+func (p *Provider) HLen(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		hash, _, err := lookup[*hashValue](tx, key)
+		return int64(len(sortedFields(hash, tx.now))), err
+	})
+	return newResult(n, err)
+}
+
+// HMGet implements caches.HashCommand.
+func (p *Provider) HMGet(ctx context.Context, key string, fields ...string) caches.Result[map[string][]byte] {
+	key = p.prefix + key
+	values, err := viewAndReturn(ctx, p.db, func(tx *tx) (map[string][]byte, error) {
+		hash, it, err := lookup[*hashValue](tx, key)
+		if err != nil || it == nil {
+			return map[string][]byte{}, err
+		}
+
+		result := make(map[string][]byte, len(fields))
+		for _, field := range fields {
+			if v, ok := hash.get(field, tx.now); ok {
+				result[field] = v
+			}
+		}
+		return result, nil
+	})
+	return newResult(values, err)
+}
+
+// HMSet implements caches.HashCommand.
+func (p *Provider) HMSet(ctx context.Context, key string, values map[string]any) caches.StatusResult {
+	if err := p.HSet(ctx, key, values).Err(); err != nil {
+		return newStatusResult(nil, err)
+	}
+	return newStatusResult([]byte("OK"), nil)
+}
+
+// HSetNX implements caches.HashCommand.
+func (p *Provider) HSetNX(ctx context.Context, key, field string, value any) caches.Result[bool] {
+	key = p.prefix + key
+	data, err := toBytes(value)
+	if err != nil {
+		return newResult(false, err)
+	}
+
+	set, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		hash, it, err := lookupOrCreate(tx, key, newHash)
+		if err != nil {
+			return false, err
+		}
+
+		if _, ok := hash.fields[field]; ok {
+			return false, nil
+		}
+
+		hash.set(field, data, false)
+		tx.changed(key, it, len(hash.fields))
+		return true, nil
+	})
+	return newResult(set, err)
+}
+
+// HVals implements caches.HashCommand.
+func (p *Provider) HVals(ctx context.Context, key string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		hash, _, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := sortedFields(hash, tx.now)
+		result := make([][]byte, len(fields))
+		for i, field := range fields {
+			result[i] = hash.fields[field]
+		}
+		return result, nil
+	})
+	return newResult(vals, err)
+}
+
+// HScan implements caches.HashCommand.
+func (p *Provider) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) caches.Result[caches.HScanResult] {
+	key = p.prefix + key
+	result, err := viewAndReturn(ctx, p.db, func(tx *tx) (caches.HScanResult, error) {
+		hash, _, err := lookup[*hashValue](tx, key)
+		if err != nil {
+			return caches.HScanResult{}, err
+		}
+
+		fields := sortedFields(hash, tx.now)
+		matched := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if matchPattern(match, field) {
+				matched = append(matched, field)
+			}
+		}
+
+		page, next := scanPage(matched, cursor, count)
+		values := make(map[string][]byte, len(page))
+		for _, field := range page {
+			values[field] = hash.fields[field]
+		}
+
+		return caches.HScanResult{
+			Cursor: next,
+			Fields: values,
+		}, nil
+	})
+	return newResult(result, err)
+}
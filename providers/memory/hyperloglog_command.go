@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/hll"
+)
+
+var errPFCountKeys = errors.New("memory: PFCOUNT requires at least one key")
+
+var _ caches.HyperLogLogCommand = (*Provider)(nil)
+
+// getHLL returns the HyperLogLog stored at key, empty if the key does not exist.
+func getHLL(tx *tx, key string) (*hll.HLL, bool, error) {
+	cur, it, err := lookup[[]byte](tx, key)
+	if err != nil || it == nil {
+		return hll.New(), false, err
+	}
+
+	h, err := hll.Parse(cur)
+	return h, true, err
+}
+
+// PFAdd implements caches.HyperLogLogCommand.
+func (p *Provider) PFAdd(ctx context.Context, key string, els ...any) caches.Result[int64] {
+	elems, err := toBytesSlice(els)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		h, exists, err := getHLL(tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		changed := !exists
+		for _, elem := range elems {
+			if h.Add(elem) {
+				changed = true
+			}
+		}
+		if !changed {
+			return 0, nil
+		}
+		setString(tx, key, h.Bytes(), true)
+		return 1, nil
+	})
+
+	return newResult(val, err)
+}
+
+// PFCount implements caches.HyperLogLogCommand.
+func (p *Provider) PFCount(ctx context.Context, keys ...string) caches.Result[int64] {
+	if len(keys) == 0 {
+		return newResult(int64(0), errPFCountKeys)
+	}
+
+	keys = prefixKeys(p.prefix, keys)
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		union := hll.New()
+		for _, key := range keys {
+			h, _, err := getHLL(tx, key)
+			if err != nil {
+				return 0, err
+			}
+			union.Merge(h)
+		}
+		return union.Count(), nil
+	})
+
+	return newResult(val, err)
+}
+
+// PFMerge implements caches.HyperLogLogCommand.
+func (p *Provider) PFMerge(ctx context.Context, dest string, keys ...string) caches.StatusResult {
+	dest = p.prefix + dest
+	keys = prefixKeys(p.prefix, keys)
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		union, _, err := getHLL(tx, dest)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			h, _, err := getHLL(tx, key)
+			if err != nil {
+				return nil, err
+			}
+			union.Merge(h)
+		}
+
+		setString(tx, dest, union.Bytes(), true)
+		return []byte("OK"), nil
+	})
+
+	return newStatusResult(val, err)
+}
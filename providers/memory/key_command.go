@@ -0,0 +1,437 @@
+package memory
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+type expireType uint
+
+const (
+	expire expireType = iota
+	expireNX
+	expireXX
+	expireGT
+	expireLT
+)
+
+var _ caches.KeyCommand = (*Provider)(nil)
+
+// DBSize implements caches.KeyCommand.
+func (p *Provider) DBSize(ctx context.Context) caches.Result[int64] {
+	if p.prefix != "" {
+		return p.DBSizePrefix(ctx, "")
+	}
+
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		return int64(len(tx.keys())), nil
+	})
+	return newResult(val, err)
+}
+
+// DBSizePrefix implements caches.KeyCommand.
+func (p *Provider) DBSizePrefix(ctx context.Context, prefix string) caches.Result[int64] {
+	prefix = p.prefix + prefix
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		var n int64
+		for _, key := range tx.keys() {
+			if strings.HasPrefix(key, prefix) {
+				n++
+			}
+		}
+		return n, nil
+	})
+	return newResult(val, err)
+}
+
+// Del implements caches.KeyCommand.
+func (p *Provider) Del(ctx context.Context, keys ...string) caches.Result[int64] {
+	keys = prefixKeys(p.prefix, keys)
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		var n int64
+		for _, key := range keys {
+			if tx.del(key) {
+				n++
+			}
+		}
+		return n, nil
+	})
+	return newResult(val, err)
+}
+
+// Exists implements caches.KeyCommand.
+func (p *Provider) Exists(ctx context.Context, keys ...string) caches.Result[int64] {
+	keys = prefixKeys(p.prefix, keys)
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		var n int64
+		for _, key := range keys {
+			if tx.get(key) != nil {
+				n++
+			}
+		}
+		return n, nil
+	})
+	return newResult(val, err)
+}
+
+func (p *Provider) expire(ctx context.Context, key string, exp time.Duration, expType expireType) caches.Result[bool] {
+	secs := formatSec(exp)
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		it := tx.get(key)
+		if it == nil {
+			return false, nil
+		}
+
+		newETime := tx.now.Add(time.Duration(secs) * time.Second)
+		hasETime := !it.expireAt.IsZero()
+
+		shouldExpire := false
+		switch expType {
+		case expire:
+			shouldExpire = true
+		case expireNX:
+			// Only set when the key has no expiration
+			shouldExpire = !hasETime
+		case expireXX:
+			// Only set when the key already has an expiration
+			shouldExpire = hasETime
+		case expireGT:
+			// A key without expiration is treated as an infinite TTL
+			shouldExpire = hasETime && newETime.After(it.expireAt)
+		case expireLT:
+			shouldExpire = hasETime && newETime.Before(it.expireAt)
+		}
+
+		if !shouldExpire {
+			return false, nil
+		}
+
+		it.expireAt = newETime
+		tx.touch(it)
+		return true, nil
+	})
+
+	return newResult(val, err)
+}
+
+// Expire implements caches.KeyCommand.
+func (p *Provider) Expire(ctx context.Context, key string, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expire(ctx, key, expiration, expire)
+}
+
+// ExpireNX implements caches.KeyCommand.
+func (p *Provider) ExpireNX(ctx context.Context, key string, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expire(ctx, key, expiration, expireNX)
+}
+
+// ExpireXX implements caches.KeyCommand.
+func (p *Provider) ExpireXX(ctx context.Context, key string, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expire(ctx, key, expiration, expireXX)
+}
+
+// ExpireGT implements caches.KeyCommand.
+func (p *Provider) ExpireGT(ctx context.Context, key string, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expire(ctx, key, expiration, expireGT)
+}
+
+// ExpireLT implements caches.KeyCommand.
+func (p *Provider) ExpireLT(ctx context.Context, key string, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expire(ctx, key, expiration, expireLT)
+}
+
+func (p *Provider) expireAt(ctx context.Context, key string, tm time.Time) caches.Result[bool] {
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		it := tx.get(key)
+		if it == nil {
+			return false, nil
+		}
+
+		it.expireAt = tm
+		tx.touch(it)
+		return true, nil
+	})
+	return newResult(val, err)
+}
+
+// ExpireAt implements caches.KeyCommand.
+func (p *Provider) ExpireAt(ctx context.Context, key string, tm time.Time) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expireAt(ctx, key, tm.Truncate(time.Millisecond))
+}
+
+func (p *Provider) expireTime(ctx context.Context, key string) (time.Time, bool, error) {
+	type Res struct {
+		ETime  time.Time
+		Exists bool
+	}
+
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (Res, error) {
+		it := tx.get(key)
+		if it == nil {
+			return Res{}, nil
+		}
+		return Res{ETime: it.expireAt, Exists: true}, nil
+	})
+
+	return val.ETime, val.Exists, err
+}
+
+// ttlResult converts an expiration lookup into the -2 (missing) / -1 (persistent)
+// convention shared by TTL-style commands.
+func (p *Provider) ttlResult(ctx context.Context, key string, conv func(time.Time) time.Duration) caches.Result[time.Duration] {
+	exp, exists, err := p.expireTime(ctx, key)
+	if err != nil {
+		return newResult(time.Duration(0), err)
+	}
+
+	if !exists {
+		return newResult(time.Duration(-2), nil)
+	}
+
+	if exp.IsZero() {
+		return newResult(time.Duration(-1), nil)
+	}
+
+	return newResult(conv(exp), nil)
+}
+
+// ExpireTime implements caches.KeyCommand.
+func (p *Provider) ExpireTime(ctx context.Context, key string) caches.Result[time.Duration] {
+	key = p.prefix + key
+	return p.ttlResult(ctx, key, func(exp time.Time) time.Duration {
+		return time.Duration(exp.Unix()) * time.Second
+	})
+}
+
+// PExpire implements caches.KeyCommand.
+func (p *Provider) PExpire(ctx context.Context, key string, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	ms := formatMs(expiration)
+	return p.expireAt(ctx, key, time.Now().Add(time.Duration(ms)*time.Millisecond))
+}
+
+// PExpireAt implements caches.KeyCommand.
+func (p *Provider) PExpireAt(ctx context.Context, key string, tm time.Time) caches.Result[bool] {
+	key = p.prefix + key
+	return p.expireAt(ctx, key, tm)
+}
+
+// PExpireTime implements caches.KeyCommand.
+func (p *Provider) PExpireTime(ctx context.Context, key string) caches.Result[time.Duration] {
+	key = p.prefix + key
+	return p.ttlResult(ctx, key, func(exp time.Time) time.Duration {
+		return time.Duration(exp.UnixMilli()) * time.Millisecond
+	})
+}
+
+// FlushAll implements caches.KeyCommand.
+func (p *Provider) FlushAll(ctx context.Context) caches.StatusResult {
+	if p.prefix != "" {
+		if err := p.FlushPrefix(ctx, "").Err(); err != nil {
+			return newStatusResult(nil, err)
+		}
+		return newStatusResult([]byte("OK"), nil)
+	}
+
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		tx.db.items = make(map[string]*item)
+		return []byte("OK"), nil
+	})
+
+	return newStatusResult(val, err)
+}
+
+// FlushPrefix implements caches.KeyCommand.
+func (p *Provider) FlushPrefix(ctx context.Context, prefix string) caches.Result[int64] {
+	prefix = p.prefix + prefix
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		var n int64
+		for _, key := range tx.keys() {
+			if strings.HasPrefix(key, prefix) && tx.del(key) {
+				n++
+			}
+		}
+		return n, nil
+	})
+	return newResult(val, err)
+}
+
+// Keys implements caches.KeyCommand.
+func (p *Provider) Keys(ctx context.Context, pattern string) caches.Result[[]string] {
+	pattern = p.prefix + pattern
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]string, error) {
+		res := make([]string, 0)
+		for _, key := range tx.keys() {
+			if strings.HasPrefix(key, p.prefix) && matchPattern(pattern, key) {
+				res = append(res, p.trimPrefix(key))
+			}
+		}
+		return res, nil
+	})
+
+	return newResult(val, err)
+}
+
+// TTL implements caches.KeyCommand.
+func (p *Provider) TTL(ctx context.Context, key string) caches.Result[time.Duration] {
+	key = p.prefix + key
+	return p.ttlResult(ctx, key, func(exp time.Time) time.Duration {
+		return max(time.Until(exp).Truncate(time.Second), 0)
+	})
+}
+
+// PTTL implements caches.KeyCommand.
+func (p *Provider) PTTL(ctx context.Context, key string) caches.Result[time.Duration] {
+	key = p.prefix + key
+	return p.ttlResult(ctx, key, func(exp time.Time) time.Duration {
+		return max(time.Until(exp), 0)
+	})
+}
+
+// Persist implements caches.KeyCommand.
+func (p *Provider) Persist(ctx context.Context, key string) caches.Result[bool] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		it := tx.get(key)
+		if it == nil || it.expireAt.IsZero() {
+			return false, nil
+		}
+
+		it.expireAt = time.Time{}
+		tx.touch(it)
+		return true, nil
+	})
+	return newResult(val, err)
+}
+
+// rename moves the item stored under key to newKey, keeping its expiration.
+func rename(tx *tx, key, newKey string) error {
+	it := tx.get(key)
+	if it == nil {
+		return caches.Nil
+	}
+
+	if key == newKey {
+		return nil
+	}
+
+	tx.del(newKey)
+	delete(tx.db.items, key)
+	tx.db.items[newKey] = it
+	tx.touch(it)
+	return nil
+}
+
+// Rename implements caches.KeyCommand.
+func (p *Provider) Rename(ctx context.Context, key string, newKey string) caches.StatusResult {
+	key = p.prefix + key
+	newKey = p.prefix + newKey
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		if err := rename(tx, key, newKey); err != nil {
+			return nil, err
+		}
+		return []byte("OK"), nil
+	})
+	return newStatusResult(val, err)
+}
+
+// RenameNX implements caches.KeyCommand.
+func (p *Provider) RenameNX(ctx context.Context, key string, newKey string) caches.Result[bool] {
+	key = p.prefix + key
+	newKey = p.prefix + newKey
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		if tx.get(newKey) != nil {
+			return false, nil
+		}
+
+		if err := rename(tx, key, newKey); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	return newResult(val, err)
+}
+
+// Type implements caches.KeyCommand.
+func (p *Provider) Type(ctx context.Context, key string) caches.Result[string] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (string, error) {
+		it := tx.get(key)
+		if it == nil {
+			return "none", nil
+		}
+
+		switch it.value.(type) {
+		case []byte:
+			return "string", nil
+		case *listValue:
+			return "list", nil
+		case setValue:
+			return "set", nil
+		case *hashValue:
+			return "hash", nil
+		case zsetValue:
+			return "zset", nil
+		default:
+			return "unknown", nil
+		}
+	})
+	return newResult(val, err)
+}
+
+// RandomKey implements caches.KeyCommand.
+func (p *Provider) RandomKey(ctx context.Context) caches.Result[string] {
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (string, error) {
+		keys := make([]string, 0)
+		for _, key := range tx.keys() {
+			if strings.HasPrefix(key, p.prefix) {
+				keys = append(keys, key)
+			}
+		}
+
+		if len(keys) == 0 {
+			return "", caches.Nil
+		}
+
+		return p.trimPrefix(keys[rand.Intn(len(keys))]), nil
+	})
+	return newResult(val, err)
+}
+
+// Scan implements caches.KeyCommand.
+func (p *Provider) Scan(ctx context.Context, cursor uint64, match string, count int64) caches.Result[caches.KeyScanResult] {
+	pattern := p.prefix + match
+	if match == "" {
+		pattern = p.prefix + "*"
+	}
+
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (caches.KeyScanResult, error) {
+		matched := make([]string, 0)
+		for _, key := range tx.keys() {
+			if strings.HasPrefix(key, p.prefix) && matchPattern(pattern, key) {
+				matched = append(matched, key)
+			}
+		}
+
+		page, next := scanPage(matched, cursor, count)
+		keys := make([]string, len(page))
+		for i, key := range page {
+			keys[i] = p.trimPrefix(key)
+		}
+
+		return caches.KeyScanResult{
+			Cursor: next,
+			Keys:   keys,
+		}, nil
+	})
+	return newResult(val, err)
+}
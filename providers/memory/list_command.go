@@ -0,0 +1,583 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var (
+	errIndexOutOfRange = errors.New("memory: index out of range")
+	errInvalidCount    = errors.New("memory: count should be greater than 0")
+)
+
+var _ caches.ListCommand = (*Provider)(nil)
+
+func newList() *listValue {
+	return &listValue{}
+}
+
+// normalizeRange converts inclusive start/stop indexes, which may be negative,
+// into a half-open [lo, hi) range over a collection of length n.
+func normalizeRange(start, stop int64, n int) (int, int) {
+	length := int64(n)
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	if start > stop || start >= length {
+		return 0, 0
+	}
+	return int(start), int(stop) + 1
+}
+
+// normalizeIndex converts a possibly negative index and reports whether it is in range.
+func normalizeIndex(index int64, n int) (int, bool) {
+	if index < 0 {
+		index += int64(n)
+	}
+	if index < 0 || index >= int64(n) {
+		return 0, false
+	}
+	return int(index), true
+}
+
+// listMove pops an element from the srcpos end of source and pushes it to the destpos end of destination.
+func listMove(tx *tx, source, destination string, srcpos, destpos caches.ListDirection) ([]byte, error) {
+	// Check the destination type before touching the source
+	if _, _, err := lookup[*listValue](tx, destination); err != nil {
+		return nil, err
+	}
+
+	vals, err := popList(tx, source, 1, srcpos == caches.ListRight)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := pushList(tx, destination, vals, destpos == caches.ListRight); err != nil {
+		return nil, err
+	}
+	return vals[0], nil
+}
+
+// BLMove implements caches.ListCommand.
+func (p *Provider) BLMove(ctx context.Context, source, destination string, srcpos, destpos caches.ListDirection, timeout time.Duration) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	val, err := block(ctx, p.waiter, timeout, func() ([]byte, error) {
+		return updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+			return listMove(tx, source, destination, srcpos, destpos)
+		})
+	})
+	return newResult(val, err)
+}
+
+// blockingPop pops an element from the first non-empty list among keys, waiting for one if needed.
+func (p *Provider) blockingPop(ctx context.Context, timeout time.Duration, keys []string, back bool) caches.Result[caches.ListPopResult] {
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := block(ctx, p.waiter, timeout, func() (caches.ListPopResult, error) {
+		return updateAndReturn(ctx, p.db, func(tx *tx) (caches.ListPopResult, error) {
+			for i, key := range prefixed {
+				vals, err := popList(tx, key, 1, back)
+				if err == caches.Nil {
+					continue
+				}
+				if err != nil {
+					return caches.ListPopResult{}, err
+				}
+				return caches.ListPopResult{Key: keys[i], Element: vals[0]}, nil
+			}
+			return caches.ListPopResult{}, caches.Nil
+		})
+	})
+	return newResult(res, err)
+}
+
+// BLPop implements caches.ListCommand.
+func (p *Provider) BLPop(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ListPopResult] {
+	return p.blockingPop(ctx, timeout, keys, false)
+}
+
+// BRPop implements caches.ListCommand.
+func (p *Provider) BRPop(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ListPopResult] {
+	return p.blockingPop(ctx, timeout, keys, true)
+}
+
+// BRPopLPush implements caches.ListCommand.
+func (p *Provider) BRPopLPush(ctx context.Context, source, destination string, timeout time.Duration) caches.Result[[]byte] {
+	return p.BLMove(ctx, source, destination, caches.ListRight, caches.ListLeft, timeout)
+}
+
+// LIndex implements caches.ListCommand.
+func (p *Provider) LIndex(ctx context.Context, key string, index int64) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return nil, caches.Nil
+		}
+
+		i, ok := normalizeIndex(index, len(list.elems))
+		if !ok {
+			return nil, caches.Nil
+		}
+		return list.elems[i], nil
+	})
+	return newResult(val, err)
+}
+
+// LInsert implements caches.ListCommand.
+func (p *Provider) LInsert(ctx context.Context, key string, position caches.LInsertPosition, pivot, element any) caches.Result[int64] {
+	key = p.prefix + key
+	pivotData, err := toBytes(pivot)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+	elemData, err := toBytes(element)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return 0, err
+		}
+		if it == nil {
+			return 0, nil
+		}
+
+		for i, elem := range list.elems {
+			if !bytes.Equal(elem, pivotData) {
+				continue
+			}
+
+			if position == caches.LInsertAfter {
+				i++
+			}
+			list.elems = append(list.elems[:i], append([][]byte{elemData}, list.elems[i:]...)...)
+			tx.touch(it)
+			return int64(len(list.elems)), nil
+		}
+
+		// Redis returns -1 when pivot is not found, not an error
+		return -1, nil
+	})
+	return newResult(n, err)
+}
+
+// LLen implements caches.ListCommand.
+func (p *Provider) LLen(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil || it == nil {
+			return 0, err
+		}
+		return int64(len(list.elems)), nil
+	})
+	return newResult(n, err)
+}
+
+// popList removes up to count elements from the head (or tail) of the list.
+func popList(tx *tx, key string, count int, back bool) ([][]byte, error) {
+	list, it, err := lookup[*listValue](tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if it == nil {
+		return nil, caches.Nil
+	}
+
+	count = min(count, len(list.elems))
+	result := make([][]byte, count)
+	for i := 0; i < count; i++ {
+		if back {
+			result[i] = list.elems[len(list.elems)-1]
+			list.elems = list.elems[:len(list.elems)-1]
+		} else {
+			result[i] = list.elems[0]
+			list.elems = list.elems[1:]
+		}
+	}
+
+	tx.changed(key, it, len(list.elems))
+	return result, nil
+}
+
+func (p *Provider) pop(ctx context.Context, key string, back bool) caches.Result[[]byte] {
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		vals, err := popList(tx, key, 1, back)
+		if err != nil {
+			return nil, err
+		}
+		return vals[0], nil
+	})
+	return newResult(val, err)
+}
+
+func (p *Provider) popCount(ctx context.Context, key string, count int, back bool) caches.Result[[][]byte] {
+	vals, err := updateAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		vals, err := popList(tx, key, count, back)
+		if err == caches.Nil {
+			return [][]byte{}, nil
+		}
+		return vals, err
+	})
+	return newResult(vals, err)
+}
+
+// LMove implements caches.ListCommand.
+func (p *Provider) LMove(ctx context.Context, source, destination string, srcpos, destpos caches.ListDirection) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		return listMove(tx, source, destination, srcpos, destpos)
+	})
+	return newResult(val, err)
+}
+
+// LMPop implements caches.ListCommand.
+func (p *Provider) LMPop(ctx context.Context, direction caches.ListDirection, count int64, keys ...string) caches.Result[caches.ListMPopResult] {
+	if count <= 0 {
+		return newResult(caches.ListMPopResult{}, errInvalidCount)
+	}
+
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := updateAndReturn(ctx, p.db, func(tx *tx) (caches.ListMPopResult, error) {
+		for i, key := range prefixed {
+			vals, err := popList(tx, key, int(count), direction == caches.ListRight)
+			if err == caches.Nil {
+				continue
+			}
+			if err != nil {
+				return caches.ListMPopResult{}, err
+			}
+			return caches.ListMPopResult{Key: keys[i], Elements: vals}, nil
+		}
+		return caches.ListMPopResult{}, caches.Nil
+	})
+	return newResult(res, err)
+}
+
+// LPop implements caches.ListCommand.
+func (p *Provider) LPop(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	return p.pop(ctx, key, false)
+}
+
+// LPopCount implements caches.ListCommand.
+func (p *Provider) LPopCount(ctx context.Context, key string, count int) caches.Result[[][]byte] {
+	key = p.prefix + key
+	return p.popCount(ctx, key, count, false)
+}
+
+// pushList adds elements to the head (or tail) of the list and returns its new length.
+func pushList(tx *tx, key string, elems [][]byte, back bool) (int64, error) {
+	list, it, err := lookupOrCreate(tx, key, newList)
+	if err != nil {
+		return 0, err
+	}
+
+	if back {
+		list.elems = append(list.elems, elems...)
+	} else {
+		// Elements are pushed one after another, so the last one ends up first
+		head := make([][]byte, len(elems), len(elems)+len(list.elems))
+		for i, elem := range elems {
+			head[len(elems)-1-i] = elem
+		}
+		list.elems = append(head, list.elems...)
+	}
+
+	tx.changed(key, it, len(list.elems))
+	return int64(len(list.elems)), nil
+}
+
+func (p *Provider) push(ctx context.Context, key string, elements []any, back bool) caches.Result[int64] {
+	elems, err := toBytesSlice(elements)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		return pushList(tx, key, elems, back)
+	})
+	return newResult(n, err)
+}
+
+// listPositions returns the indexes of the elements equal to element, following LPOS semantics.
+// A zero count returns all matches.
+func listPositions(elems [][]byte, element []byte, rank, count, maxLen int64) []int64 {
+	if rank == 0 {
+		rank = 1
+	}
+
+	n := int64(len(elems))
+	if maxLen <= 0 || maxLen > n {
+		maxLen = n
+	}
+
+	// Skip the first |rank|-1 matches
+	skip := rank - 1
+	if rank < 0 {
+		skip = -rank - 1
+	}
+
+	result := []int64{}
+	for i := int64(0); i < maxLen; i++ {
+		idx := i
+		if rank < 0 {
+			idx = n - 1 - i
+		}
+		if !bytes.Equal(elems[idx], element) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		result = append(result, idx)
+		if count > 0 && int64(len(result)) == count {
+			break
+		}
+	}
+	return result
+}
+
+// LPos implements caches.ListCommand.
+func (p *Provider) LPos(ctx context.Context, key string, element string, args caches.LPosArgs) caches.Result[int64] {
+	key = p.prefix + key
+	pos, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return 0, err
+		}
+		if it == nil {
+			return 0, caches.Nil
+		}
+
+		positions := listPositions(list.elems, []byte(element), args.Rank, 1, args.MaxLen)
+		if len(positions) == 0 {
+			return 0, caches.Nil
+		}
+		return positions[0], nil
+	})
+	return newResult(pos, err)
+}
+
+// LPosCount implements caches.ListCommand.
+func (p *Provider) LPosCount(ctx context.Context, key string, element string, count int64, args caches.LPosArgs) caches.Result[[]int64] {
+	key = p.prefix + key
+	positions, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]int64, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return []int64{}, nil
+		}
+		return listPositions(list.elems, []byte(element), args.Rank, count, args.MaxLen), nil
+	})
+	return newResult(positions, err)
+}
+
+// LPush implements caches.ListCommand.
+func (p *Provider) LPush(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	return p.push(ctx, key, elements, false)
+}
+
+// pushX pushes elements to an existing list, and does nothing when key does not exist.
+func (p *Provider) pushX(ctx context.Context, key string, elements []any, back bool) caches.Result[int64] {
+	elems, err := toBytesSlice(elements)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		_, it, err := lookup[*listValue](tx, key)
+		if err != nil || it == nil {
+			return 0, err
+		}
+		return pushList(tx, key, elems, back)
+	})
+	return newResult(n, err)
+}
+
+// LPushX implements caches.ListCommand.
+func (p *Provider) LPushX(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	return p.pushX(ctx, key, elements, false)
+}
+
+// LRange implements caches.ListCommand.
+func (p *Provider) LRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return [][]byte{}, nil
+		}
+
+		lo, hi := normalizeRange(start, stop, len(list.elems))
+		return append([][]byte{}, list.elems[lo:hi]...), nil
+	})
+	return newResult(vals, err)
+}
+
+// LRem implements caches.ListCommand.
+func (p *Provider) LRem(ctx context.Context, key string, count int64, element any) caches.Result[int64] {
+	key = p.prefix + key
+	elemData, err := toBytes(element)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil || it == nil {
+			return 0, err
+		}
+
+		// count > 0 removes from head to tail, count < 0 from tail to head,
+		// count == 0 removes all occurrences.
+		limit := count
+		if limit < 0 {
+			limit = -limit
+		}
+
+		removed := make([]bool, len(list.elems))
+		var deleted int64
+		for i := range list.elems {
+			idx := i
+			if count < 0 {
+				idx = len(list.elems) - 1 - i
+			}
+			if limit > 0 && deleted >= limit {
+				break
+			}
+			if bytes.Equal(list.elems[idx], elemData) {
+				removed[idx] = true
+				deleted++
+			}
+		}
+
+		if deleted == 0 {
+			return 0, nil
+		}
+
+		kept := list.elems[:0]
+		for i, elem := range list.elems {
+			if !removed[i] {
+				kept = append(kept, elem)
+			}
+		}
+		list.elems = kept
+
+		tx.changed(key, it, len(list.elems))
+		return deleted, nil
+	})
+	return newResult(n, err)
+}
+
+// LSet implements caches.ListCommand.
+func (p *Provider) LSet(ctx context.Context, key string, index int64, element any) caches.StatusResult {
+	key = p.prefix + key
+	elemData, err := toBytes(element)
+	if err != nil {
+		return newStatusResult(nil, err)
+	}
+
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return nil, caches.Nil
+		}
+
+		i, ok := normalizeIndex(index, len(list.elems))
+		if !ok {
+			return nil, errIndexOutOfRange
+		}
+
+		list.elems[i] = elemData
+		tx.touch(it)
+		return []byte("OK"), nil
+	})
+	return newStatusResult(val, err)
+}
+
+// LTrim implements caches.ListCommand.
+func (p *Provider) LTrim(ctx context.Context, key string, start, stop int64) caches.StatusResult {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		list, it, err := lookup[*listValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if it != nil {
+			lo, hi := normalizeRange(start, stop, len(list.elems))
+			list.elems = append([][]byte{}, list.elems[lo:hi]...)
+			tx.changed(key, it, len(list.elems))
+		}
+
+		return []byte("OK"), nil
+	})
+	return newStatusResult(val, err)
+}
+
+// RPop implements caches.ListCommand.
+func (p *Provider) RPop(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	return p.pop(ctx, key, true)
+}
+
+// RPopCount implements caches.ListCommand.
+func (p *Provider) RPopCount(ctx context.Context, key string, count int) caches.Result[[][]byte] {
+	key = p.prefix + key
+	return p.popCount(ctx, key, count, true)
+}
+
+// RPush implements caches.ListCommand.
+func (p *Provider) RPush(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	return p.push(ctx, key, elements, true)
+}
+
+// RPopLPush implements caches.ListCommand.
+func (p *Provider) RPopLPush(ctx context.Context, source, destination string) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		return listMove(tx, source, destination, caches.ListRight, caches.ListLeft)
+	})
+	return newResult(val, err)
+}
+
+// RPushX implements caches.ListCommand.
+func (p *Provider) RPushX(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	return p.pushX(ctx, key, elements, true)
+}
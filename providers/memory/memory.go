@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"strings"
+
+	"github.com/rockcookies/go-caches"
+)
+
+type Options struct {
+	Prefix string
+}
+
+type Provider struct {
+	db     store
+	prefix string
+
+	// waiter wakes up blocked commands, it is nil within a transaction
+	waiter *waiter
+
+	// broker delivers published messages to the subscribers of the provider
+	broker *caches.Broker
+
+	// scripts holds the Go implementations of the scripts run by Eval
+	scripts *caches.ScriptRegistry
+}
+
+func New() *Provider {
+	return NewWithOptions(nil)
+}
+
+func NewWithOptions(opts *Options) *Provider {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	d := newDB()
+	return &Provider{
+		db:      d,
+		prefix:  strings.TrimSpace(opts.Prefix),
+		waiter:  d.waiter,
+		broker:  caches.NewBroker(),
+		scripts: caches.NewScriptRegistry(),
+	}
+}
+
+func (p *Provider) Prefix() string {
+	return p.prefix
+}
+
+var _ caches.Cache = (*Provider)(nil)
+
+// Capabilities implements caches.Capable.
+//
+// Streams are not supported, and scripts are Go functions registered with the provider.
+func (p *Provider) Capabilities() caches.Capabilities {
+	return caches.CapKeepTTL |
+		caches.CapSetGet |
+		caches.CapZRangeByLex |
+		caches.CapZScoreExclusive |
+		caches.CapZStoreWeights |
+		caches.CapHashFieldTTL |
+		caches.CapHyperLogLog |
+		caches.CapGeo |
+		caches.CapBitmap |
+		caches.CapCompare |
+		caches.CapRateLimit |
+		caches.CapScript |
+		caches.CapPipeline |
+		caches.CapTx |
+		caches.CapPubSub
+}
+
+// withTx returns a copy of the provider that runs all commands within tx.
+// Blocking commands do not wait within a transaction, as in Redis MULTI.
+func (p *Provider) withTx(tx *tx) *Provider {
+	bound := *p
+	bound.db = txStore{tx: tx}
+	bound.waiter = nil
+	return &bound
+}
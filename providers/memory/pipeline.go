@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.PipelineCommand = (*Provider)(nil)
+
+// newPipeline returns a pipeline that applies the queued commands
+// under a single write lock, once check succeeds.
+func (p *Provider) newPipeline(check func(tx *tx) error) caches.TxPipeliner {
+	return caches.NewPipeline(p.Capabilities(), func(ctx context.Context, run func(c caches.Cache)) error {
+		return p.db.update(ctx, func(tx *tx) error {
+			if check != nil {
+				if err := check(tx); err != nil {
+					return err
+				}
+			}
+			run(p.withTx(tx))
+			return nil
+		})
+	})
+}
+
+// Pipeline implements caches.PipelineCommand.
+//
+// Queued commands are applied under a single write lock on Exec.
+func (p *Provider) Pipeline() caches.Pipeliner {
+	return p.newPipeline(nil)
+}
+
+// Pipelined implements caches.PipelineCommand.
+func (p *Provider) Pipelined(ctx context.Context, fn func(pipe caches.Pipeliner) error) error {
+	pipe := p.Pipeline()
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	return pipe.Exec(ctx)
+}
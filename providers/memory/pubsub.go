@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.PubSub = (*Provider)(nil)
+
+// Publish implements caches.PubSub.
+// Messages are delivered by an in-process broker,
+// only the subscribers of the same Provider receive them.
+func (p *Provider) Publish(ctx context.Context, channel string, message any) caches.Result[int64] {
+	payload, err := toBytes(message)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+	return newResult(p.broker.Publish(ctx, channel, payload))
+}
+
+// PSubscribe implements caches.PubSub.
+func (p *Provider) PSubscribe(ctx context.Context, patterns ...string) caches.Subscription {
+	return p.broker.PSubscribe(patterns...)
+}
+
+// Subscribe implements caches.PubSub.
+func (p *Provider) Subscribe(ctx context.Context, channels ...string) caches.Subscription {
+	return p.broker.Subscribe(channels...)
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.RateLimitCommand = (*Provider)(nil)
+
+// denied returns the outcome of a denied request,
+// which can be retried after retryAfter unless it exceeds the limit.
+func denied(remaining int64, retryAfter time.Duration, exceeded bool) caches.RateLimit {
+	if exceeded {
+		retryAfter = -1
+	}
+	return caches.RateLimit{Remaining: max(remaining, 0), RetryAfter: retryAfter}
+}
+
+// RateLimitFixedWindow implements caches.RateLimitCommand.
+func (p *Provider) RateLimitFixedWindow(ctx context.Context, key string, limit int64, window time.Duration, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (caches.RateLimit, error) {
+		cur, it, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return caches.RateLimit{}, err
+		}
+
+		var count int64
+		if it != nil {
+			count, err = strconv.ParseInt(string(cur), 10, 64)
+			if err != nil {
+				return caches.RateLimit{}, errNotInteger
+			}
+		}
+
+		if count+n > limit {
+			retryAfter := window
+			if it != nil && !it.expireAt.IsZero() {
+				retryAfter = it.expireAt.Sub(tx.now)
+			}
+			return denied(limit-count, retryAfter, n > limit), nil
+		}
+
+		if n > 0 {
+			it = setString(tx, key, strconv.AppendInt(nil, count+n, 10), true)
+			if it.expireAt.IsZero() {
+				it.expireAt = tx.now.Add(time.Duration(formatMs(window)) * time.Millisecond)
+			}
+		}
+		return caches.RateLimit{Allowed: true, Remaining: limit - count - n}, nil
+	})
+	return newResult(val, err)
+}
+
+// RateLimitSlidingLog implements caches.RateLimitCommand.
+func (p *Provider) RateLimitSlidingLog(ctx context.Context, key string, limit int64, window time.Duration, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (caches.RateLimit, error) {
+		zset, it, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return caches.RateLimit{}, err
+		}
+
+		// Requests logged at or before now - window are out of the window
+		now := tx.now.UnixMicro()
+		start := float64(now - window.Microseconds())
+		size := len(zset)
+		scores := make([]float64, 0, size)
+		for member, score := range zset {
+			if score <= start {
+				delete(zset, member)
+				continue
+			}
+			scores = append(scores, score)
+		}
+		if len(zset) < size {
+			tx.changed(key, it, len(zset))
+		}
+
+		count := int64(len(scores))
+		if count+n > limit {
+			// The request fits once the oldest count + n - limit requests left the window
+			var retryAfter time.Duration
+			if n <= limit {
+				sort.Float64s(scores)
+				oldest := int64(scores[count+n-limit-1])
+				retryAfter = time.Duration(oldest-now)*time.Microsecond + window
+			}
+			return denied(limit-count, retryAfter, n > limit), nil
+		}
+
+		if n > 0 {
+			if it == nil || len(zset) == 0 {
+				zset = newZSet()
+				it = tx.put(key, zset)
+			}
+			// Members are made unique by the number of requests already logged
+			for i := int64(0); i < n; i++ {
+				zset[strconv.FormatInt(now, 10)+"-"+strconv.FormatInt(count+i, 10)] = float64(now)
+			}
+			it.expireAt = tx.now.Add(time.Duration(formatMs(window)) * time.Millisecond)
+			tx.touch(it)
+		}
+		return caches.RateLimit{Allowed: true, Remaining: limit - count - n}, nil
+	})
+	return newResult(val, err)
+}
+
+// RateLimitGCRA implements caches.RateLimitCommand.
+func (p *Provider) RateLimitGCRA(ctx context.Context, key string, limit int64, period time.Duration, burst, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (caches.RateLimit, error) {
+		cur, it, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return caches.RateLimit{}, err
+		}
+
+		// Times are in microseconds, tat being the theoretical arrival time of the next request
+		interval := max(period.Microseconds()/limit, 1)
+		offset := interval * burst
+		now := tx.now.UnixMicro()
+		tat := now
+		if it != nil {
+			stored, err := strconv.ParseInt(string(cur), 10, 64)
+			if err != nil {
+				return caches.RateLimit{}, errNotInteger
+			}
+			tat = max(stored, now)
+		}
+
+		newTat := tat + interval*n
+		diff := now - (newTat - offset)
+		if diff < 0 {
+			return denied((now-tat+offset)/interval, time.Duration(-diff)*time.Microsecond, n > burst), nil
+		}
+
+		if n > 0 {
+			it = tx.put(key, strconv.AppendInt(nil, newTat, 10))
+			it.expireAt = tx.now.Add(time.Duration(newTat-now) * time.Microsecond)
+		}
+		return caches.RateLimit{Allowed: true, Remaining: diff / interval}, nil
+	})
+	return newResult(val, err)
+}
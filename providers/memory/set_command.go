@@ -0,0 +1,395 @@
+package memory
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.SetCommand = (*Provider)(nil)
+
+func newSet() setValue {
+	return make(setValue)
+}
+
+// sortedMembers returns the members of the set in a stable order.
+func sortedMembers(set setValue) []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+func toBytesList(members []string) [][]byte {
+	result := make([][]byte, len(members))
+	for i, member := range members {
+		result[i] = []byte(member)
+	}
+	return result
+}
+
+// combineSets applies a set operation over the sets stored under keys.
+// Missing keys are treated as empty sets.
+func combineSets(tx *tx, keys []string, op func(acc, next setValue) setValue) (setValue, error) {
+	var acc setValue
+	for i, key := range keys {
+		set, _, err := lookup[setValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			acc = make(setValue, len(set))
+			for member := range set {
+				acc[member] = struct{}{}
+			}
+			continue
+		}
+		acc = op(acc, set)
+	}
+
+	if acc == nil {
+		acc = newSet()
+	}
+	return acc, nil
+}
+
+func diffSets(acc, next setValue) setValue {
+	for member := range next {
+		delete(acc, member)
+	}
+	return acc
+}
+
+func interSets(acc, next setValue) setValue {
+	for member := range acc {
+		if _, ok := next[member]; !ok {
+			delete(acc, member)
+		}
+	}
+	return acc
+}
+
+func unionSets(acc, next setValue) setValue {
+	for member := range next {
+		acc[member] = struct{}{}
+	}
+	return acc
+}
+
+func (p *Provider) combine(ctx context.Context, keys []string, op func(acc, next setValue) setValue) caches.Result[[][]byte] {
+	keys = prefixKeys(p.prefix, keys)
+	vals, err := viewAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		set, err := combineSets(tx, keys, op)
+		if err != nil {
+			return nil, err
+		}
+		return toBytesList(sortedMembers(set)), nil
+	})
+	return newResult(vals, err)
+}
+
+func (p *Provider) combineStore(ctx context.Context, destination string, keys []string, op func(acc, next setValue) setValue) caches.Result[int64] {
+	destination = p.prefix + destination
+	keys = prefixKeys(p.prefix, keys)
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		set, err := combineSets(tx, keys, op)
+		if err != nil {
+			return 0, err
+		}
+
+		tx.del(destination)
+		if len(set) > 0 {
+			tx.put(destination, set)
+		}
+		return int64(len(set)), nil
+	})
+	return newResult(n, err)
+}
+
+// SAdd implements caches.SetCommand.
+func (p *Provider) SAdd(ctx context.Context, key string, members ...any) caches.Result[int64] {
+	key = p.prefix + key
+	data, err := toBytesSlice(members)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		set, it, err := lookupOrCreate(tx, key, newSet)
+		if err != nil {
+			return 0, err
+		}
+
+		var added int64
+		for _, member := range data {
+			if _, ok := set[string(member)]; !ok {
+				set[string(member)] = struct{}{}
+				added++
+			}
+		}
+
+		tx.changed(key, it, len(set))
+		return added, nil
+	})
+	return newResult(n, err)
+}
+
+// SCard implements caches.SetCommand.
+func (p *Provider) SCard(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		set, _, err := lookup[setValue](tx, key)
+		return int64(len(set)), err
+	})
+	return newResult(n, err)
+}
+
+// SDiff implements caches.SetCommand.
+func (p *Provider) SDiff(ctx context.Context, keys ...string) caches.Result[[][]byte] {
+	return p.combine(ctx, keys, diffSets)
+}
+
+// SDiffStore implements caches.SetCommand.
+func (p *Provider) SDiffStore(ctx context.Context, destination string, keys ...string) caches.Result[int64] {
+	return p.combineStore(ctx, destination, keys, diffSets)
+}
+
+// SInter implements caches.SetCommand.
+func (p *Provider) SInter(ctx context.Context, keys ...string) caches.Result[[][]byte] {
+	return p.combine(ctx, keys, interSets)
+}
+
+// SInterStore implements caches.SetCommand.
+func (p *Provider) SInterStore(ctx context.Context, destination string, keys ...string) caches.Result[int64] {
+	return p.combineStore(ctx, destination, keys, interSets)
+}
+
+// SIsMember implements caches.SetCommand.
+func (p *Provider) SIsMember(ctx context.Context, key string, member any) caches.Result[bool] {
+	key = p.prefix + key
+	data, err := toBytes(member)
+	if err != nil {
+		return newResult(false, err)
+	}
+
+	ok, err := viewAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		set, _, err := lookup[setValue](tx, key)
+		if err != nil {
+			return false, err
+		}
+
+		_, ok := set[string(data)]
+		return ok, nil
+	})
+	return newResult(ok, err)
+}
+
+// SMembers implements caches.SetCommand.
+func (p *Provider) SMembers(ctx context.Context, key string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		set, _, err := lookup[setValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		return toBytesList(sortedMembers(set)), nil
+	})
+	return newResult(vals, err)
+}
+
+// SMove implements caches.SetCommand.
+func (p *Provider) SMove(ctx context.Context, source, destination string, member any) caches.Result[bool] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	data, err := toBytes(member)
+	if err != nil {
+		return newResult(false, err)
+	}
+
+	moved, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		src, srcItem, err := lookup[setValue](tx, source)
+		if err != nil {
+			return false, err
+		}
+		if _, _, err := lookup[setValue](tx, destination); err != nil {
+			return false, err
+		}
+
+		if _, ok := src[string(data)]; !ok {
+			return false, nil
+		}
+
+		delete(src, string(data))
+		tx.changed(source, srcItem, len(src))
+
+		dst, dstItem, _ := lookupOrCreate(tx, destination, newSet)
+		dst[string(data)] = struct{}{}
+		tx.changed(destination, dstItem, len(dst))
+		return true, nil
+	})
+	return newResult(moved, err)
+}
+
+// randomMembers returns up to count distinct members picked at random.
+func randomMembers(set setValue, count int) []string {
+	members := sortedMembers(set)
+	rand.Shuffle(len(members), func(i, j int) {
+		members[i], members[j] = members[j], members[i]
+	})
+	return members[:min(count, len(members))]
+}
+
+// SPop implements caches.SetCommand.
+func (p *Provider) SPop(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		set, it, err := lookup[setValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return nil, caches.Nil
+		}
+
+		member := randomMembers(set, 1)[0]
+		delete(set, member)
+		tx.changed(key, it, len(set))
+		return []byte(member), nil
+	})
+	return newResult(val, err)
+}
+
+// SPopN implements caches.SetCommand.
+func (p *Provider) SPopN(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := updateAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		set, it, err := lookup[setValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil || count <= 0 {
+			return [][]byte{}, nil
+		}
+
+		members := randomMembers(set, int(count))
+		for _, member := range members {
+			delete(set, member)
+		}
+		tx.changed(key, it, len(set))
+		return toBytesList(members), nil
+	})
+	return newResult(vals, err)
+}
+
+// SRandMember implements caches.SetCommand.
+func (p *Provider) SRandMember(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		set, it, err := lookup[setValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if it == nil {
+			return nil, caches.Nil
+		}
+		return []byte(randomMembers(set, 1)[0]), nil
+	})
+	return newResult(val, err)
+}
+
+// SRandMemberN implements caches.SetCommand.
+func (p *Provider) SRandMemberN(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *tx) ([][]byte, error) {
+		set, _, err := lookup[setValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if len(set) == 0 {
+			return [][]byte{}, nil
+		}
+
+		if count >= 0 {
+			return toBytesList(randomMembers(set, int(count))), nil
+		}
+
+		// Negative count: allow duplicates
+		members := sortedMembers(set)
+		result := make([][]byte, -count)
+		for i := range result {
+			result[i] = []byte(members[rand.Intn(len(members))])
+		}
+		return result, nil
+	})
+	return newResult(vals, err)
+}
+
+// SRem implements caches.SetCommand.
+func (p *Provider) SRem(ctx context.Context, key string, members ...any) caches.Result[int64] {
+	key = p.prefix + key
+	data, err := toBytesSlice(members)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		set, it, err := lookup[setValue](tx, key)
+		if err != nil || it == nil {
+			return 0, err
+		}
+
+		var removed int64
+		for _, member := range data {
+			if _, ok := set[string(member)]; ok {
+				delete(set, string(member))
+				removed++
+			}
+		}
+
+		if removed > 0 {
+			tx.changed(key, it, len(set))
+		}
+		return removed, nil
+	})
+	return newResult(n, err)
+}
+
+// SScan implements caches.SetCommand.
+func (p *Provider) SScan(ctx context.Context, key string, cursor uint64, match string, count int64) caches.Result[caches.ScanResult] {
+	key = p.prefix + key
+	result, err := viewAndReturn(ctx, p.db, func(tx *tx) (caches.ScanResult, error) {
+		set, _, err := lookup[setValue](tx, key)
+		if err != nil {
+			return caches.ScanResult{}, err
+		}
+
+		matched := make([]string, 0, len(set))
+		for _, member := range sortedMembers(set) {
+			if matchPattern(match, member) {
+				matched = append(matched, member)
+			}
+		}
+
+		page, next := scanPage(matched, cursor, count)
+		return caches.ScanResult{
+			Cursor:   next,
+			Elements: toBytesList(page),
+		}, nil
+	})
+	return newResult(result, err)
+}
+
+// SUnion implements caches.SetCommand.
+func (p *Provider) SUnion(ctx context.Context, keys ...string) caches.Result[[][]byte] {
+	return p.combine(ctx, keys, unionSets)
+}
+
+// SUnionStore implements caches.SetCommand.
+func (p *Provider) SUnionStore(ctx context.Context, destination string, keys ...string) caches.Result[int64] {
+	return p.combineStore(ctx, destination, keys, unionSets)
+}
@@ -0,0 +1,1036 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var (
+	errInvalidScore = errors.New("memory: min or max is not a float")
+	errInvalidLex   = errors.New("memory: min or max not valid string range item")
+	errZMPopOrder   = errors.New("memory: order must be MIN or MAX")
+	errZNoKeys      = errors.New("memory: at least 1 input key is needed")
+	errZLimit       = errors.New("memory: LIMIT can't be negative")
+)
+
+var _ caches.SortedSetCommand = (*Provider)(nil)
+
+func newZSet() zsetValue {
+	return make(zsetValue)
+}
+
+// sortedZMembers returns the members ordered by score, then lexicographically.
+func sortedZMembers(zset zsetValue) []caches.ZMember {
+	members := make([]caches.ZMember, 0, len(zset))
+	for member, score := range zset {
+		members = append(members, caches.ZMember{Member: []byte(member), Score: score})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Score != members[j].Score {
+			return members[i].Score < members[j].Score
+		}
+		return bytes.Compare(members[i].Member, members[j].Member) < 0
+	})
+	return members
+}
+
+func reverseZMembers(members []caches.ZMember) []caches.ZMember {
+	for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+		members[i], members[j] = members[j], members[i]
+	}
+	return members
+}
+
+func zMemberNames(members []caches.ZMember) [][]byte {
+	result := make([][]byte, len(members))
+	for i, m := range members {
+		result[i] = m.Member
+	}
+	return result
+}
+
+// scoreBound is one end of a score interval such as "1.5", "(1.5" or "+inf".
+type scoreBound struct {
+	value     float64
+	exclusive bool
+}
+
+func parseScoreBound(val any) (scoreBound, error) {
+	switch v := val.(type) {
+	case float64:
+		return scoreBound{value: v}, nil
+	case float32:
+		return scoreBound{value: float64(v)}, nil
+	case int:
+		return scoreBound{value: float64(v)}, nil
+	case int64:
+		return scoreBound{value: float64(v)}, nil
+	case string:
+		var b scoreBound
+		if strings.HasPrefix(v, "(") {
+			b.exclusive = true
+			v = v[1:]
+		}
+		switch strings.ToLower(v) {
+		case "-inf":
+			b.value = math.Inf(-1)
+		case "+inf", "inf":
+			b.value = math.Inf(1)
+		default:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return b, errInvalidScore
+			}
+			b.value = f
+		}
+		return b, nil
+	default:
+		return scoreBound{}, errInvalidScore
+	}
+}
+
+// scoreRange is an interval of scores built from min and max bounds.
+type scoreRange struct {
+	min, max scoreBound
+}
+
+func parseScoreRange(min, max any) (scoreRange, error) {
+	lo, err := parseScoreBound(min)
+	if err != nil {
+		return scoreRange{}, err
+	}
+	hi, err := parseScoreBound(max)
+	if err != nil {
+		return scoreRange{}, err
+	}
+	return scoreRange{min: lo, max: hi}, nil
+}
+
+func (r scoreRange) contains(score float64) bool {
+	if score < r.min.value || (r.min.exclusive && score == r.min.value) {
+		return false
+	}
+	if score > r.max.value || (r.max.exclusive && score == r.max.value) {
+		return false
+	}
+	return true
+}
+
+// lexBound is one end of a lexicographical interval such as "[a", "(a", "-" or "+".
+type lexBound struct {
+	value     string
+	exclusive bool
+	inf       int
+}
+
+func parseLexBound(val any) (lexBound, error) {
+	s, ok := val.(string)
+	if !ok || s == "" {
+		return lexBound{}, errInvalidLex
+	}
+
+	switch s[0] {
+	case '-':
+		if len(s) != 1 {
+			return lexBound{}, errInvalidLex
+		}
+		return lexBound{inf: -1}, nil
+	case '+':
+		if len(s) != 1 {
+			return lexBound{}, errInvalidLex
+		}
+		return lexBound{inf: 1}, nil
+	case '[':
+		return lexBound{value: s[1:]}, nil
+	case '(':
+		return lexBound{value: s[1:], exclusive: true}, nil
+	default:
+		return lexBound{}, errInvalidLex
+	}
+}
+
+// lexRange is an interval of members built from min and max bounds.
+type lexRange struct {
+	min, max lexBound
+}
+
+func parseLexRange(min, max any) (lexRange, error) {
+	lo, err := parseLexBound(min)
+	if err != nil {
+		return lexRange{}, err
+	}
+	hi, err := parseLexBound(max)
+	if err != nil {
+		return lexRange{}, err
+	}
+	return lexRange{min: lo, max: hi}, nil
+}
+
+func (r lexRange) contains(member string) bool {
+	switch r.min.inf {
+	case 1:
+		return false
+	case 0:
+		if c := strings.Compare(member, r.min.value); c < 0 || (c == 0 && r.min.exclusive) {
+			return false
+		}
+	}
+
+	switch r.max.inf {
+	case -1:
+		return false
+	case 0:
+		if c := strings.Compare(member, r.max.value); c > 0 || (c == 0 && r.max.exclusive) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseIndex converts a rank passed as any into an int64.
+func parseIndex(val any) (int64, error) {
+	switch v := val.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("memory: invalid index type %T", val)
+	}
+}
+
+// limitZMembers applies the LIMIT offset count clause. A negative count returns
+// all remaining members.
+func limitZMembers(members []caches.ZMember, offset, count int64) []caches.ZMember {
+	if offset < 0 || offset >= int64(len(members)) {
+		return members[:0]
+	}
+	members = members[offset:]
+	if count >= 0 && count < int64(len(members)) {
+		members = members[:count]
+	}
+	return members
+}
+
+// rangeZSet selects members of the sorted set stored under key as described by args.
+func rangeZSet(tx *tx, key string, args caches.ZRangeArgs) ([]caches.ZMember, error) {
+	zset, _, err := lookup[zsetValue](tx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	members := sortedZMembers(zset)
+	switch {
+	case args.ByScore:
+		r, err := parseScoreRange(args.Start, args.Stop)
+		if err != nil {
+			return nil, err
+		}
+		selected := members[:0]
+		for _, m := range members {
+			if r.contains(m.Score) {
+				selected = append(selected, m)
+			}
+		}
+		members = selected
+		if args.Rev {
+			members = reverseZMembers(members)
+		}
+	case args.ByLex:
+		r, err := parseLexRange(args.Start, args.Stop)
+		if err != nil {
+			return nil, err
+		}
+		selected := members[:0]
+		for _, m := range members {
+			if r.contains(string(m.Member)) {
+				selected = append(selected, m)
+			}
+		}
+		members = selected
+		if args.Rev {
+			members = reverseZMembers(members)
+		}
+	default:
+		start, err := parseIndex(args.Start)
+		if err != nil {
+			return nil, err
+		}
+		stop, err := parseIndex(args.Stop)
+		if err != nil {
+			return nil, err
+		}
+		if args.Rev {
+			members = reverseZMembers(members)
+		}
+		lo, hi := normalizeRange(start, stop, len(members))
+		members = members[lo:hi]
+	}
+
+	if args.Offset != 0 || args.Count != 0 {
+		members = limitZMembers(members, args.Offset, args.Count)
+	}
+
+	return members, nil
+}
+
+func (p *Provider) zrange(ctx context.Context, key string, args caches.ZRangeArgs) ([]caches.ZMember, error) {
+	key = p.prefix + key
+	return viewAndReturn(ctx, p.db, func(tx *tx) ([]caches.ZMember, error) {
+		return rangeZSet(tx, key, args)
+	})
+}
+
+// popZSet removes and returns up to count members with the lowest, or highest, scores
+// of the sorted set stored under key. Returns caches.Nil when the key does not exist.
+func popZSet(tx *tx, key string, count int64, max bool) ([]caches.ZMember, error) {
+	zset, it, err := lookup[zsetValue](tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if it == nil {
+		return nil, caches.Nil
+	}
+
+	members := sortedZMembers(zset)
+	if max {
+		reverseZMembers(members)
+	}
+	members = members[:min(count, int64(len(members)))]
+	removeZMembers(tx, key, members)
+	return members, nil
+}
+
+// parseZMPopOrder reports whether order, MIN or MAX, pops the highest scores.
+func parseZMPopOrder(order string) (bool, error) {
+	switch strings.ToUpper(order) {
+	case "MIN":
+		return false, nil
+	case "MAX":
+		return true, nil
+	}
+	return false, errZMPopOrder
+}
+
+// blockingZPop pops a member from the first non-empty sorted set among keys, waiting for one if needed.
+func (p *Provider) blockingZPop(ctx context.Context, timeout time.Duration, keys []string, max bool) caches.Result[caches.ZPopResult] {
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := block(ctx, p.waiter, timeout, func() (caches.ZPopResult, error) {
+		return updateAndReturn(ctx, p.db, func(tx *tx) (caches.ZPopResult, error) {
+			for i, key := range prefixed {
+				members, err := popZSet(tx, key, 1, max)
+				if err == caches.Nil {
+					continue
+				}
+				if err != nil {
+					return caches.ZPopResult{}, err
+				}
+				return caches.ZPopResult{Key: keys[i], Member: members[0]}, nil
+			}
+			return caches.ZPopResult{}, caches.Nil
+		})
+	})
+	return newResult(res, err)
+}
+
+// BZPopMax implements caches.SortedSetCommand.
+func (p *Provider) BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ZPopResult] {
+	return p.blockingZPop(ctx, timeout, keys, true)
+}
+
+// BZPopMin implements caches.SortedSetCommand.
+func (p *Provider) BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ZPopResult] {
+	return p.blockingZPop(ctx, timeout, keys, false)
+}
+
+// ZAdd implements caches.SortedSetCommand.
+func (p *Provider) ZAdd(ctx context.Context, key string, members ...caches.ZMember) caches.Result[int64] {
+	return p.ZAddArgs(ctx, key, "", false, members...)
+}
+
+// ZAddArgs implements caches.SortedSetCommand.
+func (p *Provider) ZAddArgs(ctx context.Context, key string, mode string, ch bool, members ...caches.ZMember) caches.Result[int64] {
+	key = p.prefix + key
+	mode = strings.ToUpper(mode)
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, it, err := lookupOrCreate(tx, key, newZSet)
+		if err != nil {
+			return 0, err
+		}
+
+		var count int64
+		for _, m := range members {
+			member := string(m.Member)
+			oldScore, exists := zset[member]
+
+			switch {
+			case mode == "NX" && exists,
+				mode == "XX" && !exists,
+				mode == "GT" && exists && m.Score <= oldScore,
+				mode == "LT" && exists && m.Score >= oldScore:
+				continue
+			}
+
+			zset[member] = m.Score
+			if !exists || (ch && oldScore != m.Score) {
+				count++
+			}
+		}
+
+		tx.changed(key, it, len(zset))
+		return count, nil
+	})
+	return newResult(n, err)
+}
+
+// ZCard implements caches.SortedSetCommand.
+func (p *Provider) ZCard(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		return int64(len(zset)), err
+	})
+	return newResult(n, err)
+}
+
+// ZCount implements caches.SortedSetCommand.
+func (p *Provider) ZCount(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		r, err := parseScoreRange(min, max)
+		if err != nil {
+			return 0, err
+		}
+
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		var count int64
+		for _, score := range zset {
+			if r.contains(score) {
+				count++
+			}
+		}
+		return count, nil
+	})
+	return newResult(n, err)
+}
+
+// ZIncrBy implements caches.SortedSetCommand.
+func (p *Provider) ZIncrBy(ctx context.Context, key string, increment float64, member string) caches.Result[float64] {
+	key = p.prefix + key
+	score, err := updateAndReturn(ctx, p.db, func(tx *tx) (float64, error) {
+		zset, it, err := lookupOrCreate(tx, key, newZSet)
+		if err != nil {
+			return 0, err
+		}
+
+		zset[member] += increment
+		tx.changed(key, it, len(zset))
+		return zset[member], nil
+	})
+	return newResult(score, err)
+}
+
+// aggregateZSets combines the sorted sets listed in store using its weights and aggregate.
+// Missing keys are treated as empty sets.
+func aggregateZSets(tx *tx, keys []string, store caches.ZStore, inter bool) (zsetValue, error) {
+	aggregate := strings.ToUpper(store.Aggregate)
+	result := newZSet()
+	for i, key := range keys {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		weight := 1.0
+		if i < len(store.Weights) {
+			weight = store.Weights[i]
+		}
+
+		if inter && i > 0 {
+			for member := range result {
+				if _, ok := zset[member]; !ok {
+					delete(result, member)
+				}
+			}
+		}
+
+		for member, score := range zset {
+			score *= weight
+			cur, ok := result[member]
+			switch {
+			case i == 0 || (!ok && !inter):
+				result[member] = score
+			case !ok:
+				// Not present in every previous set
+			case aggregate == "MIN":
+				result[member] = math.Min(cur, score)
+			case aggregate == "MAX":
+				result[member] = math.Max(cur, score)
+			default:
+				result[member] = cur + score
+			}
+		}
+	}
+	return result, nil
+}
+
+func (p *Provider) zcombine(ctx context.Context, store caches.ZStore, inter bool) ([]caches.ZMember, error) {
+	keys := prefixKeys(p.prefix, store.Keys)
+	return viewAndReturn(ctx, p.db, func(tx *tx) ([]caches.ZMember, error) {
+		zset, err := aggregateZSets(tx, keys, store, inter)
+		if err != nil {
+			return nil, err
+		}
+		return sortedZMembers(zset), nil
+	})
+}
+
+func (p *Provider) zcombineStore(ctx context.Context, destination string, store caches.ZStore, inter bool) caches.Result[int64] {
+	destination = p.prefix + destination
+	keys := prefixKeys(p.prefix, store.Keys)
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, err := aggregateZSets(tx, keys, store, inter)
+		if err != nil {
+			return 0, err
+		}
+		return storeZSet(tx, destination, zset), nil
+	})
+	return newResult(n, err)
+}
+
+// storeZSet overwrites destination with zset, deleting it when zset is empty.
+// Returns the size of zset.
+func storeZSet(tx *tx, destination string, zset zsetValue) int64 {
+	tx.del(destination)
+	if len(zset) > 0 {
+		tx.put(destination, zset)
+	}
+	return int64(len(zset))
+}
+
+// diffZSets returns the members of the first sorted set among keys that are not in the following ones.
+func diffZSets(tx *tx, keys []string) (zsetValue, error) {
+	if len(keys) == 0 {
+		return nil, errZNoKeys
+	}
+
+	result := newZSet()
+	for i, key := range keys {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		for member, score := range zset {
+			if i == 0 {
+				result[member] = score
+			} else {
+				delete(result, member)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (p *Provider) zdiff(ctx context.Context, keys []string) ([]caches.ZMember, error) {
+	keys = prefixKeys(p.prefix, keys)
+	return viewAndReturn(ctx, p.db, func(tx *tx) ([]caches.ZMember, error) {
+		zset, err := diffZSets(tx, keys)
+		if err != nil {
+			return nil, err
+		}
+		return sortedZMembers(zset), nil
+	})
+}
+
+// ZDiff implements caches.SortedSetCommand.
+func (p *Provider) ZDiff(ctx context.Context, keys ...string) caches.Result[[][]byte] {
+	members, err := p.zdiff(ctx, keys)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZDiffWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZDiffWithScores(ctx context.Context, keys ...string) caches.Result[[]caches.ZMember] {
+	members, err := p.zdiff(ctx, keys)
+	return newResult(members, err)
+}
+
+// ZDiffStore implements caches.SortedSetCommand.
+func (p *Provider) ZDiffStore(ctx context.Context, destination string, keys ...string) caches.Result[int64] {
+	destination = p.prefix + destination
+	keys = prefixKeys(p.prefix, keys)
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, err := diffZSets(tx, keys)
+		if err != nil {
+			return 0, err
+		}
+		return storeZSet(tx, destination, zset), nil
+	})
+	return newResult(n, err)
+}
+
+// ZInter implements caches.SortedSetCommand.
+func (p *Provider) ZInter(ctx context.Context, store caches.ZStore) caches.Result[[][]byte] {
+	members, err := p.zcombine(ctx, store, true)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZInterWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZInterWithScores(ctx context.Context, store caches.ZStore) caches.Result[[]caches.ZMember] {
+	members, err := p.zcombine(ctx, store, true)
+	return newResult(members, err)
+}
+
+// ZInterCard implements caches.SortedSetCommand.
+func (p *Provider) ZInterCard(ctx context.Context, limit int64, keys ...string) caches.Result[int64] {
+	if len(keys) == 0 {
+		return newResult(int64(0), errZNoKeys)
+	}
+	if limit < 0 {
+		return newResult(int64(0), errZLimit)
+	}
+
+	keys = prefixKeys(p.prefix, keys)
+	n, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, err := aggregateZSets(tx, keys, caches.ZStore{}, true)
+		if err != nil {
+			return 0, err
+		}
+		if limit > 0 {
+			return min(int64(len(zset)), limit), nil
+		}
+		return int64(len(zset)), nil
+	})
+	return newResult(n, err)
+}
+
+// ZInterStore implements caches.SortedSetCommand.
+func (p *Provider) ZInterStore(ctx context.Context, destination string, store caches.ZStore) caches.Result[int64] {
+	return p.zcombineStore(ctx, destination, store, true)
+}
+
+// ZLexCount implements caches.SortedSetCommand.
+func (p *Provider) ZLexCount(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByLex: true})
+	return newResult(int64(len(members)), err)
+}
+
+// ZMPop implements caches.SortedSetCommand.
+func (p *Provider) ZMPop(ctx context.Context, order string, count int64, keys ...string) caches.Result[caches.ZMPopResult] {
+	max, err := parseZMPopOrder(order)
+	if err != nil {
+		return newResult(caches.ZMPopResult{}, err)
+	}
+	if count <= 0 {
+		return newResult(caches.ZMPopResult{}, errInvalidCount)
+	}
+
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := updateAndReturn(ctx, p.db, func(tx *tx) (caches.ZMPopResult, error) {
+		for i, key := range prefixed {
+			members, err := popZSet(tx, key, count, max)
+			if err == caches.Nil {
+				continue
+			}
+			if err != nil {
+				return caches.ZMPopResult{}, err
+			}
+			return caches.ZMPopResult{Key: keys[i], Members: members}, nil
+		}
+		return caches.ZMPopResult{}, caches.Nil
+	})
+	return newResult(res, err)
+}
+
+func (p *Provider) zpop(ctx context.Context, key string, count int64, max bool) caches.Result[[]caches.ZMember] {
+	if count < 0 {
+		return newResult([]caches.ZMember(nil), errInvalidCount)
+	}
+
+	key = p.prefix + key
+	members, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]caches.ZMember, error) {
+		members, err := popZSet(tx, key, count, max)
+		if err == caches.Nil {
+			return []caches.ZMember{}, nil
+		}
+		return members, err
+	})
+	return newResult(members, err)
+}
+
+// ZMScore implements caches.SortedSetCommand.
+func (p *Provider) ZMScore(ctx context.Context, key string, members ...string) caches.Result[[]*float64] {
+	key = p.prefix + key
+	scores, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]*float64, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]*float64, len(members))
+		for i, member := range members {
+			if score, ok := zset[member]; ok {
+				result[i] = &score
+			}
+		}
+		return result, nil
+	})
+	return newResult(scores, err)
+}
+
+// ZPopMax implements caches.SortedSetCommand.
+func (p *Provider) ZPopMax(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	return p.zpop(ctx, key, count, true)
+}
+
+// ZPopMin implements caches.SortedSetCommand.
+func (p *Provider) ZPopMin(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	return p.zpop(ctx, key, count, false)
+}
+
+// randomZMembers returns count members picked at random, distinct when count is positive
+// and possibly repeated when it is negative.
+func randomZMembers(zset zsetValue, count int64) []caches.ZMember {
+	members := sortedZMembers(zset)
+	if len(members) == 0 {
+		return []caches.ZMember{}
+	}
+
+	if count >= 0 {
+		rand.Shuffle(len(members), func(i, j int) {
+			members[i], members[j] = members[j], members[i]
+		})
+		return members[:min(count, int64(len(members)))]
+	}
+
+	result := make([]caches.ZMember, -count)
+	for i := range result {
+		result[i] = members[rand.Intn(len(members))]
+	}
+	return result
+}
+
+func (p *Provider) zrandMember(ctx context.Context, key string, count int64) ([]caches.ZMember, error) {
+	key = p.prefix + key
+	return viewAndReturn(ctx, p.db, func(tx *tx) ([]caches.ZMember, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		return randomZMembers(zset, count), nil
+	})
+}
+
+// ZRandMember implements caches.SortedSetCommand.
+func (p *Provider) ZRandMember(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
+	members, err := p.zrandMember(ctx, key, count)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRandMemberWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRandMemberWithScores(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	members, err := p.zrandMember(ctx, key, count)
+	return newResult(members, err)
+}
+
+// ZRange implements caches.SortedSetCommand.
+func (p *Provider) ZRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: start, Stop: stop})
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRangeWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRangeWithScores(ctx context.Context, key string, start, stop int64) caches.Result[[]caches.ZMember] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: start, Stop: stop})
+	return newResult(members, err)
+}
+
+// ZRangeArgs implements caches.SortedSetCommand.
+func (p *Provider) ZRangeArgs(ctx context.Context, key string, args caches.ZRangeArgs) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, args)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRangeArgsWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRangeArgsWithScores(ctx context.Context, key string, args caches.ZRangeArgs) caches.Result[[]caches.ZMember] {
+	members, err := p.zrange(ctx, key, args)
+	return newResult(members, err)
+}
+
+// ZRangeStore implements caches.SortedSetCommand.
+func (p *Provider) ZRangeStore(ctx context.Context, destination, key string, args caches.ZRangeArgs) caches.Result[int64] {
+	destination = p.prefix + destination
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		selected, err := rangeZSet(tx, key, args)
+		if err != nil {
+			return 0, err
+		}
+
+		zset := newZSet()
+		for _, m := range selected {
+			zset[string(m.Member)] = m.Score
+		}
+		return storeZSet(tx, destination, zset), nil
+	})
+	return newResult(n, err)
+}
+
+// ZRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRangeByLex(ctx context.Context, key string, min, max string) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByLex: true})
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRangeByScore implements caches.SortedSetCommand.
+func (p *Provider) ZRangeByScore(ctx context.Context, key string, min, max string) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByScore: true})
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRangeByScoreWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRangeByScoreWithScores(ctx context.Context, key string, min, max string) caches.Result[[]caches.ZMember] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByScore: true})
+	return newResult(members, err)
+}
+
+func (p *Provider) zrank(ctx context.Context, key string, member string, rev bool) (caches.ZRankScore, error) {
+	key = p.prefix + key
+	return viewAndReturn(ctx, p.db, func(tx *tx) (caches.ZRankScore, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return caches.ZRankScore{}, err
+		}
+
+		score, ok := zset[member]
+		if !ok {
+			return caches.ZRankScore{}, caches.Nil
+		}
+
+		members := sortedZMembers(zset)
+		if rev {
+			members = reverseZMembers(members)
+		}
+		for i, m := range members {
+			if string(m.Member) == member {
+				return caches.ZRankScore{Rank: int64(i), Score: score}, nil
+			}
+		}
+		return caches.ZRankScore{}, caches.Nil
+	})
+}
+
+// ZRank implements caches.SortedSetCommand.
+func (p *Provider) ZRank(ctx context.Context, key string, member string) caches.Result[int64] {
+	rs, err := p.zrank(ctx, key, member, false)
+	return newResult(rs.Rank, err)
+}
+
+// ZRankWithScore implements caches.SortedSetCommand.
+func (p *Provider) ZRankWithScore(ctx context.Context, key string, member string) caches.Result[caches.ZRankScore] {
+	rs, err := p.zrank(ctx, key, member, false)
+	return newResult(rs, err)
+}
+
+// ZRem implements caches.SortedSetCommand.
+func (p *Provider) ZRem(ctx context.Context, key string, members ...any) caches.Result[int64] {
+	key = p.prefix + key
+	data, err := toBytesSlice(members)
+	if err != nil {
+		return newResult(int64(0), err)
+	}
+
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		zset, it, err := lookup[zsetValue](tx, key)
+		if err != nil || it == nil {
+			return 0, err
+		}
+
+		var removed int64
+		for _, member := range data {
+			if _, ok := zset[string(member)]; ok {
+				delete(zset, string(member))
+				removed++
+			}
+		}
+
+		if removed > 0 {
+			tx.changed(key, it, len(zset))
+		}
+		return removed, nil
+	})
+	return newResult(n, err)
+}
+
+// removeZMembers deletes the selected members from the sorted set stored under key.
+func removeZMembers(tx *tx, key string, selected []caches.ZMember) int64 {
+	zset, it, _ := lookup[zsetValue](tx, key)
+	if it == nil || len(selected) == 0 {
+		return 0
+	}
+
+	for _, m := range selected {
+		delete(zset, string(m.Member))
+	}
+	tx.changed(key, it, len(zset))
+	return int64(len(selected))
+}
+
+// ZRemRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRemRangeByLex(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		selected, err := rangeZSet(tx, key, caches.ZRangeArgs{Start: min, Stop: max, ByLex: true})
+		if err != nil {
+			return 0, err
+		}
+		return removeZMembers(tx, key, selected), nil
+	})
+	return newResult(n, err)
+}
+
+// ZRemRangeByRank implements caches.SortedSetCommand.
+func (p *Provider) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		selected, err := rangeZSet(tx, key, caches.ZRangeArgs{Start: start, Stop: stop})
+		if err != nil {
+			return 0, err
+		}
+		return removeZMembers(tx, key, selected), nil
+	})
+	return newResult(n, err)
+}
+
+// ZRemRangeByScore implements caches.SortedSetCommand.
+func (p *Provider) ZRemRangeByScore(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		selected, err := rangeZSet(tx, key, caches.ZRangeArgs{Start: min, Stop: max, ByScore: true})
+		if err != nil {
+			return 0, err
+		}
+		return removeZMembers(tx, key, selected), nil
+	})
+	return newResult(n, err)
+}
+
+// ZRevRange implements caches.SortedSetCommand.
+func (p *Provider) ZRevRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: start, Stop: stop, Rev: true})
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRevRangeWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) caches.Result[[]caches.ZMember] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: start, Stop: stop, Rev: true})
+	return newResult(members, err)
+}
+
+// ZRevRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRevRangeByLex(ctx context.Context, key string, max, min string) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByLex: true, Rev: true})
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRevRangeByScore implements caches.SortedSetCommand.
+func (p *Provider) ZRevRangeByScore(ctx context.Context, key string, max, min string) caches.Result[[][]byte] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByScore: true, Rev: true})
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRevRangeByScoreWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRevRangeByScoreWithScores(ctx context.Context, key string, max, min string) caches.Result[[]caches.ZMember] {
+	members, err := p.zrange(ctx, key, caches.ZRangeArgs{Start: min, Stop: max, ByScore: true, Rev: true})
+	return newResult(members, err)
+}
+
+// ZRevRank implements caches.SortedSetCommand.
+func (p *Provider) ZRevRank(ctx context.Context, key string, member string) caches.Result[int64] {
+	rs, err := p.zrank(ctx, key, member, true)
+	return newResult(rs.Rank, err)
+}
+
+// ZRevRankWithScore implements caches.SortedSetCommand.
+func (p *Provider) ZRevRankWithScore(ctx context.Context, key string, member string) caches.Result[caches.ZRankScore] {
+	rs, err := p.zrank(ctx, key, member, true)
+	return newResult(rs, err)
+}
+
+// ZScan implements caches.SortedSetCommand.
+func (p *Provider) ZScan(ctx context.Context, key string, cursor uint64, match string, count int64) caches.Result[caches.ZScanResult] {
+	key = p.prefix + key
+	result, err := viewAndReturn(ctx, p.db, func(tx *tx) (caches.ZScanResult, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return caches.ZScanResult{}, err
+		}
+
+		matched := make([]caches.ZMember, 0, len(zset))
+		for _, m := range sortedZMembers(zset) {
+			if matchPattern(match, string(m.Member)) {
+				matched = append(matched, m)
+			}
+		}
+
+		page, next := scanPage(matched, cursor, count)
+		return caches.ZScanResult{
+			Cursor:  next,
+			Members: page,
+		}, nil
+	})
+	return newResult(result, err)
+}
+
+// ZScore implements caches.SortedSetCommand.
+func (p *Provider) ZScore(ctx context.Context, key string, member string) caches.Result[float64] {
+	key = p.prefix + key
+	score, err := viewAndReturn(ctx, p.db, func(tx *tx) (float64, error) {
+		zset, _, err := lookup[zsetValue](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		score, ok := zset[member]
+		if !ok {
+			return 0, caches.Nil
+		}
+		return score, nil
+	})
+	return newResult(score, err)
+}
+
+// ZUnion implements caches.SortedSetCommand.
+func (p *Provider) ZUnion(ctx context.Context, store caches.ZStore) caches.Result[[][]byte] {
+	members, err := p.zcombine(ctx, store, false)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZUnionWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZUnionWithScores(ctx context.Context, store caches.ZStore) caches.Result[[]caches.ZMember] {
+	members, err := p.zcombine(ctx, store, false)
+	return newResult(members, err)
+}
+
+// ZUnionStore implements caches.SortedSetCommand.
+func (p *Provider) ZUnionStore(ctx context.Context, destination string, store caches.ZStore) caches.Result[int64] {
+	return p.zcombineStore(ctx, destination, store, false)
+}
@@ -0,0 +1,436 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/str"
+)
+
+var (
+	errNotInteger = errors.New("memory: value is not an integer or out of range")
+	errNotFloat   = errors.New("memory: value is not a valid float")
+)
+
+var _ caches.StringCommand = (*Provider)(nil)
+
+// setString stores a string value under key, keeping the expiration when keepTTL is set.
+func setString(tx *tx, key string, value []byte, keepTTL bool) *item {
+	var expireAt time.Time
+	if keepTTL {
+		if it := tx.get(key); it != nil {
+			expireAt = it.expireAt
+		}
+	}
+
+	it := tx.put(key, value)
+	it.expireAt = expireAt
+	return it
+}
+
+// getString returns the string stored under key or caches.Nil.
+func getString(tx *tx, key string) ([]byte, *item, error) {
+	val, it, err := lookup[[]byte](tx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if it == nil {
+		return nil, nil, caches.Nil
+	}
+	return val, it, nil
+}
+
+func (p *Provider) incr(ctx context.Context, key string, value int64) caches.Result[int64] {
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, it, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		var n int64
+		if it != nil {
+			n, err = strconv.ParseInt(string(cur), 10, 64)
+			if err != nil {
+				return 0, errNotInteger
+			}
+		}
+
+		n += value
+		setString(tx, key, strconv.AppendInt(nil, n, 10), true)
+		return n, nil
+	})
+
+	return newResult(val, err)
+}
+
+// Append implements caches.StringCommand.
+func (p *Provider) Append(ctx context.Context, key string, value string) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		b, err := str.Append(cur, value)
+		if err != nil {
+			return 0, err
+		}
+		setString(tx, key, b, true)
+		return int64(len(b)), nil
+	})
+
+	return newResult(val, err)
+}
+
+// Decr implements caches.StringCommand.
+func (p *Provider) Decr(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	return p.incr(ctx, key, -1)
+}
+
+// DecrBy implements caches.StringCommand.
+func (p *Provider) DecrBy(ctx context.Context, key string, value int64) caches.Result[int64] {
+	key = p.prefix + key
+	return p.incr(ctx, key, -value)
+}
+
+// Get implements caches.StringCommand.
+func (p *Provider) Get(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		val, _, err := getString(tx, key)
+		return val, err
+	})
+
+	return newResult(val, err)
+}
+
+// GetDel implements caches.StringCommand.
+func (p *Provider) GetDel(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		val, _, err := getString(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		tx.del(key)
+		return val, nil
+	})
+
+	return newResult(val, err)
+}
+
+// GetEx implements caches.StringCommand.
+func (p *Provider) GetEx(ctx context.Context, key string, args caches.GetExArgs) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		val, it, err := getString(tx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case args.TTL > 0:
+			it.expireAt = tx.now.Add(args.TTL)
+		case !args.ExpireAt.IsZero():
+			if !args.ExpireAt.After(tx.now) {
+				// A time in the past deletes the key, as in Redis
+				tx.del(key)
+				return val, nil
+			}
+			it.expireAt = args.ExpireAt
+		case args.Persist:
+			it.expireAt = time.Time{}
+		default:
+			return val, nil
+		}
+
+		tx.touch(it)
+		return val, nil
+	})
+
+	return newResult(val, err)
+}
+
+// GetRange implements caches.StringCommand.
+func (p *Provider) GetRange(ctx context.Context, key string, start, end int64) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return nil, err
+		}
+		return str.GetRange(cur, start, end), nil
+	})
+
+	return newResult(val, err)
+}
+
+// Incr implements caches.StringCommand.
+func (p *Provider) Incr(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	return p.incr(ctx, key, 1)
+}
+
+// IncrBy implements caches.StringCommand.
+func (p *Provider) IncrBy(ctx context.Context, key string, value int64) caches.Result[int64] {
+	key = p.prefix + key
+	return p.incr(ctx, key, value)
+}
+
+// IncrByFloat implements caches.StringCommand.
+func (p *Provider) IncrByFloat(ctx context.Context, key string, value float64) caches.Result[float64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (float64, error) {
+		cur, it, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		var n float64
+		if it != nil {
+			n, err = strconv.ParseFloat(string(cur), 64)
+			if err != nil {
+				return 0, errNotFloat
+			}
+		}
+
+		n += value
+		setString(tx, key, strconv.AppendFloat(nil, n, 'f', -1, 64), true)
+		return n, nil
+	})
+
+	return newResult(val, err)
+}
+
+// LCS implements caches.StringCommand.
+func (p *Provider) LCS(ctx context.Context, q *caches.LCSQuery) caches.Result[*caches.LCSMatch] {
+	key1 := p.prefix + q.Key1
+	key2 := p.prefix + q.Key2
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (*caches.LCSMatch, error) {
+		a, _, err := lookup[[]byte](tx, key1)
+		if err != nil {
+			return nil, err
+		}
+		b, _, err := lookup[[]byte](tx, key2)
+		if err != nil {
+			return nil, err
+		}
+		return str.LCS(a, b, q)
+	})
+
+	return newResult(val, err)
+}
+
+// Set implements caches.StringCommand.
+func (p *Provider) Set(ctx context.Context, key string, value any, expiration time.Duration) caches.StatusResult {
+	key = p.prefix + key
+	val, _, err := p.setArgs(ctx, key, value, &caches.SetArgs{
+		TTL:     expiration,
+		KeepTTL: expiration == caches.KeepTTL,
+	})
+	return newStatusResult(val, err)
+}
+
+// SetArgs implements caches.StringCommand.
+func (p *Provider) SetArgs(ctx context.Context, key string, value any, args caches.SetArgs) caches.StatusResult {
+	key = p.prefix + key
+	val, _, err := p.setArgs(ctx, key, value, &args)
+	return newStatusResult(val, err)
+}
+
+// SetNX implements caches.StringCommand.
+func (p *Provider) SetNX(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	_, ok, err := p.setArgs(ctx, key, value, &caches.SetArgs{
+		Mode:    "NX",
+		TTL:     expiration,
+		KeepTTL: expiration == caches.KeepTTL,
+	})
+	// SetNX reports false instead of an error when the key exists
+	if err == caches.Nil {
+		return newResult(false, nil)
+	}
+	return newResult(ok, err)
+}
+
+// SetRange implements caches.StringCommand.
+func (p *Provider) SetRange(ctx context.Context, key string, offset int64, value string) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		b, err := str.SetRange(cur, offset, value)
+		if err != nil {
+			return 0, err
+		}
+		// An empty value neither changes nor creates the key
+		if len(value) == 0 {
+			return int64(len(cur)), nil
+		}
+		setString(tx, key, b, true)
+		return int64(len(b)), nil
+	})
+
+	return newResult(val, err)
+}
+
+// SetXX implements caches.StringCommand.
+func (p *Provider) SetXX(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	_, ok, err := p.setArgs(ctx, key, value, &caches.SetArgs{
+		Mode:    "XX",
+		TTL:     expiration,
+		KeepTTL: expiration == caches.KeepTTL,
+	})
+	// SetXX reports false instead of an error when the key is missing
+	if err == caches.Nil {
+		return newResult(false, nil)
+	}
+	return newResult(ok, err)
+}
+
+// StrLen implements caches.StringCommand.
+func (p *Provider) StrLen(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (int64, error) {
+		cur, _, err := lookup[[]byte](tx, key)
+		return int64(len(cur)), err
+	})
+
+	return newResult(val, err)
+}
+
+func (p *Provider) setArgs(ctx context.Context, key string, value any, args *caches.SetArgs) ([]byte, bool, error) {
+	data, err := toBytes(value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	type Res struct {
+		Prev    []byte
+		Updated bool
+	}
+
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (Res, error) {
+		var prev []byte
+		old := tx.get(key)
+		if old != nil && args.Get {
+			b, ok := old.value.([]byte)
+			if !ok {
+				return Res{}, errKeyType
+			}
+			prev = b
+		}
+
+		mode := strings.ToUpper(args.Mode)
+		if (mode == "NX" && old != nil) || (mode == "XX" && old == nil) {
+			return Res{Prev: prev}, nil
+		}
+
+		it := setString(tx, key, data, args.KeepTTL)
+		if !args.KeepTTL {
+			if !args.ExpireAt.IsZero() {
+				it.expireAt = args.ExpireAt
+			}
+			if args.TTL > 0 {
+				it.expireAt = tx.now.Add(args.TTL)
+			}
+		}
+
+		return Res{Prev: prev, Updated: true}, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if args.Get {
+		if val.Prev == nil {
+			return nil, false, caches.Nil
+		}
+		return val.Prev, true, nil
+	}
+
+	if !val.Updated {
+		return nil, false, caches.Nil
+	}
+
+	return []byte("OK"), true, nil
+}
+
+// MGet implements caches.StringCommand.
+func (p *Provider) MGet(ctx context.Context, keys ...string) caches.Result[map[string][]byte] {
+	keys = prefixKeys(p.prefix, keys)
+	val, err := viewAndReturn(ctx, p.db, func(tx *tx) (map[string][]byte, error) {
+		result := make(map[string][]byte, len(keys))
+		for _, key := range keys {
+			// Missing keys and keys of another type are left out
+			if cur, it, _ := lookup[[]byte](tx, key); it != nil {
+				result[p.trimPrefix(key)] = cur
+			}
+		}
+		return result, nil
+	})
+
+	return newResult(val, err)
+}
+
+// toBytesMap converts the values of a key-value map and applies the prefix to its keys.
+func toBytesMap(prefix string, values map[string]any) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(values))
+	for key, value := range values {
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		result[prefix+key] = b
+	}
+	return result, nil
+}
+
+// MSet implements caches.StringCommand.
+func (p *Provider) MSet(ctx context.Context, values map[string]any) caches.StatusResult {
+	data, err := toBytesMap(p.prefix, values)
+	if err != nil {
+		return newStatusResult(nil, err)
+	}
+
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) ([]byte, error) {
+		for key, value := range data {
+			tx.put(key, value)
+		}
+		return []byte("OK"), nil
+	})
+	return newStatusResult(val, err)
+}
+
+// MSetNX implements caches.StringCommand.
+func (p *Provider) MSetNX(ctx context.Context, values map[string]any) caches.Result[bool] {
+	data, err := toBytesMap(p.prefix, values)
+	if err != nil {
+		return newResult(false, err)
+	}
+
+	val, err := updateAndReturn(ctx, p.db, func(tx *tx) (bool, error) {
+		// Nothing is written when any of the keys already exists
+		for key := range data {
+			if tx.get(key) != nil {
+				return false, nil
+			}
+		}
+
+		for key, value := range data {
+			tx.put(key, value)
+		}
+		return true, nil
+	})
+	return newResult(val, err)
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.TxCommand = (*Provider)(nil)
+
+// watchTx implements caches.Tx by comparing the versions of the watched keys
+// with the ones observed when Watch was called.
+type watchTx struct {
+	*Provider
+	keys     []string
+	versions []uint64
+}
+
+var _ caches.Tx = (*watchTx)(nil)
+
+// watchKeys returns the current versions of keys.
+// Missing or expired keys are reported as version 0, and since versions are
+// never reused a deleted and recreated key is detected as changed.
+func watchKeys(tx *tx, keys []string) []uint64 {
+	versions := make([]uint64, len(keys))
+	for i, key := range keys {
+		if it := tx.get(key); it != nil {
+			versions[i] = it.version
+		}
+	}
+	return versions
+}
+
+// TxPipeline implements caches.TxCommand.
+func (p *Provider) TxPipeline() caches.TxPipeliner {
+	return p.newPipeline(nil)
+}
+
+// TxPipelined implements caches.TxCommand.
+func (p *Provider) TxPipelined(ctx context.Context, fn func(pipe caches.TxPipeliner) error) error {
+	return txPipelined(ctx, p.TxPipeline(), fn)
+}
+
+// Watch implements caches.TxCommand.
+func (p *Provider) Watch(ctx context.Context, fn func(tx caches.Tx) error, keys ...string) error {
+	keys = prefixKeys(p.prefix, keys)
+	versions, err := viewAndReturn(ctx, p.db, func(tx *tx) ([]uint64, error) {
+		return watchKeys(tx, keys), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return fn(&watchTx{
+		Provider: p,
+		keys:     keys,
+		versions: versions,
+	})
+}
+
+// TxPipeline implements caches.Tx.
+func (t *watchTx) TxPipeline() caches.TxPipeliner {
+	return t.newPipeline(func(tx *tx) error {
+		if !slices.Equal(t.versions, watchKeys(tx, t.keys)) {
+			return caches.ErrTxFailed
+		}
+		return nil
+	})
+}
+
+// TxPipelined implements caches.Tx.
+func (t *watchTx) TxPipelined(ctx context.Context, fn func(pipe caches.TxPipeliner) error) error {
+	return txPipelined(ctx, t.TxPipeline(), fn)
+}
+
+func txPipelined(ctx context.Context, pipe caches.TxPipeliner, fn func(pipe caches.TxPipeliner) error) error {
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	return pipe.Exec(ctx)
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/glob"
+)
+
+// newResult creates a new BaseResult.
+func newResult[T any](result T, err error) caches.Result[T] {
+	return caches.NewResult(result, err)
+}
+
+// newStatusResult creates a new statusResult.
+func newStatusResult(val []byte, err error) caches.StatusResult {
+	return caches.NewStatusResult(val, err)
+}
+
+func prefixKeys(prefix string, keys []string) []string {
+	if prefix == "" {
+		return keys
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = prefix + key
+	}
+
+	return prefixed
+}
+
+// trimPrefix removes the provider prefix from a stored key.
+func (p *Provider) trimPrefix(key string) string {
+	return key[len(p.prefix):]
+}
+
+func viewAndReturn[T any](ctx context.Context, s store, cb func(tx *tx) (T, error)) (res T, err error) {
+	err = s.view(ctx, func(tx *tx) (e error) {
+		res, e = cb(tx)
+		return
+	})
+	return
+}
+
+func updateAndReturn[T any](ctx context.Context, s store, cb func(tx *tx) (T, error)) (res T, err error) {
+	err = s.update(ctx, func(tx *tx) (e error) {
+		res, e = cb(tx)
+		return
+	})
+	return
+}
+
+func formatMs(dur time.Duration) int64 {
+	if dur > 0 && dur < time.Millisecond {
+		return 1
+	}
+	return int64(dur / time.Millisecond)
+}
+
+func formatSec(dur time.Duration) int64 {
+	if dur > 0 && dur < time.Second {
+		return 1
+	}
+	return int64(dur / time.Second)
+}
+
+// toBytes converts a command argument to its stored representation,
+// following the same rules as the go-redis argument writer.
+func toBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return []byte{}, nil
+	case string:
+		return []byte(v), nil
+	case *string:
+		if v == nil {
+			return []byte{}, nil
+		}
+		return []byte(*v), nil
+	case []byte:
+		return append([]byte(nil), v...), nil
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(nil, v, 10), nil
+	case uint:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(nil, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(nil, v, 10), nil
+	case float32:
+		return strconv.AppendFloat(nil, float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	case time.Time:
+		return v.AppendFormat(nil, time.RFC3339Nano), nil
+	case time.Duration:
+		return strconv.AppendInt(nil, v.Nanoseconds(), 10), nil
+	case encoding.BinaryMarshaler:
+		return v.MarshalBinary()
+	case net.IP:
+		return append([]byte(nil), v...), nil
+	default:
+		return nil, fmt.Errorf("memory: can't marshal %T (implement encoding.BinaryMarshaler)", v)
+	}
+}
+
+// toBytesSlice converts every argument with toBytes.
+func toBytesSlice(values []any) ([][]byte, error) {
+	result := make([][]byte, len(values))
+	for i, v := range values {
+		b, err := toBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = b
+	}
+	return result, nil
+}
+
+// matchPattern reports whether s matches the Redis glob-style pattern.
+// An empty pattern matches everything.
+func matchPattern(pattern, s string) bool {
+	return pattern == "" || glob.Match(pattern, s)
+}
+
+// scanPage returns the page of items starting at cursor along with the
+// cursor of the next page, which is 0 once the iteration is complete.
+func scanPage[T any](items []T, cursor uint64, count int64) ([]T, uint64) {
+	if count <= 0 {
+		count = 10
+	}
+
+	if cursor >= uint64(len(items)) {
+		return items[:0], 0
+	}
+
+	end := cursor + uint64(count)
+	if end >= uint64(len(items)) {
+		return items[cursor:], 0
+	}
+
+	return items[cursor:end], end
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+// waiter wakes up blocked commands when an update is applied to the database.
+type waiter struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newWaiter() *waiter {
+	return &waiter{}
+}
+
+// wait returns a channel that is closed by the next notify.
+func (w *waiter) wait() <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ch == nil {
+		w.ch = make(chan struct{})
+	}
+	return w.ch
+}
+
+// notify wakes up all pending waits.
+func (w *waiter) notify() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ch != nil {
+		close(w.ch)
+		w.ch = nil
+	}
+}
+
+// block calls pop until it returns something other than caches.Nil,
+// the timeout expires or ctx is done. A zero timeout blocks indefinitely.
+// Without a waiter, as within a transaction, pop is only called once.
+func block[T any](ctx context.Context, w *waiter, timeout time.Duration, pop func() (T, error)) (T, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		// Grab the channel before popping so that no update is missed in between
+		var wake <-chan struct{}
+		if w != nil {
+			wake = w.wait()
+		}
+
+		val, err := pop()
+		if err != caches.Nil || w == nil {
+			return val, err
+		}
+
+		select {
+		case <-wake:
+		case <-expired:
+			return val, caches.Nil
+		case <-ctx.Done():
+			return val, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.BitmapCommand = (*Provider)(nil)
+
+// processor is implemented by the clients, pipelines and transactions behind Provider.db,
+// to send commands without a dedicated method.
+type processor interface {
+	Process(ctx context.Context, cmd rds.Cmder) error
+}
+
+// SetBit implements caches.BitmapCommand.
+func (p *Provider) SetBit(ctx context.Context, key string, offset int64, value int) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.SetBit(ctx, key, offset, value)
+	return newResultFunc(p, res.Result)
+}
+
+// GetBit implements caches.BitmapCommand.
+func (p *Provider) GetBit(ctx context.Context, key string, offset int64) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.GetBit(ctx, key, offset)
+	return newResultFunc(p, res.Result)
+}
+
+// BitCount implements caches.BitmapCommand.
+func (p *Provider) BitCount(ctx context.Context, key string, bitCount *caches.BitCount) caches.Result[int64] {
+	key = p.prefix + key
+	var rdsBitCount *rds.BitCount
+	if bitCount != nil {
+		rdsBitCount = &rds.BitCount{
+			Start: bitCount.Start,
+			End:   bitCount.End,
+			Unit:  bitCount.Unit,
+		}
+	}
+	res := p.db.BitCount(ctx, key, rdsBitCount)
+	return newResultFunc(p, res.Result)
+}
+
+// BitPos implements caches.BitmapCommand.
+func (p *Provider) BitPos(ctx context.Context, key string, bit int64, pos ...int64) caches.Result[int64] {
+	if len(pos) > 2 {
+		return caches.NewResult(int64(0), errors.New("redis: too many arguments for BitPos"))
+	}
+
+	key = p.prefix + key
+	res := p.db.BitPos(ctx, key, bit, pos...)
+	return newResultFunc(p, res.Result)
+}
+
+// BitPosSpan implements caches.BitmapCommand.
+func (p *Provider) BitPosSpan(ctx context.Context, key string, bit int8, start, end int64, span string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.BitPosSpan(ctx, key, bit, start, end, span)
+	return newResultFunc(p, res.Result)
+}
+
+// BitOpAnd implements caches.BitmapCommand.
+func (p *Provider) BitOpAnd(ctx context.Context, destKey string, keys ...string) caches.Result[int64] {
+	destKey = p.prefix + destKey
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.BitOpAnd(ctx, destKey, keys...)
+	return newResultFunc(p, res.Result)
+}
+
+// BitOpOr implements caches.BitmapCommand.
+func (p *Provider) BitOpOr(ctx context.Context, destKey string, keys ...string) caches.Result[int64] {
+	destKey = p.prefix + destKey
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.BitOpOr(ctx, destKey, keys...)
+	return newResultFunc(p, res.Result)
+}
+
+// BitOpXor implements caches.BitmapCommand.
+func (p *Provider) BitOpXor(ctx context.Context, destKey string, keys ...string) caches.Result[int64] {
+	destKey = p.prefix + destKey
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.BitOpXor(ctx, destKey, keys...)
+	return newResultFunc(p, res.Result)
+}
+
+// BitOpNot implements caches.BitmapCommand.
+func (p *Provider) BitOpNot(ctx context.Context, destKey string, key string) caches.Result[int64] {
+	destKey = p.prefix + destKey
+	key = p.prefix + key
+	res := p.db.BitOpNot(ctx, destKey, key)
+	return newResultFunc(p, res.Result)
+}
+
+// BitField implements caches.BitmapCommand.
+// The reply is parsed from a generic command since IntSliceCmd rejects the nil results of OVERFLOW FAIL.
+func (p *Provider) BitField(ctx context.Context, key string, args ...any) caches.Result[[]*int64] {
+	key = p.prefix + key
+	res := rds.NewCmd(ctx, append([]any{"bitfield", key}, args...)...)
+	_ = p.db.(processor).Process(ctx, res)
+	return newResultFunc(p, func() ([]*int64, error) {
+		vals, err := res.Slice()
+		if err != nil {
+			return nil, err
+		}
+
+		fields := make([]*int64, len(vals))
+		for i, v := range vals {
+			if v == nil {
+				continue
+			}
+			n, ok := v.(int64)
+			if !ok {
+				return nil, fmt.Errorf("redis: unexpected BITFIELD reply %T", v)
+			}
+			fields[i] = &n
+		}
+		return fields, nil
+	})
+}
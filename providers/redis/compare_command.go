@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.CompareCommand = (*Provider)(nil)
+
+var compareAndDeleteScript = rds.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+var compareAndExpireScript = rds.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// acquireWithTokenScript increments the token before setting the key,
+// so that a token which is not an integer fails the script without setting the key.
+var acquireWithTokenScript = rds.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return false
+end
+local token = redis.call("INCR", KEYS[2])
+if tonumber(ARGV[2]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return token`)
+
+// boolScriptResult converts the integer reply of a script into a caches.Result[bool].
+func (p *Provider) boolScriptResult(res *rds.Cmd) caches.Result[bool] {
+	return newResultFunc(p, func() (bool, error) {
+		n, err := res.Int64()
+		return n == 1, err
+	})
+}
+
+// CompareAndDelete implements caches.CompareCommand.
+func (p *Provider) CompareAndDelete(ctx context.Context, key string, value any) caches.Result[bool] {
+	key = p.prefix + key
+	res := p.runScript(ctx, compareAndDeleteScript, []string{key}, value)
+	return p.boolScriptResult(res)
+}
+
+// CompareAndExpire implements caches.CompareCommand.
+func (p *Provider) CompareAndExpire(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	res := p.runScript(ctx, compareAndExpireScript, []string{key}, value, formatMs(expiration))
+	return p.boolScriptResult(res)
+}
+
+// AcquireWithToken implements caches.CompareCommand.
+// On Redis Cluster, key and tokenKey must hash to the same slot, e.g. by sharing a hash tag.
+func (p *Provider) AcquireWithToken(ctx context.Context, key string, value any, expiration time.Duration, tokenKey string) caches.Result[int64] {
+	key, tokenKey = p.prefix+key, p.prefix+tokenKey
+	res := p.runScript(ctx, acquireWithTokenScript, []string{key, tokenKey}, value, formatMs(expiration))
+	return newResultFunc(p, res.Int64)
+}
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.GeoCommand = (*Provider)(nil)
+
+// toGeoSearchQuery returns a copy of q, the client setting the default units of the queries it is given.
+func toGeoSearchQuery(q *caches.GeoSearchQuery) rds.GeoSearchQuery {
+	return rds.GeoSearchQuery{
+		Member:     q.Member,
+		Longitude:  q.Longitude,
+		Latitude:   q.Latitude,
+		Radius:     q.Radius,
+		RadiusUnit: q.RadiusUnit,
+		BoxWidth:   q.BoxWidth,
+		BoxHeight:  q.BoxHeight,
+		BoxUnit:    q.BoxUnit,
+		Sort:       q.Sort,
+		Count:      q.Count,
+		CountAny:   q.CountAny,
+	}
+}
+
+// GeoAdd implements caches.GeoCommand.
+func (p *Provider) GeoAdd(ctx context.Context, key string, geoLocation ...*caches.GeoLocation) caches.Result[int64] {
+	key = p.prefix + key
+	locs := make([]*rds.GeoLocation, len(geoLocation))
+	for i, loc := range geoLocation {
+		locs[i] = &rds.GeoLocation{Name: loc.Name, Longitude: loc.Longitude, Latitude: loc.Latitude}
+	}
+	res := p.db.GeoAdd(ctx, key, locs...)
+	return newResultFunc(p, res.Result)
+}
+
+// GeoPos implements caches.GeoCommand.
+func (p *Provider) GeoPos(ctx context.Context, key string, members ...string) caches.Result[[]*caches.GeoPos] {
+	key = p.prefix + key
+	res := p.db.GeoPos(ctx, key, members...)
+	return newResultFunc(p, func() ([]*caches.GeoPos, error) {
+		vals, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]*caches.GeoPos, len(vals))
+		for i, pos := range vals {
+			if pos != nil {
+				result[i] = &caches.GeoPos{Longitude: pos.Longitude, Latitude: pos.Latitude}
+			}
+		}
+		return result, nil
+	})
+}
+
+// GeoDist implements caches.GeoCommand.
+func (p *Provider) GeoDist(ctx context.Context, key string, member1, member2, unit string) caches.Result[float64] {
+	key = p.prefix + key
+	res := p.db.GeoDist(ctx, key, member1, member2, unit)
+	return newResultFunc(p, res.Result)
+}
+
+// GeoHash implements caches.GeoCommand.
+func (p *Provider) GeoHash(ctx context.Context, key string, members ...string) caches.Result[[]string] {
+	key = p.prefix + key
+	res := p.db.GeoHash(ctx, key, members...)
+	return newResultFunc(p, res.Result)
+}
+
+// GeoSearch implements caches.GeoCommand.
+func (p *Provider) GeoSearch(ctx context.Context, key string, q *caches.GeoSearchQuery) caches.Result[[]string] {
+	key = p.prefix + key
+	query := toGeoSearchQuery(q)
+	res := p.db.GeoSearch(ctx, key, &query)
+	return newResultFunc(p, res.Result)
+}
+
+// GeoSearchLocation implements caches.GeoCommand.
+func (p *Provider) GeoSearchLocation(ctx context.Context, key string, q *caches.GeoSearchLocationQuery) caches.Result[[]caches.GeoLocation] {
+	key = p.prefix + key
+	res := p.db.GeoSearchLocation(ctx, key, &rds.GeoSearchLocationQuery{
+		GeoSearchQuery: toGeoSearchQuery(&q.GeoSearchQuery),
+		WithCoord:      q.WithCoord,
+		WithDist:       q.WithDist,
+		WithHash:       q.WithHash,
+	})
+	return newResultFunc(p, func() ([]caches.GeoLocation, error) {
+		vals, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]caches.GeoLocation, len(vals))
+		for i, loc := range vals {
+			result[i] = caches.GeoLocation(loc)
+		}
+		return result, nil
+	})
+}
+
+// GeoSearchStore implements caches.GeoCommand.
+func (p *Provider) GeoSearchStore(ctx context.Context, key, store string, q *caches.GeoSearchStoreQuery) caches.Result[int64] {
+	key = p.prefix + key
+	store = p.prefix + store
+	res := p.db.GeoSearchStore(ctx, key, store, &rds.GeoSearchStoreQuery{
+		GeoSearchQuery: toGeoSearchQuery(&q.GeoSearchQuery),
+		StoreDist:      q.StoreDist,
+	})
+	return newResultFunc(p, res.Result)
+}
@@ -2,7 +2,10 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	rds "github.com/redis/go-redis/v9"
 	"github.com/rockcookies/go-caches"
 )
 
@@ -28,24 +31,27 @@ func (p *Provider) HExists(ctx context.Context, key string, field string) caches
 func (p *Provider) HGet(ctx context.Context, key string, field string) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.HGet(ctx, key, field)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // HGetAll implements caches.HashCommand.
 func (p *Provider) HGetAll(ctx context.Context, key string) caches.Result[map[string][]byte] {
 	key = p.prefix + key
 	res := p.db.HGetAll(ctx, key)
+	return newResultFunc(p, func() (map[string][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(map[string][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make(map[string][]byte, len(res.Val()))
-	for field, value := range res.Val() {
-		result[field] = []byte(value)
-	}
+		result := make(map[string][]byte, len(res.Val()))
+		for field, value := range res.Val() {
+			result[field] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // HIncrBy implements caches.HashCommand.
@@ -84,58 +90,61 @@ func (p *Provider) HLen(ctx context.Context, key string) caches.Result[int64] {
 func (p *Provider) HMGet(ctx context.Context, key string, fields ...string) caches.Result[map[string][]byte] {
 	key = p.prefix + key
 	res := p.db.HMGet(ctx, key, fields...)
+	return newResultFunc(p, func() (map[string][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(map[string][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make(map[string][]byte, len(fields))
-	for i, value := range res.Val() {
-		if value != nil {
-			if strVal, ok := value.(string); ok {
-				result[fields[i]] = []byte(strVal)
+		result := make(map[string][]byte, len(fields))
+		for i, value := range res.Val() {
+			if value != nil {
+				if strVal, ok := value.(string); ok {
+					result[fields[i]] = []byte(strVal)
+				}
 			}
 		}
-	}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // HMSet implements caches.HashCommand.
 func (p *Provider) HMSet(ctx context.Context, key string, values map[string]any) caches.StatusResult {
 	key = p.prefix + key
 	res := p.db.HSet(ctx, key, values)
-
-	// Convert IntCmd to StatusResult
-	if res.Err() != nil {
-		return newStatusResult(nil, formatError(res.Err()))
-	}
-	return newStatusResult([]byte("OK"), nil)
+	return newStatusResultFunc(p, func() ([]byte, error) {
+		// Convert IntCmd to StatusResult
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
+		return []byte("OK"), nil
+	})
 }
 
 // HScan implements caches.HashCommand.
 func (p *Provider) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) caches.Result[caches.HScanResult] {
 	key = p.prefix + key
 	res := p.db.HScan(ctx, key, cursor, match, count)
+	return newResultFunc(p, func() (caches.HScanResult, error) {
+		if res.Err() != nil {
+			return caches.HScanResult{}, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(caches.HScanResult{}, formatError(res.Err()))
-	}
-
-	keys, newCursor := res.Val()
-	fields := make(map[string][]byte)
+		keys, newCursor := res.Val()
+		fields := make(map[string][]byte)
 
-	// HScan returns field-value pairs as a flat slice
-	for i := 0; i < len(keys); i += 2 {
-		if i+1 < len(keys) {
-			fields[keys[i]] = []byte(keys[i+1])
+		// HScan returns field-value pairs as a flat slice
+		for i := 0; i < len(keys); i += 2 {
+			if i+1 < len(keys) {
+				fields[keys[i]] = []byte(keys[i+1])
+			}
 		}
-	}
 
-	return newResult(caches.HScanResult{
-		Cursor: newCursor,
-		Fields: fields,
-	}, nil)
+		return caches.HScanResult{
+			Cursor: newCursor,
+			Fields: fields,
+		}, nil
+	})
 }
 
 // HSet implements caches.HashCommand.
@@ -158,15 +167,209 @@ func (p *Provider) HSetNX(ctx context.Context, key string, field string, value a
 func (p *Provider) HVals(ctx context.Context, key string) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.HVals(ctx, key)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
+
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
+
+		return result, nil
+	})
+}
+
+// HExpire implements caches.HashCommand.
+func (p *Provider) HExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HExpire(ctx, key, expiration, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HExpireNX implements caches.HashCommand.
+func (p *Provider) HExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{NX: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HExpireXX implements caches.HashCommand.
+func (p *Provider) HExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{XX: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HExpireGT implements caches.HashCommand.
+func (p *Provider) HExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{GT: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HExpireLT implements caches.HashCommand.
+func (p *Provider) HExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{LT: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HExpireAt implements caches.HashCommand.
+func (p *Provider) HExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HExpireAt(ctx, key, tm, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HPExpire implements caches.HashCommand.
+func (p *Provider) HPExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPExpire(ctx, key, expiration, fields...)
+	return newResultFunc(p, res.Result)
+}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
+// HPExpireNX implements caches.HashCommand.
+func (p *Provider) HPExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{NX: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HPExpireXX implements caches.HashCommand.
+func (p *Provider) HPExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{XX: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HPExpireGT implements caches.HashCommand.
+func (p *Provider) HPExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{GT: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HPExpireLT implements caches.HashCommand.
+func (p *Provider) HPExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPExpireWithArgs(ctx, key, expiration, rds.HExpireArgs{LT: true}, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HPExpireAt implements caches.HashCommand.
+func (p *Provider) HPExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPExpireAt(ctx, key, tm, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// httlResult converts the replies of HTTL and HPTTL to durations of unit,
+// keeping the -2 (missing) / -1 (persistent) codes.
+func httlResult(res *rds.IntSliceCmd, unit time.Duration) func() ([]time.Duration, error) {
+	return func() ([]time.Duration, error) {
+		vals, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]time.Duration, len(vals))
+		for i, v := range vals {
+			if v < 0 {
+				result[i] = time.Duration(v)
+			} else {
+				result[i] = time.Duration(v) * unit
+			}
+		}
+		return result, nil
+	}
+}
+
+// HTTL implements caches.HashCommand.
+func (p *Provider) HTTL(ctx context.Context, key string, fields ...string) caches.Result[[]time.Duration] {
+	key = p.prefix + key
+	res := p.db.HTTL(ctx, key, fields...)
+	return newResultFunc(p, httlResult(res, time.Second))
+}
+
+// HPTTL implements caches.HashCommand.
+func (p *Provider) HPTTL(ctx context.Context, key string, fields ...string) caches.Result[[]time.Duration] {
+	key = p.prefix + key
+	res := p.db.HPTTL(ctx, key, fields...)
+	return newResultFunc(p, httlResult(res, time.Millisecond))
+}
+
+// HPersist implements caches.HashCommand.
+func (p *Provider) HPersist(ctx context.Context, key string, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.HPersist(ctx, key, fields...)
+	return newResultFunc(p, res.Result)
+}
+
+// HGetEx implements caches.HashCommand.
+// HGETEX requires Redis 8.0 or later.
+// The reply is parsed from a generic command since StringSliceCmd turns the missing fields into empty strings.
+func (p *Provider) HGetEx(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	args := []any{"hgetex", key}
+	switch {
+	case expiration > 0:
+		args = append(args, "px", formatMs(expiration))
+	case expiration == 0:
+		args = append(args, "persist")
 	}
+	args = append(args, "fields", len(fields))
+	for _, field := range fields {
+		args = append(args, field)
+	}
+
+	res := rds.NewCmd(ctx, args...)
+	_ = p.db.(processor).Process(ctx, res)
+	return newResultFunc(p, func() ([][]byte, error) {
+		vals, err := res.Slice()
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([][]byte, len(vals))
+		for i, v := range vals {
+			switch v := v.(type) {
+			case nil:
+			case string:
+				result[i] = []byte(v)
+			default:
+				return nil, fmt.Errorf("redis: unexpected HGETEX reply %T", v)
+			}
+		}
+		return result, nil
+	})
+}
 
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
+// HSetEx implements caches.HashCommand.
+// HSETEX requires Redis 8.0 or later.
+// The command is built by hand so that the values are encoded like the ones of HSet.
+func (p *Provider) HSetEx(ctx context.Context, key string, mode string, expiration time.Duration, values map[string]any) caches.Result[bool] {
+	key = p.prefix + key
+	args := []any{"hsetex", key}
+	if mode != "" {
+		args = append(args, mode)
+	}
+	switch {
+	case expiration > 0:
+		args = append(args, "px", formatMs(expiration))
+	case expiration < 0:
+		args = append(args, "keepttl")
+	}
+	args = append(args, "fields", len(values))
+	for field, value := range values {
+		args = append(args, field, value)
 	}
 
-	return newResult(result, nil)
+	res := rds.NewIntCmd(ctx, args...)
+	_ = p.db.(processor).Process(ctx, res)
+	return newResultFunc(p, func() (bool, error) {
+		n, err := res.Result()
+		return n == 1, err
+	})
 }
@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.HyperLogLogCommand = (*Provider)(nil)
+
+// PFAdd implements caches.HyperLogLogCommand.
+func (p *Provider) PFAdd(ctx context.Context, key string, els ...any) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.PFAdd(ctx, key, els...)
+	return newResultFunc(p, res.Result)
+}
+
+// PFCount implements caches.HyperLogLogCommand.
+func (p *Provider) PFCount(ctx context.Context, keys ...string) caches.Result[int64] {
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.PFCount(ctx, keys...)
+	return newResultFunc(p, res.Result)
+}
+
+// PFMerge implements caches.HyperLogLogCommand.
+func (p *Provider) PFMerge(ctx context.Context, dest string, keys ...string) caches.StatusResult {
+	dest = p.prefix + dest
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.PFMerge(ctx, dest, keys...)
+	return newStatusResultFunc(p, func() ([]byte, error) {
+		val, err := res.Result()
+		return []byte(val), err
+	})
+}
@@ -2,8 +2,10 @@ package redis
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
+	rds "github.com/redis/go-redis/v9"
 	"github.com/rockcookies/go-caches"
 )
 
@@ -11,11 +13,29 @@ var _ caches.KeyCommand = (*Provider)(nil)
 
 // DBSize implements caches.KeyCommand.
 func (p *Provider) DBSize(ctx context.Context) caches.Result[int64] {
+	if p.prefix != "" {
+		return p.DBSizePrefix(ctx, "")
+	}
+
 	res := p.db.DBSize(ctx)
 	res.SetErr(formatError(res.Err()))
 	return res
 }
 
+// DBSizePrefix implements caches.KeyCommand.
+// Keys are counted with SCAN, when the provider is bound to a pipeline they are counted once it is executed.
+func (p *Provider) DBSizePrefix(ctx context.Context, prefix string) caches.Result[int64] {
+	prefix = p.prefix + prefix
+	return newResultFunc(p, func() (int64, error) {
+		var n atomic.Int64
+		err := p.scanPrefix(ctx, prefix, func(ctx context.Context, c rds.Cmdable, keys []string) error {
+			n.Add(int64(len(keys)))
+			return nil
+		})
+		return n.Load(), err
+	})
+}
+
 // Del implements caches.KeyCommand.
 func (p *Provider) Del(ctx context.Context, keys ...string) caches.Result[int64] {
 	keys = prefixKeys(p.prefix, keys)
@@ -89,36 +109,84 @@ func (p *Provider) ExpireXX(ctx context.Context, key string, expiration time.Dur
 }
 
 // FlushAll implements caches.KeyCommand.
+// With a key prefix, FLUSHALL is not sent and the keys under the prefix are deleted as with FlushPrefix.
 func (p *Provider) FlushAll(ctx context.Context) caches.StatusResult {
+	if p.prefix != "" {
+		res := p.FlushPrefix(ctx, "")
+		return newStatusResultFunc(p, func() ([]byte, error) {
+			if err := res.Err(); err != nil {
+				return nil, err
+			}
+			return []byte("OK"), nil
+		})
+	}
+
 	res := p.db.FlushAll(ctx)
 	res.SetErr(formatError(res.Err()))
 	return res
 }
 
+// FlushPrefix implements caches.KeyCommand.
+// Keys are found with SCAN and deleted with UNLINK in batches, so the deletion is not atomic.
+// When the provider is bound to a pipeline they are deleted once it is executed.
+func (p *Provider) FlushPrefix(ctx context.Context, prefix string) caches.Result[int64] {
+	prefix = p.prefix + prefix
+	_, cluster := p.client.(*rds.ClusterClient)
+	return newResultFunc(p, func() (int64, error) {
+		var n atomic.Int64
+		err := p.scanPrefix(ctx, prefix, func(ctx context.Context, c rds.Cmdable, keys []string) error {
+			if !cluster {
+				deleted, err := c.Unlink(ctx, keys...).Result()
+				n.Add(deleted)
+				return err
+			}
+
+			// Keys of a cluster node may belong to different slots, so they are unlinked one by one
+			cmds, err := c.Pipelined(ctx, func(pipe rds.Pipeliner) error {
+				for _, key := range keys {
+					pipe.Unlink(ctx, key)
+				}
+				return nil
+			})
+			for _, cmd := range cmds {
+				if cmd, ok := cmd.(*rds.IntCmd); ok {
+					n.Add(cmd.Val())
+				}
+			}
+			return err
+		})
+		return n.Load(), err
+	})
+}
+
 // Keys implements caches.KeyCommand.
 func (p *Provider) Keys(ctx context.Context, pattern string) caches.Result[[]string] {
 	pattern = p.prefix + pattern
 	res := p.db.Keys(ctx, pattern)
+	return newResultFunc(p, func() ([]string, error) {
+		keys, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
 
-	// 去除前缀
-	prefixLen := len(p.prefix)
-	if prefixLen > 0 && res.Err() == nil {
-		keys := res.Val()
-		result := make([]string, len(keys))
-		for i, key := range keys {
-			// 边界检查：键长度应该 >= 前缀长度
-			if len(key) >= prefixLen {
-				result[i] = key[prefixLen:]
-			} else {
-				// 理论上不应该发生，但添加防御性代码
-				result[i] = key
+		// 去除前缀
+		prefixLen := len(p.prefix)
+		if prefixLen > 0 {
+			result := make([]string, len(keys))
+			for i, key := range keys {
+				// 边界检查：键长度应该 >= 前缀长度
+				if len(key) >= prefixLen {
+					result[i] = key[prefixLen:]
+				} else {
+					// 理论上不应该发生，但添加防御性代码
+					result[i] = key
+				}
 			}
+			return result, nil
 		}
-		return newResult(result, formatError(res.Err()))
-	}
 
-	res.SetErr(formatError(res.Err()))
-	return res
+		return keys, nil
+	})
 }
 
 // PExpire implements caches.KeyCommand.
@@ -208,56 +276,58 @@ func (p *Provider) RandomKey(ctx context.Context) caches.Result[string] {
 	// 使用 SCAN 找到匹配前缀的键，然后随机选择一个
 	// 这避免了泄露其他前缀的键
 	res := p.db.RandomKey(ctx)
-	if res.Err() != nil {
-		res.SetErr(formatError(res.Err()))
-		return res
-	}
+	return newResultFunc(p, func() (string, error) {
+		if res.Err() != nil {
+			return "", res.Err()
+		}
 
-	key := res.Val()
-	prefixLen := len(p.prefix)
+		key := res.Val()
+		prefixLen := len(p.prefix)
 
-	// 检查键是否匹配前缀
-	if len(key) >= prefixLen && key[:prefixLen] == p.prefix {
-		// 匹配成功，去除前缀返回
-		return newResult(key[prefixLen:], nil)
-	}
+		// 检查键是否匹配前缀
+		if len(key) >= prefixLen && key[:prefixLen] == p.prefix {
+			// 匹配成功，去除前缀返回
+			return key[prefixLen:], nil
+		}
 
-	// 键不匹配前缀，返回 Nil 表示未找到（避免泄露其他应用的键）
-	return newResult("", caches.Nil)
+		// 键不匹配前缀，返回 Nil 表示未找到（避免泄露其他应用的键）
+		return "", caches.Nil
+	})
 }
 
 // Scan implements caches.KeyCommand.
 func (p *Provider) Scan(ctx context.Context, cursor uint64, match string, count int64) caches.Result[caches.KeyScanResult] {
 	pattern := p.prefix + match
 	res := p.db.Scan(ctx, cursor, pattern, count)
+	return newResultFunc(p, func() (caches.KeyScanResult, error) {
+		if res.Err() != nil {
+			return caches.KeyScanResult{}, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(caches.KeyScanResult{}, formatError(res.Err()))
-	}
-
-	keys, newCursor, err := res.Result()
-	if err != nil {
-		return newResult(caches.KeyScanResult{}, formatError(err))
-	}
+		keys, newCursor, err := res.Result()
+		if err != nil {
+			return caches.KeyScanResult{}, err
+		}
 
-	// 去除前缀
-	prefixLen := len(p.prefix)
-	if prefixLen > 0 {
-		result := make([]string, len(keys))
-		for i, key := range keys {
-			// 边界检查：键长度应该 >= 前缀长度
-			if len(key) >= prefixLen {
-				result[i] = key[prefixLen:]
-			} else {
-				// 理论上不应该发生，但添加防御性代码
-				result[i] = key
+		// 去除前缀
+		prefixLen := len(p.prefix)
+		if prefixLen > 0 {
+			result := make([]string, len(keys))
+			for i, key := range keys {
+				// 边界检查：键长度应该 >= 前缀长度
+				if len(key) >= prefixLen {
+					result[i] = key[prefixLen:]
+				} else {
+					// 理论上不应该发生，但添加防御性代码
+					result[i] = key
+				}
 			}
+			keys = result
 		}
-		keys = result
-	}
 
-	return newResult(caches.KeyScanResult{
-		Cursor: newCursor,
-		Keys:   keys,
-	}, nil)
+		return caches.KeyScanResult{
+			Cursor: newCursor,
+			Keys:   keys,
+		}, nil
+	})
 }
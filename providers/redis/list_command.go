@@ -2,17 +2,69 @@ package redis
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	rds "github.com/redis/go-redis/v9"
 	"github.com/rockcookies/go-caches"
 )
 
 var _ caches.ListCommand = (*Provider)(nil)
 
+// BLMove implements caches.ListCommand.
+func (p *Provider) BLMove(ctx context.Context, source, destination string, srcpos, destpos caches.ListDirection, timeout time.Duration) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	res := p.db.BLMove(ctx, source, destination, string(srcpos), string(destpos), timeout)
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
+}
+
+// listPopResult converts a BLPOP/BRPOP reply into a caches.ListPopResult.
+func (p *Provider) listPopResult(res *rds.StringSliceCmd) caches.Result[caches.ListPopResult] {
+	return newResultFunc(p, func() (caches.ListPopResult, error) {
+		vals, err := res.Result()
+		if err != nil {
+			return caches.ListPopResult{}, err
+		}
+
+		return caches.ListPopResult{
+			Key:     strings.TrimPrefix(vals[0], p.prefix),
+			Element: []byte(vals[1]),
+		}, nil
+	})
+}
+
+// BLPop implements caches.ListCommand.
+func (p *Provider) BLPop(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ListPopResult] {
+	keys = prefixKeys(p.prefix, keys)
+	return p.listPopResult(p.db.BLPop(ctx, timeout, keys...))
+}
+
+// BRPop implements caches.ListCommand.
+func (p *Provider) BRPop(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ListPopResult] {
+	keys = prefixKeys(p.prefix, keys)
+	return p.listPopResult(p.db.BRPop(ctx, timeout, keys...))
+}
+
+// BRPopLPush implements caches.ListCommand.
+func (p *Provider) BRPopLPush(ctx context.Context, source, destination string, timeout time.Duration) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	res := p.db.BRPopLPush(ctx, source, destination, timeout)
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
+}
+
 // LIndex implements caches.ListCommand.
 func (p *Provider) LIndex(ctx context.Context, key string, index int64) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.LIndex(ctx, key, index)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // LInsert implements caches.ListCommand.
@@ -31,28 +83,79 @@ func (p *Provider) LLen(ctx context.Context, key string) caches.Result[int64] {
 	return res
 }
 
+// LMove implements caches.ListCommand.
+func (p *Provider) LMove(ctx context.Context, source, destination string, srcpos, destpos caches.ListDirection) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	res := p.db.LMove(ctx, source, destination, string(srcpos), string(destpos))
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
+}
+
+// LMPop implements caches.ListCommand.
+func (p *Provider) LMPop(ctx context.Context, direction caches.ListDirection, count int64, keys ...string) caches.Result[caches.ListMPopResult] {
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.LMPop(ctx, string(direction), count, keys...)
+	return newResultFunc(p, func() (caches.ListMPopResult, error) {
+		key, vals, err := res.Result()
+		if err != nil {
+			return caches.ListMPopResult{}, err
+		}
+
+		elements := make([][]byte, len(vals))
+		for i, value := range vals {
+			elements[i] = []byte(value)
+		}
+
+		return caches.ListMPopResult{
+			Key:      strings.TrimPrefix(key, p.prefix),
+			Elements: elements,
+		}, nil
+	})
+}
+
 // LPop implements caches.ListCommand.
 func (p *Provider) LPop(ctx context.Context, key string) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.LPop(ctx, key)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // LPopCount implements caches.ListCommand.
 func (p *Provider) LPopCount(ctx context.Context, key string, count int) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.LPopCount(ctx, key, count)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		return result, nil
+	})
+}
 
-	return newResult(result, nil)
+// LPos implements caches.ListCommand.
+func (p *Provider) LPos(ctx context.Context, key string, element string, args caches.LPosArgs) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.LPos(ctx, key, element, rds.LPosArgs{Rank: args.Rank, MaxLen: args.MaxLen})
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// LPosCount implements caches.ListCommand.
+func (p *Provider) LPosCount(ctx context.Context, key string, element string, count int64, args caches.LPosArgs) caches.Result[[]int64] {
+	key = p.prefix + key
+	res := p.db.LPosCount(ctx, key, element, count, rds.LPosArgs{Rank: args.Rank, MaxLen: args.MaxLen})
+	res.SetErr(formatError(res.Err()))
+	return res
 }
 
 // LPush implements caches.ListCommand.
@@ -63,21 +166,30 @@ func (p *Provider) LPush(ctx context.Context, key string, elements ...any) cache
 	return res
 }
 
+// LPushX implements caches.ListCommand.
+func (p *Provider) LPushX(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.LPushX(ctx, key, elements...)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
 // LRange implements caches.ListCommand.
 func (p *Provider) LRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.LRange(ctx, key, start, stop)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // LRem implements caches.ListCommand.
@@ -108,24 +220,27 @@ func (p *Provider) LTrim(ctx context.Context, key string, start, stop int64) cac
 func (p *Provider) RPop(ctx context.Context, key string) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.RPop(ctx, key)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // RPopCount implements caches.ListCommand.
 func (p *Provider) RPopCount(ctx context.Context, key string, count int) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.RPopCount(ctx, key, count)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // RPopLPush implements caches.ListCommand.
@@ -133,7 +248,9 @@ func (p *Provider) RPopLPush(ctx context.Context, source, destination string) ca
 	source = p.prefix + source
 	destination = p.prefix + destination
 	res := p.db.RPopLPush(ctx, source, destination)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // RPush implements caches.ListCommand.
@@ -143,3 +260,11 @@ func (p *Provider) RPush(ctx context.Context, key string, elements ...any) cache
 	res.SetErr(formatError(res.Err()))
 	return res
 }
+
+// RPushX implements caches.ListCommand.
+func (p *Provider) RPushX(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.RPushX(ctx, key, elements...)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
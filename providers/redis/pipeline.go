@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.PipelineCommand = (*Provider)(nil)
+
+// pipeline implements caches.Pipeliner on top of rds.Pipeliner.
+// Commands are issued through a Provider bound to the pipeline,
+// and their results are resolved after Exec.
+type pipeline struct {
+	*Provider
+	pipe    rds.Pipeliner
+	pending []func()
+}
+
+var _ caches.Pipeliner = (*pipeline)(nil)
+
+func (p *Provider) newPipeline(pipe rds.Pipeliner) *pipeline {
+	pl := &pipeline{pipe: pipe}
+	pl.Provider = &Provider{
+		client:  p.client,
+		db:      pipe,
+		prefix:  p.prefix,
+		pending: &pl.pending,
+	}
+	return pl
+}
+
+// Pipeline implements caches.PipelineCommand.
+func (p *Provider) Pipeline() caches.Pipeliner {
+	return p.newPipeline(p.client.Pipeline())
+}
+
+// Pipelined implements caches.PipelineCommand.
+func (p *Provider) Pipelined(ctx context.Context, fn func(pipe caches.Pipeliner) error) error {
+	pipe := p.Pipeline()
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	return pipe.Exec(ctx)
+}
+
+// Len implements caches.Pipeliner.
+func (p *pipeline) Len() int {
+	return p.pipe.Len()
+}
+
+// Discard implements caches.Pipeliner.
+func (p *pipeline) Discard() {
+	p.pipe.Discard()
+	p.pending = nil
+}
+
+// Exec implements caches.Pipeliner.
+func (p *pipeline) Exec(ctx context.Context) error {
+	cmds, err := p.pipe.Exec(ctx)
+
+	// Commands returned as is still carry the raw go-redis errors
+	for _, cmd := range cmds {
+		cmd.SetErr(formatError(cmd.Err()))
+	}
+
+	pending := p.pending
+	p.pending = nil
+	for _, fn := range pending {
+		fn()
+	}
+
+	return formatError(err)
+}
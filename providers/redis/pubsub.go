@@ -0,0 +1,152 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.PubSub = (*Provider)(nil)
+
+// Publish implements caches.PubSub.
+func (p *Provider) Publish(ctx context.Context, channel string, message any) caches.Result[int64] {
+	res := p.db.Publish(ctx, p.prefix+channel, message)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// PSubscribe implements caches.PubSub.
+func (p *Provider) PSubscribe(ctx context.Context, patterns ...string) caches.Subscription {
+	ps := p.client.PSubscribe(ctx, prefixKeys(p.prefix, patterns)...)
+	return newSubscription(ctx, p.prefix, ps, len(patterns))
+}
+
+// Subscribe implements caches.PubSub.
+func (p *Provider) Subscribe(ctx context.Context, channels ...string) caches.Subscription {
+	ps := p.client.Subscribe(ctx, prefixKeys(p.prefix, channels)...)
+	return newSubscription(ctx, p.prefix, ps, len(channels))
+}
+
+// subscription converts the messages of a Redis PubSub to caches.Message.
+type subscription struct {
+	ps     *rds.PubSub
+	prefix string
+
+	ch   chan *caches.Message
+	done chan struct{}
+	once sync.Once
+}
+
+var _ caches.Subscription = (*subscription)(nil)
+
+// newSubscription waits for the confirmation of n subscriptions,
+// so that the messages published afterwards are received, and starts forwarding messages.
+// Messages received while waiting are forwarded first.
+func newSubscription(ctx context.Context, prefix string, ps *rds.PubSub, n int) *subscription {
+	s := &subscription{
+		ps:     ps,
+		prefix: prefix,
+		ch:     make(chan *caches.Message, 100),
+		done:   make(chan struct{}),
+	}
+
+	var received []*rds.Message
+	for n > 0 {
+		msg, err := ps.Receive(ctx)
+		if err != nil {
+			// go-redis resubscribes once the connection is restored
+			break
+		}
+
+		switch msg := msg.(type) {
+		case *rds.Subscription:
+			n--
+		case *rds.Message:
+			received = append(received, msg)
+		}
+	}
+
+	go s.forward(received)
+	return s
+}
+
+func (s *subscription) forward(received []*rds.Message) {
+	defer close(s.ch)
+
+	send := func(msg *rds.Message) bool {
+		select {
+		case s.ch <- s.convert(msg):
+			return true
+		case <-s.done:
+			return false
+		}
+	}
+
+	for _, msg := range received {
+		if !send(msg) {
+			return
+		}
+	}
+
+	msgs := s.ps.Channel()
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok || !send(msg) {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// convert removes the provider prefix from the channel and pattern of msg.
+func (s *subscription) convert(msg *rds.Message) *caches.Message {
+	m := &caches.Message{
+		Channel: strings.TrimPrefix(msg.Channel, s.prefix),
+		Payload: []byte(msg.Payload),
+	}
+	if msg.Pattern != "" {
+		m.Pattern = strings.TrimPrefix(msg.Pattern, s.prefix)
+	}
+	return m
+}
+
+// Subscribe implements caches.Subscription.
+func (s *subscription) Subscribe(ctx context.Context, channels ...string) error {
+	return formatError(s.ps.Subscribe(ctx, prefixKeys(s.prefix, channels)...))
+}
+
+// PSubscribe implements caches.Subscription.
+func (s *subscription) PSubscribe(ctx context.Context, patterns ...string) error {
+	return formatError(s.ps.PSubscribe(ctx, prefixKeys(s.prefix, patterns)...))
+}
+
+// Unsubscribe implements caches.Subscription.
+func (s *subscription) Unsubscribe(ctx context.Context, channels ...string) error {
+	return formatError(s.ps.Unsubscribe(ctx, prefixKeys(s.prefix, channels)...))
+}
+
+// PUnsubscribe implements caches.Subscription.
+func (s *subscription) PUnsubscribe(ctx context.Context, patterns ...string) error {
+	return formatError(s.ps.PUnsubscribe(ctx, prefixKeys(s.prefix, patterns)...))
+}
+
+// Channel implements caches.Subscription.
+func (s *subscription) Channel() <-chan *caches.Message {
+	return s.ch
+}
+
+// Close implements caches.Subscription.
+func (s *subscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.ps.Close()
+	})
+	return err
+}
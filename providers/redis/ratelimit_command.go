@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.RateLimitCommand = (*Provider)(nil)
+
+// The rate limiting scripts reply {allowed, remaining, retry after in microseconds},
+// times being read from the server clock so that all clients agree on them.
+
+var rateLimitFixedWindowScript = rds.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local count = tonumber(redis.call("GET", KEYS[1]) or "0")
+if not count then
+	return redis.error_reply("ERR value is not an integer or out of range")
+end
+
+local pttl = redis.call("PTTL", KEYS[1])
+if count + n > limit then
+	local retry = -1
+	if n <= limit then
+		retry = window * 1000
+		if pttl > 0 then
+			retry = pttl * 1000
+		end
+	end
+	return {0, math.max(limit - count, 0), retry}
+end
+
+if n > 0 then
+	redis.call("INCRBY", KEYS[1], n)
+	if pttl < 0 then
+		redis.call("PEXPIRE", KEYS[1], window)
+	end
+end
+return {1, limit - count - n, 0}`)
+
+var rateLimitSlidingLogScript = rds.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+if count + n > limit then
+	local retry = -1
+	if n <= limit then
+		local rank = count + n - limit - 1
+		local oldest = redis.call("ZRANGE", KEYS[1], rank, rank, "WITHSCORES")
+		retry = tonumber(oldest[2]) - now + window
+	end
+	return {0, math.max(limit - count, 0), retry}
+end
+
+if n > 0 then
+	for i = 0, n - 1 do
+		redis.call("ZADD", KEYS[1], now, string.format("%d-%d", now, count + i))
+	end
+	redis.call("PEXPIRE", KEYS[1], ARGV[4])
+end
+return {1, limit - count - n, 0}`)
+
+var rateLimitGCRAScript = rds.NewScript(`
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if not tat then
+	return redis.error_reply("ERR value is not an integer or out of range")
+end
+tat = math.max(tat, now)
+
+local offset = interval * burst
+local new_tat = tat + interval * n
+local diff = now - (new_tat - offset)
+if diff < 0 then
+	local retry = -diff
+	if n > burst then
+		retry = -1
+	end
+	return {0, math.max(math.floor((now - tat + offset) / interval), 0), retry}
+end
+
+if n > 0 then
+	redis.call("SET", KEYS[1], string.format("%d", new_tat), "PX", math.ceil((new_tat - now) / 1000))
+end
+return {1, math.floor(diff / interval), 0}`)
+
+// rateLimitScriptResult converts the reply of a rate limiting script into a caches.Result[caches.RateLimit].
+func (p *Provider) rateLimitScriptResult(res *rds.Cmd) caches.Result[caches.RateLimit] {
+	return newResultFunc(p, func() (caches.RateLimit, error) {
+		vals, err := res.Int64Slice()
+		if err != nil {
+			return caches.RateLimit{}, err
+		}
+
+		retryAfter := time.Duration(vals[2]) * time.Microsecond
+		if vals[2] < 0 {
+			retryAfter = -1
+		}
+		return caches.RateLimit{Allowed: vals[0] == 1, Remaining: vals[1], RetryAfter: retryAfter}, nil
+	})
+}
+
+// RateLimitFixedWindow implements caches.RateLimitCommand.
+func (p *Provider) RateLimitFixedWindow(ctx context.Context, key string, limit int64, window time.Duration, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	res := p.runScript(ctx, rateLimitFixedWindowScript, []string{key}, limit, formatMs(window), n)
+	return p.rateLimitScriptResult(res)
+}
+
+// RateLimitSlidingLog implements caches.RateLimitCommand.
+func (p *Provider) RateLimitSlidingLog(ctx context.Context, key string, limit int64, window time.Duration, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	res := p.runScript(ctx, rateLimitSlidingLogScript, []string{key}, limit, window.Microseconds(), n, formatMs(window))
+	return p.rateLimitScriptResult(res)
+}
+
+// RateLimitGCRA implements caches.RateLimitCommand.
+func (p *Provider) RateLimitGCRA(ctx context.Context, key string, limit int64, period time.Duration, burst, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	interval := max(period.Microseconds()/limit, 1)
+	res := p.runScript(ctx, rateLimitGCRAScript, []string{key}, interval, burst, n)
+	return p.rateLimitScriptResult(res)
+}
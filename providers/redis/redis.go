@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
 )
 
 type Options struct {
@@ -11,8 +12,13 @@ type Options struct {
 }
 
 type Provider struct {
-	db     rds.UniversalClient
+	client rds.UniversalClient
+	db     rds.Cmdable
 	prefix string
+
+	// pending holds the result resolvers of commands queued in a pipeline.
+	// It is nil when commands are sent to the client directly.
+	pending *[]func()
 }
 
 func New(client rds.UniversalClient) *Provider {
@@ -29,7 +35,42 @@ func NewWithOptions(client rds.UniversalClient, opts *Options) *Provider {
 	}
 
 	return &Provider{
+		client: client,
 		db:     client,
 		prefix: strings.TrimSpace(opts.Prefix),
 	}
 }
+
+var _ caches.Cache = (*Provider)(nil)
+
+// Capabilities implements caches.Capable.
+//
+// Hash field expiration requires Redis 7.4 or later, and Redis 8.0 for HGetEx and HSetEx.
+func (p *Provider) Capabilities() caches.Capabilities {
+	return caches.CapKeepTTL |
+		caches.CapSetGet |
+		caches.CapZRangeByLex |
+		caches.CapZScoreExclusive |
+		caches.CapZStoreWeights |
+		caches.CapStreams |
+		caches.CapHashFieldTTL |
+		caches.CapLua |
+		caches.CapHyperLogLog |
+		caches.CapGeo |
+		caches.CapBitmap |
+		caches.CapCompare |
+		caches.CapRateLimit |
+		caches.CapScript |
+		caches.CapPipeline |
+		caches.CapTx |
+		caches.CapPubSub
+}
+
+// resolve runs fn immediately, or once the pipeline the provider is bound to has been executed.
+func (p *Provider) resolve(fn func()) {
+	if p.pending != nil {
+		*p.pending = append(*p.pending, fn)
+		return
+	}
+	fn()
+}
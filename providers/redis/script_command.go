@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.ScriptCommand = (*Provider)(nil)
+
+// Eval implements caches.ScriptCommand.
+func (p *Provider) Eval(ctx context.Context, script string, keys []string, args ...any) caches.Result[any] {
+	res := p.db.Eval(ctx, script, prefixKeys(p.prefix, keys), args...)
+	return newResultFunc(p, res.Result)
+}
+
+// EvalSha implements caches.ScriptCommand.
+func (p *Provider) EvalSha(ctx context.Context, sha1 string, keys []string, args ...any) caches.Result[any] {
+	res := p.db.EvalSha(ctx, sha1, prefixKeys(p.prefix, keys), args...)
+	return newResultFunc(p, res.Result)
+}
+
+// ScriptLoad implements caches.ScriptCommand.
+func (p *Provider) ScriptLoad(ctx context.Context, script string) caches.Result[string] {
+	res := p.db.ScriptLoad(ctx, script)
+	return newResultFunc(p, res.Result)
+}
+
+// ScriptExists implements caches.ScriptCommand.
+func (p *Provider) ScriptExists(ctx context.Context, hashes ...string) caches.Result[[]bool] {
+	res := p.db.ScriptExists(ctx, hashes...)
+	return newResultFunc(p, res.Result)
+}
@@ -28,17 +28,18 @@ func (p *Provider) SCard(ctx context.Context, key string) caches.Result[int64] {
 func (p *Provider) SDiff(ctx context.Context, keys ...string) caches.Result[[][]byte] {
 	keys = prefixKeys(p.prefix, keys)
 	res := p.db.SDiff(ctx, keys...)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
-
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // SDiffStore implements caches.SetCommand.
@@ -54,17 +55,18 @@ func (p *Provider) SDiffStore(ctx context.Context, destination string, keys ...s
 func (p *Provider) SInter(ctx context.Context, keys ...string) caches.Result[[][]byte] {
 	keys = prefixKeys(p.prefix, keys)
 	res := p.db.SInter(ctx, keys...)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // SInterStore implements caches.SetCommand.
@@ -88,17 +90,18 @@ func (p *Provider) SIsMember(ctx context.Context, key string, member any) caches
 func (p *Provider) SMembers(ctx context.Context, key string) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.SMembers(ctx, key)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // SMove implements caches.SetCommand.
@@ -114,48 +117,54 @@ func (p *Provider) SMove(ctx context.Context, source, destination string, member
 func (p *Provider) SPop(ctx context.Context, key string) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.SPop(ctx, key)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // SPopN implements caches.SetCommand.
 func (p *Provider) SPopN(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.SPopN(ctx, key, count)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
-
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // SRandMember implements caches.SetCommand.
 func (p *Provider) SRandMember(ctx context.Context, key string) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.SRandMember(ctx, key)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // SRandMemberN implements caches.SetCommand.
 func (p *Provider) SRandMemberN(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.SRandMemberN(ctx, key, count)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // SRem implements caches.SetCommand.
@@ -170,38 +179,40 @@ func (p *Provider) SRem(ctx context.Context, key string, members ...any) caches.
 func (p *Provider) SScan(ctx context.Context, key string, cursor uint64, match string, count int64) caches.Result[caches.ScanResult] {
 	key = p.prefix + key
 	res := p.db.SScan(ctx, key, cursor, match, count)
+	return newResultFunc(p, func() (caches.ScanResult, error) {
+		if res.Err() != nil {
+			return caches.ScanResult{}, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(caches.ScanResult{}, formatError(res.Err()))
-	}
+		keys, newCursor := res.Val()
+		elements := make([][]byte, len(keys))
+		for i, value := range keys {
+			elements[i] = []byte(value)
+		}
 
-	keys, newCursor := res.Val()
-	elements := make([][]byte, len(keys))
-	for i, value := range keys {
-		elements[i] = []byte(value)
-	}
-
-	return newResult(caches.ScanResult{
-		Cursor:   newCursor,
-		Elements: elements,
-	}, nil)
+		return caches.ScanResult{
+			Cursor:   newCursor,
+			Elements: elements,
+		}, nil
+	})
 }
 
 // SUnion implements caches.SetCommand.
 func (p *Provider) SUnion(ctx context.Context, keys ...string) caches.Result[[][]byte] {
 	keys = prefixKeys(p.prefix, keys)
 	res := p.db.SUnion(ctx, keys...)
-
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
-
-	return newResult(result, nil)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
+
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
+
+		return result, nil
+	})
 }
 
 // SUnionStore implements caches.SetCommand.
@@ -3,6 +3,9 @@ package redis
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	rds "github.com/redis/go-redis/v9"
 	"github.com/rockcookies/go-caches"
@@ -10,6 +13,45 @@ import (
 
 var _ caches.SortedSetCommand = (*Provider)(nil)
 
+// zMembers converts the members of a reply into caches.ZMember values.
+func zMembers(zs []rds.Z) []caches.ZMember {
+	result := make([]caches.ZMember, len(zs))
+	for i, z := range zs {
+		result[i] = caches.ZMember{
+			Member: []byte(z.Member.(string)),
+			Score:  z.Score,
+		}
+	}
+	return result
+}
+
+// zPopResult converts a BZPOPMIN/BZPOPMAX reply into a caches.ZPopResult.
+func (p *Provider) zPopResult(res *rds.ZWithKeyCmd) caches.Result[caches.ZPopResult] {
+	return newResultFunc(p, func() (caches.ZPopResult, error) {
+		val, err := res.Result()
+		if err != nil {
+			return caches.ZPopResult{}, err
+		}
+
+		return caches.ZPopResult{
+			Key:    strings.TrimPrefix(val.Key, p.prefix),
+			Member: zMembers([]rds.Z{val.Z})[0],
+		}, nil
+	})
+}
+
+// BZPopMax implements caches.SortedSetCommand.
+func (p *Provider) BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ZPopResult] {
+	keys = prefixKeys(p.prefix, keys)
+	return p.zPopResult(p.db.BZPopMax(ctx, timeout, keys...))
+}
+
+// BZPopMin implements caches.SortedSetCommand.
+func (p *Provider) BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ZPopResult] {
+	keys = prefixKeys(p.prefix, keys)
+	return p.zPopResult(p.db.BZPopMin(ctx, timeout, keys...))
+}
+
 // ZAdd implements caches.SortedSetCommand.
 func (p *Provider) ZAdd(ctx context.Context, key string, members ...caches.ZMember) caches.Result[int64] {
 	key = p.prefix + key
@@ -67,6 +109,42 @@ func (p *Provider) ZCount(ctx context.Context, key string, min, max string) cach
 	return res
 }
 
+// ZDiff implements caches.SortedSetCommand.
+func (p *Provider) ZDiff(ctx context.Context, keys ...string) caches.Result[[][]byte] {
+	res := p.db.ZDiff(ctx, prefixKeys(p.prefix, keys)...)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
+
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
+
+		return result, nil
+	})
+}
+
+// ZDiffWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZDiffWithScores(ctx context.Context, keys ...string) caches.Result[[]caches.ZMember] {
+	res := p.db.ZDiffWithScores(ctx, prefixKeys(p.prefix, keys)...)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
+		return zMembers(res.Val()), nil
+	})
+}
+
+// ZDiffStore implements caches.SortedSetCommand.
+func (p *Provider) ZDiffStore(ctx context.Context, destination string, keys ...string) caches.Result[int64] {
+	destination = p.prefix + destination
+	res := p.db.ZDiffStore(ctx, destination, prefixKeys(p.prefix, keys)...)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
 // ZIncrBy implements caches.SortedSetCommand.
 func (p *Provider) ZIncrBy(ctx context.Context, key string, increment float64, member string) caches.Result[float64] {
 	key = p.prefix + key
@@ -79,37 +157,46 @@ func (p *Provider) ZIncrBy(ctx context.Context, key string, increment float64, m
 func (p *Provider) ZInter(ctx context.Context, store caches.ZStore) caches.Result[[][]byte] {
 	zstore := convertZStore(p.prefix, store)
 	res := p.db.ZInter(ctx, &zstore)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZInterWithScores implements caches.SortedSetCommand.
 func (p *Provider) ZInterWithScores(ctx context.Context, store caches.ZStore) caches.Result[[]caches.ZMember] {
 	zstore := convertZStore(p.prefix, store)
 	res := p.db.ZInterWithScores(ctx, &zstore)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
-
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
 		}
-	}
 
-	return newResult(result, nil)
+		return result, nil
+	})
+}
+
+// ZInterCard implements caches.SortedSetCommand.
+func (p *Provider) ZInterCard(ctx context.Context, limit int64, keys ...string) caches.Result[int64] {
+	res := p.db.ZInterCard(ctx, limit, prefixKeys(p.prefix, keys)...)
+	res.SetErr(formatError(res.Err()))
+	return res
 }
 
 // ZInterStore implements caches.SortedSetCommand.
@@ -121,41 +208,150 @@ func (p *Provider) ZInterStore(ctx context.Context, destination string, store ca
 	return res
 }
 
+// ZLexCount implements caches.SortedSetCommand.
+func (p *Provider) ZLexCount(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.ZLexCount(ctx, key, min, max)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// ZMPop implements caches.SortedSetCommand.
+func (p *Provider) ZMPop(ctx context.Context, order string, count int64, keys ...string) caches.Result[caches.ZMPopResult] {
+	keys = prefixKeys(p.prefix, keys)
+	res := p.db.ZMPop(ctx, order, count, keys...)
+	return newResultFunc(p, func() (caches.ZMPopResult, error) {
+		key, zs, err := res.Result()
+		if err != nil {
+			return caches.ZMPopResult{}, err
+		}
+
+		return caches.ZMPopResult{
+			Key:     strings.TrimPrefix(key, p.prefix),
+			Members: zMembers(zs),
+		}, nil
+	})
+}
+
+// ZMScore implements caches.SortedSetCommand.
+// The command is sent by hand, as the client reports missing members as 0.
+func (p *Provider) ZMScore(ctx context.Context, key string, members ...string) caches.Result[[]*float64] {
+	key = p.prefix + key
+	args := make([]any, 0, len(members)+2)
+	args = append(args, "zmscore", key)
+	for _, member := range members {
+		args = append(args, member)
+	}
+
+	res := rds.NewCmd(ctx, args...)
+	_ = p.db.(processor).Process(ctx, res)
+	return newResultFunc(p, func() ([]*float64, error) {
+		vals, err := res.Slice()
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]*float64, len(vals))
+		for i, v := range vals {
+			switch v := v.(type) {
+			case nil:
+			case float64:
+				result[i] = &v
+			case string:
+				score, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, err
+				}
+				result[i] = &score
+			default:
+				return nil, fmt.Errorf("redis: unexpected ZMSCORE reply %T", v)
+			}
+		}
+		return result, nil
+	})
+}
+
+// ZPopMax implements caches.SortedSetCommand.
+func (p *Provider) ZPopMax(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	key = p.prefix + key
+	res := p.db.ZPopMax(ctx, key, count)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		zs, err := res.Result()
+		return zMembers(zs), err
+	})
+}
+
+// ZPopMin implements caches.SortedSetCommand.
+func (p *Provider) ZPopMin(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	key = p.prefix + key
+	res := p.db.ZPopMin(ctx, key, count)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		zs, err := res.Result()
+		return zMembers(zs), err
+	})
+}
+
+// ZRandMember implements caches.SortedSetCommand.
+func (p *Provider) ZRandMember(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
+	key = p.prefix + key
+	res := p.db.ZRandMember(ctx, key, int(count))
+	return newResultFunc(p, func() ([][]byte, error) {
+		vals, err := res.Result()
+		result := make([][]byte, len(vals))
+		for i, v := range vals {
+			result[i] = []byte(v)
+		}
+		return result, err
+	})
+}
+
+// ZRandMemberWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRandMemberWithScores(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	key = p.prefix + key
+	res := p.db.ZRandMemberWithScores(ctx, key, int(count))
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		zs, err := res.Result()
+		return zMembers(zs), err
+	})
+}
+
 // ZRange implements caches.SortedSetCommand.
 func (p *Provider) ZRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.ZRange(ctx, key, start, stop)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRangeWithScores implements caches.SortedSetCommand.
 func (p *Provider) ZRangeWithScores(ctx context.Context, key string, start, stop int64) caches.Result[[]caches.ZMember] {
 	key = p.prefix + key
 	res := p.db.ZRangeWithScores(ctx, key, start, stop)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
-
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
 		}
-	}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRangeArgs implements caches.SortedSetCommand.
@@ -163,17 +359,18 @@ func (p *Provider) ZRangeArgs(ctx context.Context, key string, args caches.ZRang
 	key = p.prefix + key
 	zargs := convertZRangeArgs(key, args)
 	res := p.db.ZRangeArgs(ctx, zargs)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRangeArgsWithScores implements caches.SortedSetCommand.
@@ -181,57 +378,87 @@ func (p *Provider) ZRangeArgsWithScores(ctx context.Context, key string, args ca
 	key = p.prefix + key
 	zargs := convertZRangeArgs(key, args)
 	res := p.db.ZRangeArgsWithScores(ctx, zargs)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
+		}
 
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		return result, nil
+	})
+}
+
+// ZRangeStore implements caches.SortedSetCommand.
+func (p *Provider) ZRangeStore(ctx context.Context, destination, key string, args caches.ZRangeArgs) caches.Result[int64] {
+	destination = p.prefix + destination
+	key = p.prefix + key
+	res := p.db.ZRangeStore(ctx, destination, convertZRangeArgs(key, args))
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// ZRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRangeByLex(ctx context.Context, key string, min, max string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	res := p.db.ZRangeByLex(ctx, key, &rds.ZRangeBy{Min: min, Max: max})
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
 		}
-	}
 
-	return newResult(result, nil)
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
+
+		return result, nil
+	})
 }
 
 // ZRangeByScore implements caches.SortedSetCommand.
 func (p *Provider) ZRangeByScore(ctx context.Context, key string, min, max string) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.ZRangeByScore(ctx, key, &rds.ZRangeBy{Min: min, Max: max})
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRangeByScoreWithScores implements caches.SortedSetCommand.
 func (p *Provider) ZRangeByScoreWithScores(ctx context.Context, key string, min, max string) caches.Result[[]caches.ZMember] {
 	key = p.prefix + key
 	res := p.db.ZRangeByScoreWithScores(ctx, key, &rds.ZRangeBy{Min: min, Max: max})
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
-
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
 		}
-	}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRank implements caches.SortedSetCommand.
@@ -246,15 +473,16 @@ func (p *Provider) ZRank(ctx context.Context, key string, member string) caches.
 func (p *Provider) ZRankWithScore(ctx context.Context, key string, member string) caches.Result[caches.ZRankScore] {
 	key = p.prefix + key
 	res := p.db.ZRankWithScore(ctx, key, member)
+	return newResultFunc(p, func() (caches.ZRankScore, error) {
+		if res.Err() != nil {
+			return caches.ZRankScore{}, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(caches.ZRankScore{}, formatError(res.Err()))
-	}
-
-	return newResult(caches.ZRankScore{
-		Rank:  res.Val().Rank,
-		Score: res.Val().Score,
-	}, nil)
+		return caches.ZRankScore{
+			Rank:  res.Val().Rank,
+			Score: res.Val().Score,
+		}, nil
+	})
 }
 
 // ZRem implements caches.SortedSetCommand.
@@ -265,6 +493,14 @@ func (p *Provider) ZRem(ctx context.Context, key string, members ...any) caches.
 	return res
 }
 
+// ZRemRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRemRangeByLex(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.ZRemRangeByLex(ctx, key, min, max)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
 // ZRemRangeByRank implements caches.SortedSetCommand.
 func (p *Provider) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) caches.Result[int64] {
 	key = p.prefix + key
@@ -285,74 +521,96 @@ func (p *Provider) ZRemRangeByScore(ctx context.Context, key string, min, max st
 func (p *Provider) ZRevRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.ZRevRange(ctx, key, start, stop)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRevRangeWithScores implements caches.SortedSetCommand.
 func (p *Provider) ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) caches.Result[[]caches.ZMember] {
 	key = p.prefix + key
 	res := p.db.ZRevRangeWithScores(ctx, key, start, stop)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
+		}
 
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		return result, nil
+	})
+}
+
+// ZRevRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRevRangeByLex(ctx context.Context, key string, max, min string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	res := p.db.ZRevRangeByLex(ctx, key, &rds.ZRangeBy{Min: min, Max: max})
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
 		}
-	}
 
-	return newResult(result, nil)
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
+
+		return result, nil
+	})
 }
 
 // ZRevRangeByScore implements caches.SortedSetCommand.
 func (p *Provider) ZRevRangeByScore(ctx context.Context, key string, max, min string) caches.Result[[][]byte] {
 	key = p.prefix + key
 	res := p.db.ZRevRangeByScore(ctx, key, &rds.ZRangeBy{Min: min, Max: max})
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRevRangeByScoreWithScores implements caches.SortedSetCommand.
 func (p *Provider) ZRevRangeByScoreWithScores(ctx context.Context, key string, max, min string) caches.Result[[]caches.ZMember] {
 	key = p.prefix + key
 	res := p.db.ZRevRangeByScoreWithScores(ctx, key, &rds.ZRangeBy{Min: min, Max: max})
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
-
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
 		}
-	}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZRevRank implements caches.SortedSetCommand.
@@ -367,46 +625,48 @@ func (p *Provider) ZRevRank(ctx context.Context, key string, member string) cach
 func (p *Provider) ZRevRankWithScore(ctx context.Context, key string, member string) caches.Result[caches.ZRankScore] {
 	key = p.prefix + key
 	res := p.db.ZRevRankWithScore(ctx, key, member)
+	return newResultFunc(p, func() (caches.ZRankScore, error) {
+		if res.Err() != nil {
+			return caches.ZRankScore{}, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(caches.ZRankScore{}, formatError(res.Err()))
-	}
-
-	return newResult(caches.ZRankScore{
-		Rank:  res.Val().Rank,
-		Score: res.Val().Score,
-	}, nil)
+		return caches.ZRankScore{
+			Rank:  res.Val().Rank,
+			Score: res.Val().Score,
+		}, nil
+	})
 }
 
 // ZScan implements caches.SortedSetCommand.
 func (p *Provider) ZScan(ctx context.Context, key string, cursor uint64, match string, count int64) caches.Result[caches.ZScanResult] {
 	key = p.prefix + key
 	res := p.db.ZScan(ctx, key, cursor, match, count)
+	return newResultFunc(p, func() (caches.ZScanResult, error) {
+		if res.Err() != nil {
+			return caches.ZScanResult{}, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult(caches.ZScanResult{}, formatError(res.Err()))
-	}
-
-	keys, newCursor := res.Val()
-	members := make([]caches.ZMember, 0, len(keys)/2)
-
-	// ZScan returns member-score pairs as a flat slice
-	for i := 0; i < len(keys); i += 2 {
-		if i+1 < len(keys) {
-			var score float64
-			if _, err := fmt.Sscanf(keys[i+1], "%f", &score); err == nil {
-				members = append(members, caches.ZMember{
-					Member: []byte(keys[i]),
-					Score:  score,
-				})
+		keys, newCursor := res.Val()
+		members := make([]caches.ZMember, 0, len(keys)/2)
+
+		// ZScan returns member-score pairs as a flat slice
+		for i := 0; i < len(keys); i += 2 {
+			if i+1 < len(keys) {
+				var score float64
+				if _, err := fmt.Sscanf(keys[i+1], "%f", &score); err == nil {
+					members = append(members, caches.ZMember{
+						Member: []byte(keys[i]),
+						Score:  score,
+					})
+				}
 			}
 		}
-	}
 
-	return newResult(caches.ZScanResult{
-		Cursor:  newCursor,
-		Members: members,
-	}, nil)
+		return caches.ZScanResult{
+			Cursor:  newCursor,
+			Members: members,
+		}, nil
+	})
 }
 
 // ZScore implements caches.SortedSetCommand.
@@ -421,37 +681,39 @@ func (p *Provider) ZScore(ctx context.Context, key string, member string) caches
 func (p *Provider) ZUnion(ctx context.Context, store caches.ZStore) caches.Result[[][]byte] {
 	zstore := convertZStore(p.prefix, store)
 	res := p.db.ZUnion(ctx, zstore)
+	return newResultFunc(p, func() ([][]byte, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([][]byte(nil), formatError(res.Err()))
-	}
-
-	result := make([][]byte, len(res.Val()))
-	for i, value := range res.Val() {
-		result[i] = []byte(value)
-	}
+		result := make([][]byte, len(res.Val()))
+		for i, value := range res.Val() {
+			result[i] = []byte(value)
+		}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZUnionWithScores implements caches.SortedSetCommand.
 func (p *Provider) ZUnionWithScores(ctx context.Context, store caches.ZStore) caches.Result[[]caches.ZMember] {
 	zstore := convertZStore(p.prefix, store)
 	res := p.db.ZUnionWithScores(ctx, zstore)
+	return newResultFunc(p, func() ([]caches.ZMember, error) {
+		if res.Err() != nil {
+			return nil, res.Err()
+		}
 
-	if res.Err() != nil {
-		return newResult([]caches.ZMember(nil), formatError(res.Err()))
-	}
-
-	result := make([]caches.ZMember, len(res.Val()))
-	for i, z := range res.Val() {
-		result[i] = caches.ZMember{
-			Member: []byte(z.Member.(string)),
-			Score:  z.Score,
+		result := make([]caches.ZMember, len(res.Val()))
+		for i, z := range res.Val() {
+			result[i] = caches.ZMember{
+				Member: []byte(z.Member.(string)),
+				Score:  z.Score,
+			}
 		}
-	}
 
-	return newResult(result, nil)
+		return result, nil
+	})
 }
 
 // ZUnionStore implements caches.SortedSetCommand.
@@ -0,0 +1,308 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.StreamCommand = (*Provider)(nil)
+
+// toXMessages converts go-redis stream entries into caches.XMessage.
+func toXMessages(msgs []rds.XMessage) []caches.XMessage {
+	result := make([]caches.XMessage, len(msgs))
+	for i, msg := range msgs {
+		result[i].ID = msg.ID
+		if msg.Values == nil {
+			continue
+		}
+
+		result[i].Values = make(map[string][]byte, len(msg.Values))
+		for field, value := range msg.Values {
+			switch v := value.(type) {
+			case string:
+				result[i].Values[field] = []byte(v)
+			case nil:
+				result[i].Values[field] = nil
+			}
+		}
+	}
+	return result
+}
+
+// toXStreams converts go-redis streams into caches.XStream, removing the key prefix.
+func (p *Provider) toXStreams(streams []rds.XStream) []caches.XStream {
+	result := make([]caches.XStream, len(streams))
+	for i, stream := range streams {
+		result[i] = caches.XStream{
+			Stream:   strings.TrimPrefix(stream.Stream, p.prefix),
+			Messages: toXMessages(stream.Messages),
+		}
+	}
+	return result
+}
+
+// blockArg converts a caches block duration into the go-redis one,
+// which blocks indefinitely on zero and does not block when negative.
+func blockArg(block time.Duration) time.Duration {
+	switch {
+	case block == 0:
+		return -1
+	case block < 0:
+		return 0
+	}
+	return block
+}
+
+// streamsArg returns the prefixed stream keys followed by their IDs, as expected by go-redis.
+func (p *Provider) streamsArg(keys, ids []string) []string {
+	streams := make([]string, 0, len(keys)+len(ids))
+	for _, key := range keys {
+		streams = append(streams, p.prefix+key)
+	}
+	return append(streams, ids...)
+}
+
+// xMessagesResult converts an entries reply into a caches.Result.
+func (p *Provider) xMessagesResult(res *rds.XMessageSliceCmd) caches.Result[[]caches.XMessage] {
+	return newResultFunc(p, func() ([]caches.XMessage, error) {
+		msgs, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+		return toXMessages(msgs), nil
+	})
+}
+
+// xStreamsResult converts a XREAD/XREADGROUP reply into a caches.Result.
+func (p *Provider) xStreamsResult(res *rds.XStreamSliceCmd) caches.Result[[]caches.XStream] {
+	return newResultFunc(p, func() ([]caches.XStream, error) {
+		streams, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+		return p.toXStreams(streams), nil
+	})
+}
+
+// XAck implements caches.StreamCommand.
+func (p *Provider) XAck(ctx context.Context, key, group string, ids ...string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.XAck(ctx, key, group, ids...)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XAdd implements caches.StreamCommand.
+func (p *Provider) XAdd(ctx context.Context, key string, args caches.XAddArgs) caches.Result[string] {
+	key = p.prefix + key
+	res := p.db.XAdd(ctx, &rds.XAddArgs{
+		Stream:     key,
+		NoMkStream: args.NoMkStream,
+		MaxLen:     args.MaxLen,
+		MinID:      args.MinID,
+		Approx:     args.Approx,
+		ID:         args.ID,
+		Values:     args.Values,
+	})
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XAutoClaim implements caches.StreamCommand.
+func (p *Provider) XAutoClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, start string, count int64) caches.Result[caches.XAutoClaimResult] {
+	key = p.prefix + key
+	res := p.db.XAutoClaim(ctx, &rds.XAutoClaimArgs{
+		Stream:   key,
+		Group:    group,
+		MinIdle:  minIdle,
+		Start:    start,
+		Count:    count,
+		Consumer: consumer,
+	})
+	return newResultFunc(p, func() (caches.XAutoClaimResult, error) {
+		msgs, next, err := res.Result()
+		if err != nil {
+			return caches.XAutoClaimResult{}, err
+		}
+		return caches.XAutoClaimResult{
+			Next:     next,
+			Messages: toXMessages(msgs),
+		}, nil
+	})
+}
+
+// XClaim implements caches.StreamCommand.
+func (p *Provider) XClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, ids ...string) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	res := p.db.XClaim(ctx, &rds.XClaimArgs{
+		Stream:   key,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	})
+	return p.xMessagesResult(res)
+}
+
+// XDel implements caches.StreamCommand.
+func (p *Provider) XDel(ctx context.Context, key string, ids ...string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.XDel(ctx, key, ids...)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XGroupCreate implements caches.StreamCommand.
+func (p *Provider) XGroupCreate(ctx context.Context, key, group, start string) caches.StatusResult {
+	key = p.prefix + key
+	res := p.db.XGroupCreate(ctx, key, group, start)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XGroupCreateMkStream implements caches.StreamCommand.
+func (p *Provider) XGroupCreateMkStream(ctx context.Context, key, group, start string) caches.StatusResult {
+	key = p.prefix + key
+	res := p.db.XGroupCreateMkStream(ctx, key, group, start)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XGroupDestroy implements caches.StreamCommand.
+func (p *Provider) XGroupDestroy(ctx context.Context, key, group string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.XGroupDestroy(ctx, key, group)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XLen implements caches.StreamCommand.
+func (p *Provider) XLen(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.XLen(ctx, key)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XPending implements caches.StreamCommand.
+func (p *Provider) XPending(ctx context.Context, key, group string) caches.Result[caches.XPending] {
+	key = p.prefix + key
+	res := p.db.XPending(ctx, key, group)
+	return newResultFunc(p, func() (caches.XPending, error) {
+		pending, err := res.Result()
+		if err != nil {
+			return caches.XPending{}, err
+		}
+		return caches.XPending{
+			Count:     pending.Count,
+			Lower:     pending.Lower,
+			Higher:    pending.Higher,
+			Consumers: pending.Consumers,
+		}, nil
+	})
+}
+
+// XPendingExt implements caches.StreamCommand.
+func (p *Provider) XPendingExt(ctx context.Context, key, group string, args caches.XPendingExtArgs) caches.Result[[]caches.XPendingExt] {
+	key = p.prefix + key
+	if args.Start == "" {
+		args.Start = "-"
+	}
+	if args.End == "" {
+		args.End = "+"
+	}
+
+	res := p.db.XPendingExt(ctx, &rds.XPendingExtArgs{
+		Stream:   key,
+		Group:    group,
+		Idle:     args.Idle,
+		Start:    args.Start,
+		End:      args.End,
+		Count:    args.Count,
+		Consumer: args.Consumer,
+	})
+	return newResultFunc(p, func() ([]caches.XPendingExt, error) {
+		pending, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]caches.XPendingExt, len(pending))
+		for i, entry := range pending {
+			result[i] = caches.XPendingExt{
+				ID:         entry.ID,
+				Consumer:   entry.Consumer,
+				Idle:       entry.Idle,
+				RetryCount: entry.RetryCount,
+			}
+		}
+		return result, nil
+	})
+}
+
+// XRange implements caches.StreamCommand.
+func (p *Provider) XRange(ctx context.Context, key, start, end string) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	return p.xMessagesResult(p.db.XRange(ctx, key, start, end))
+}
+
+// XRangeN implements caches.StreamCommand.
+func (p *Provider) XRangeN(ctx context.Context, key, start, end string, count int64) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	return p.xMessagesResult(p.db.XRangeN(ctx, key, start, end, count))
+}
+
+// XRead implements caches.StreamCommand.
+func (p *Provider) XRead(ctx context.Context, args caches.XReadArgs) caches.Result[[]caches.XStream] {
+	res := p.db.XRead(ctx, &rds.XReadArgs{
+		Streams: p.streamsArg(args.Streams, args.IDs),
+		Count:   args.Count,
+		Block:   blockArg(args.Block),
+	})
+	return p.xStreamsResult(res)
+}
+
+// XReadGroup implements caches.StreamCommand.
+func (p *Provider) XReadGroup(ctx context.Context, args caches.XReadGroupArgs) caches.Result[[]caches.XStream] {
+	res := p.db.XReadGroup(ctx, &rds.XReadGroupArgs{
+		Group:    args.Group,
+		Consumer: args.Consumer,
+		Streams:  p.streamsArg(args.Streams, args.IDs),
+		Count:    args.Count,
+		Block:    blockArg(args.Block),
+		NoAck:    args.NoAck,
+	})
+	return p.xStreamsResult(res)
+}
+
+// XRevRange implements caches.StreamCommand.
+func (p *Provider) XRevRange(ctx context.Context, key, end, start string) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	return p.xMessagesResult(p.db.XRevRange(ctx, key, end, start))
+}
+
+// XRevRangeN implements caches.StreamCommand.
+func (p *Provider) XRevRangeN(ctx context.Context, key, end, start string, count int64) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	return p.xMessagesResult(p.db.XRevRangeN(ctx, key, end, start, count))
+}
+
+// XTrimMaxLen implements caches.StreamCommand.
+func (p *Provider) XTrimMaxLen(ctx context.Context, key string, maxLen int64) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.XTrimMaxLen(ctx, key, maxLen)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
+// XTrimMinID implements caches.StreamCommand.
+func (p *Provider) XTrimMinID(ctx context.Context, key string, minID string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.XTrimMinID(ctx, key, minID)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
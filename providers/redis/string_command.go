@@ -10,6 +10,14 @@ import (
 
 var _ caches.StringCommand = (*Provider)(nil)
 
+// Append implements caches.StringCommand.
+func (p *Provider) Append(ctx context.Context, key string, value string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.Append(ctx, key, value)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
 // Decr implements caches.StringCommand.
 func (p *Provider) Decr(ctx context.Context, key string) caches.Result[int64] {
 	key = p.prefix + key
@@ -30,7 +38,47 @@ func (p *Provider) DecrBy(ctx context.Context, key string, value int64) caches.R
 func (p *Provider) Get(ctx context.Context, key string) caches.Result[[]byte] {
 	key = p.prefix + key
 	res := p.db.Get(ctx, key)
-	return newResult(res.Bytes())
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
+}
+
+// GetDel implements caches.StringCommand.
+func (p *Provider) GetDel(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	res := p.db.GetDel(ctx, key)
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
+}
+
+// GetEx implements caches.StringCommand.
+func (p *Provider) GetEx(ctx context.Context, key string, args caches.GetExArgs) caches.Result[[]byte] {
+	key = p.prefix + key
+	cmdArgs := []any{"getex", key}
+	switch {
+	case args.TTL > 0:
+		cmdArgs = append(cmdArgs, "px", formatMs(args.TTL))
+	case !args.ExpireAt.IsZero():
+		cmdArgs = append(cmdArgs, "pxat", args.ExpireAt.UnixMilli())
+	case args.Persist:
+		cmdArgs = append(cmdArgs, "persist")
+	}
+
+	res := rds.NewStringCmd(ctx, cmdArgs...)
+	_ = p.db.(processor).Process(ctx, res)
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
+}
+
+// GetRange implements caches.StringCommand.
+func (p *Provider) GetRange(ctx context.Context, key string, start, end int64) caches.Result[[]byte] {
+	key = p.prefix + key
+	res := p.db.GetRange(ctx, key, start, end)
+	return newResultFunc(p, func() ([]byte, error) {
+		return res.Bytes()
+	})
 }
 
 // Incr implements caches.StringCommand.
@@ -57,6 +105,37 @@ func (p *Provider) IncrByFloat(ctx context.Context, key string, value float64) c
 	return res
 }
 
+// LCS implements caches.StringCommand.
+func (p *Provider) LCS(ctx context.Context, q *caches.LCSQuery) caches.Result[*caches.LCSMatch] {
+	res := p.db.LCS(ctx, &rds.LCSQuery{
+		Key1:         p.prefix + q.Key1,
+		Key2:         p.prefix + q.Key2,
+		Len:          q.Len,
+		Idx:          q.Idx,
+		MinMatchLen:  q.MinMatchLen,
+		WithMatchLen: q.WithMatchLen,
+	})
+	return newResultFunc(p, func() (*caches.LCSMatch, error) {
+		val, err := res.Result()
+		if err != nil {
+			return nil, err
+		}
+
+		match := &caches.LCSMatch{MatchString: val.MatchString, Len: val.Len}
+		if q.Idx {
+			match.Matches = make([]caches.LCSMatchedPosition, len(val.Matches))
+			for i, m := range val.Matches {
+				match.Matches[i] = caches.LCSMatchedPosition{
+					Key1:     caches.LCSPosition{Start: m.Key1.Start, End: m.Key1.End},
+					Key2:     caches.LCSPosition{Start: m.Key2.Start, End: m.Key2.End},
+					MatchLen: m.MatchLen,
+				}
+			}
+		}
+		return match, nil
+	})
+}
+
 // Set implements caches.StringCommand.
 func (p *Provider) Set(ctx context.Context, key string, value any, expiration time.Duration) caches.StatusResult {
 	key = p.prefix + key
@@ -87,6 +166,14 @@ func (p *Provider) SetNX(ctx context.Context, key string, value any, expiration
 	return res
 }
 
+// SetRange implements caches.StringCommand.
+func (p *Provider) SetRange(ctx context.Context, key string, offset int64, value string) caches.Result[int64] {
+	key = p.prefix + key
+	res := p.db.SetRange(ctx, key, offset, value)
+	res.SetErr(formatError(res.Err()))
+	return res
+}
+
 // SetXX implements caches.StringCommand.
 func (p *Provider) SetXX(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
 	key = p.prefix + key
@@ -107,26 +194,27 @@ func (p *Provider) StrLen(ctx context.Context, key string) caches.Result[int64]
 func (p *Provider) MGet(ctx context.Context, keys ...string) caches.Result[map[string][]byte] {
 	keys = prefixKeys(p.prefix, keys)
 	res := p.db.MGet(ctx, keys...)
-
-	result := make(map[string][]byte)
-	prefixLen := len(p.prefix)
-
-	for i, val := range res.Val() {
-		if val != nil {
-			// 去除前缀，返回原始键名
-			originalKey := keys[i]
-			if prefixLen > 0 && len(keys[i]) > prefixLen {
-				originalKey = keys[i][prefixLen:]
-			}
-
-			// 将值转换为字节数组
-			if strVal, ok := val.(string); ok {
-				result[originalKey] = []byte(strVal)
+	return newResultFunc(p, func() (map[string][]byte, error) {
+		result := make(map[string][]byte)
+		prefixLen := len(p.prefix)
+
+		for i, val := range res.Val() {
+			if val != nil {
+				// 去除前缀，返回原始键名
+				originalKey := keys[i]
+				if prefixLen > 0 && len(keys[i]) > prefixLen {
+					originalKey = keys[i][prefixLen:]
+				}
+
+				// 将值转换为字节数组
+				if strVal, ok := val.(string); ok {
+					result[originalKey] = []byte(strVal)
+				}
 			}
 		}
-	}
 
-	return newResult(result, formatError(res.Err()))
+		return result, res.Err()
+	})
 }
 
 // MSet implements caches.StringCommand.
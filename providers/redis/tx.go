@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+
+	rds "github.com/redis/go-redis/v9"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.TxCommand = (*Provider)(nil)
+
+// watchTx implements caches.Tx on top of rds.Tx.
+// Commands called on tx directly are sent on the watching connection.
+type watchTx struct {
+	*Provider
+	tx *rds.Tx
+}
+
+var _ caches.Tx = (*watchTx)(nil)
+
+// TxPipeline implements caches.TxCommand.
+func (p *Provider) TxPipeline() caches.TxPipeliner {
+	return p.newPipeline(p.client.TxPipeline())
+}
+
+// TxPipelined implements caches.TxCommand.
+func (p *Provider) TxPipelined(ctx context.Context, fn func(pipe caches.TxPipeliner) error) error {
+	return txPipelined(ctx, p.TxPipeline(), fn)
+}
+
+// Watch implements caches.TxCommand.
+func (p *Provider) Watch(ctx context.Context, fn func(tx caches.Tx) error, keys ...string) error {
+	keys = prefixKeys(p.prefix, keys)
+	err := p.client.Watch(ctx, func(rtx *rds.Tx) error {
+		return fn(&watchTx{
+			Provider: &Provider{
+				client: p.client,
+				db:     rtx,
+				prefix: p.prefix,
+			},
+			tx: rtx,
+		})
+	}, keys...)
+	return formatError(err)
+}
+
+// TxPipeline implements caches.Tx.
+func (t *watchTx) TxPipeline() caches.TxPipeliner {
+	return t.newPipeline(t.tx.TxPipeline())
+}
+
+// TxPipelined implements caches.Tx.
+func (t *watchTx) TxPipelined(ctx context.Context, fn func(pipe caches.TxPipeliner) error) error {
+	return txPipelined(ctx, t.TxPipeline(), fn)
+}
+
+func txPipelined(ctx context.Context, pipe caches.TxPipeliner, fn func(pipe caches.TxPipeliner) error) error {
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	return pipe.Exec(ctx)
+}
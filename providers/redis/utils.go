@@ -1,33 +1,48 @@
 package redis
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	rds "github.com/redis/go-redis/v9"
 	"github.com/rockcookies/go-caches"
 )
 
-// newResult creates a new BaseResult with Redis-specific error handling.
-// It converts Redis nil responses to caches.Nil for consistency.
-func newResult[T any](result T, err error) caches.Result[T] {
-	if err == rds.Nil {
-		err = caches.Nil
-	}
-
-	return caches.NewResult(result, err)
+// newResultFunc creates a new Result whose value is computed by fn with Redis-specific error handling.
+// Queued pipeline commands only have a reply after Exec, so fn is deferred until then.
+func newResultFunc[T any](p *Provider, fn func() (T, error)) caches.Result[T] {
+	var zero T
+	res := caches.NewResult(zero, nil)
+	p.resolve(func() {
+		val, err := fn()
+		res.SetVal(val)
+		res.SetErr(formatError(err))
+	})
+	return res
 }
 
-// newStatusResult creates a new statusResult with Redis-specific error handling.
-// It converts Redis nil responses to caches.Nil for consistency.
-func newStatusResult(val []byte, err error) caches.StatusResult {
-	if err == rds.Nil {
-		err = caches.Nil
-	}
-
-	return caches.NewStatusResult(val, err)
+// newStatusResultFunc creates a new StatusResult whose value is computed by fn with Redis-specific error handling.
+// Queued pipeline commands only have a reply after Exec, so fn is deferred until then.
+func newStatusResultFunc(p *Provider, fn func() ([]byte, error)) caches.StatusResult {
+	res := caches.NewStatusResult(nil, nil)
+	p.resolve(func() {
+		val, err := fn()
+		res.SetVal(string(val))
+		res.SetErr(formatError(err))
+	})
+	return res
 }
 
 func formatError(err error) error {
-	if err == rds.Nil {
+	switch err {
+	case rds.Nil:
 		return caches.Nil
+	case rds.TxFailedErr:
+		return caches.ErrTxFailed
+	}
+	if rds.HasErrorPrefix(err, "NOSCRIPT") {
+		return caches.ErrNoScript
 	}
 	return err
 }
@@ -44,3 +59,69 @@ func prefixKeys(prefix string, keys []string) []string {
 
 	return prefixed
 }
+
+// scanBatchSize is the COUNT hint of the SCAN calls used to find keys by prefix.
+const scanBatchSize = 1000
+
+// matchPrefix returns a SCAN pattern matching the keys starting with prefix.
+func matchPrefix(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('*')
+	return b.String()
+}
+
+// scanPrefix calls fn with each batch of keys starting with prefix.
+// On a cluster every master is scanned, concurrently, with fn given the client of the node.
+func (p *Provider) scanPrefix(ctx context.Context, prefix string, fn func(ctx context.Context, c rds.Cmdable, keys []string) error) error {
+	match := matchPrefix(prefix)
+	scan := func(ctx context.Context, c rds.Cmdable) error {
+		var cursor uint64
+		for {
+			keys, next, err := c.Scan(ctx, cursor, match, scanBatchSize).Result()
+			if err != nil {
+				return err
+			}
+
+			if len(keys) > 0 {
+				if err := fn(ctx, c, keys); err != nil {
+					return err
+				}
+			}
+
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	}
+
+	if cluster, ok := p.client.(*rds.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, c *rds.Client) error {
+			return scan(ctx, c)
+		})
+	}
+	return scan(ctx, p.client)
+}
+
+func formatMs(dur time.Duration) int64 {
+	if dur > 0 && dur < time.Millisecond {
+		return 1
+	}
+	return int64(dur / time.Millisecond)
+}
+
+// runScript runs script with EVALSHA, falling back to EVAL when the script is not loaded.
+// Queued pipeline commands have no reply before Exec, so EVAL is used for them.
+func (p *Provider) runScript(ctx context.Context, script *rds.Script, keys []string, args ...any) *rds.Cmd {
+	if p.pending != nil {
+		return script.Eval(ctx, p.db, keys, args...)
+	}
+	return script.Run(ctx, p.db, keys, args...)
+}
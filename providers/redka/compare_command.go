@@ -0,0 +1,84 @@
+package redka
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.CompareCommand = (*Provider)(nil)
+
+// compareString reports whether key holds value.
+func compareString(tx *rdk.Tx, key string, value any) (bool, error) {
+	want, err := toBytes(value)
+	if err != nil {
+		return false, err
+	}
+
+	val, err := tx.Str().Get(key)
+	if err == rdk.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return bytes.Equal(val.Bytes(), want), nil
+}
+
+// CompareAndDelete implements caches.CompareCommand.
+func (p *Provider) CompareAndDelete(ctx context.Context, key string, value any) caches.Result[bool] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (bool, error) {
+		ok, err := compareString(tx, key, value)
+		if !ok {
+			return false, err
+		}
+
+		n, err := tx.Key().Delete(key)
+		return n > 0, err
+	})
+	return newResult(val, err)
+}
+
+// CompareAndExpire implements caches.CompareCommand.
+func (p *Provider) CompareAndExpire(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
+	key = p.prefix + key
+	ms := formatMs(expiration)
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (bool, error) {
+		ok, err := compareString(tx, key, value)
+		if !ok {
+			return false, err
+		}
+
+		err = tx.Key().ExpireAt(key, time.Now().Add(time.Duration(ms)*time.Millisecond))
+		return err == nil, err
+	})
+	return newResult(val, err)
+}
+
+// AcquireWithToken implements caches.CompareCommand.
+func (p *Provider) AcquireWithToken(ctx context.Context, key string, value any, expiration time.Duration, tokenKey string) caches.Result[int64] {
+	key, tokenKey = p.prefix+key, p.prefix+tokenKey
+	ms := formatMs(expiration)
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		exists, err := tx.Key().Exists(key)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			return 0, caches.Nil
+		}
+
+		token, err := tx.Str().Incr(tokenKey, 1)
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Str().SetExpire(key, value, time.Duration(ms)*time.Millisecond); err != nil {
+			return 0, err
+		}
+		return int64(token), nil
+	})
+	return newResult(val, err)
+}
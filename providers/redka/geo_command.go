@@ -0,0 +1,240 @@
+package redka
+
+import (
+	"context"
+	"errors"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/geo"
+)
+
+var errGeoAddLocations = errors.New("redka: GEOADD requires at least one location")
+
+var _ caches.GeoCommand = (*Provider)(nil)
+
+// checkZSet returns whether the sorted set stored at key exists.
+// Unlike the ZSet reads, it returns rdk.ErrKeyType when the key holds another type.
+func checkZSet(tx *rdk.Tx, key string) (bool, error) {
+	k, err := tx.Key().Get(key)
+	if err != nil && err != rdk.ErrNotFound {
+		return false, err
+	}
+	if k.Exists() && k.Type != rdk.TypeZSet {
+		return false, rdk.ErrKeyType
+	}
+	return k.Exists(), nil
+}
+
+// geoScores returns the scores of members in the sorted set stored at key, and whether each member exists.
+func geoScores(tx *rdk.Tx, key string, members ...string) ([]float64, []bool, error) {
+	scores := make([]float64, len(members))
+	found := make([]bool, len(members))
+	exists, err := checkZSet(tx, key)
+	if err != nil || !exists {
+		return scores, found, err
+	}
+
+	for i, member := range members {
+		score, err := tx.ZSet().GetScore(key, member)
+		if err == rdk.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		scores[i], found[i] = score, true
+	}
+	return scores, found, nil
+}
+
+// geoSearch returns the points of the sorted set stored at key within the shape of q.
+func geoSearch(tx *rdk.Tx, key string, q *caches.GeoSearchQuery) ([]geo.Point, error) {
+	exists, err := checkZSet(tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		// 键不存在时仍需校验查询参数
+		return geo.Search(nil, 0, 0, q)
+	}
+
+	lon, lat := q.Longitude, q.Latitude
+	if q.Member != "" {
+		score, err := tx.ZSet().GetScore(key, q.Member)
+		if err == rdk.ErrNotFound {
+			return nil, geo.ErrMember
+		} else if err != nil {
+			return nil, err
+		}
+		lon, lat = geo.Decode(score)
+	} else if err := geo.CheckCoord(lon, lat); err != nil {
+		return nil, err
+	}
+
+	size, err := tx.ZSet().Len(key)
+	if err != nil {
+		return nil, err
+	}
+	items, err := tx.ZSet().Range(key, 0, size-1)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]geo.Point, len(items))
+	for i, item := range items {
+		points[i] = geo.NewPoint(item.Elem.String(), item.Score)
+	}
+	return geo.Search(points, lon, lat, q)
+}
+
+// GeoAdd implements caches.GeoCommand.
+func (p *Provider) GeoAdd(ctx context.Context, key string, geoLocation ...*caches.GeoLocation) caches.Result[int64] {
+	if len(geoLocation) == 0 {
+		return newResult(int64(0), errGeoAddLocations)
+	}
+
+	items := make(map[any]float64, len(geoLocation))
+	for _, loc := range geoLocation {
+		score, err := geo.Score(loc.Longitude, loc.Latitude)
+		if err != nil {
+			return newResult(int64(0), err)
+		}
+		items[loc.Name] = score
+	}
+
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		count, err := tx.ZSet().AddMany(key, items)
+		return int64(count), err
+	})
+	return newResult(n, err)
+}
+
+// GeoPos implements caches.GeoCommand.
+func (p *Provider) GeoPos(ctx context.Context, key string, members ...string) caches.Result[[]*caches.GeoPos] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]*caches.GeoPos, error) {
+		scores, found, err := geoScores(tx, key, members...)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]*caches.GeoPos, len(members))
+		for i, score := range scores {
+			if found[i] {
+				lon, lat := geo.Decode(score)
+				result[i] = &caches.GeoPos{Longitude: lon, Latitude: lat}
+			}
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// GeoDist implements caches.GeoCommand.
+func (p *Provider) GeoDist(ctx context.Context, key string, member1, member2, unit string) caches.Result[float64] {
+	conv, err := geo.Unit(unit)
+	if err != nil {
+		return newResult(float64(0), err)
+	}
+
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (float64, error) {
+		scores, found, err := geoScores(tx, key, member1, member2)
+		if err != nil {
+			return 0, err
+		}
+		if !found[0] || !found[1] {
+			return 0, caches.Nil
+		}
+
+		lon1, lat1 := geo.Decode(scores[0])
+		lon2, lat2 := geo.Decode(scores[1])
+		return geo.Round(geo.Distance(lon1, lat1, lon2, lat2) / conv), nil
+	})
+	return newResult(val, err)
+}
+
+// GeoHash implements caches.GeoCommand.
+func (p *Provider) GeoHash(ctx context.Context, key string, members ...string) caches.Result[[]string] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]string, error) {
+		scores, found, err := geoScores(tx, key, members...)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, len(members))
+		for i, score := range scores {
+			if found[i] {
+				result[i] = geo.Hash(score)
+			}
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// GeoSearch implements caches.GeoCommand.
+func (p *Provider) GeoSearch(ctx context.Context, key string, q *caches.GeoSearchQuery) caches.Result[[]string] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]string, error) {
+		points, err := geoSearch(tx, key, q)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, len(points))
+		for i, pt := range points {
+			result[i] = pt.Member
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// GeoSearchLocation implements caches.GeoCommand.
+func (p *Provider) GeoSearchLocation(ctx context.Context, key string, q *caches.GeoSearchLocationQuery) caches.Result[[]caches.GeoLocation] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]caches.GeoLocation, error) {
+		points, err := geoSearch(tx, key, &q.GeoSearchQuery)
+		if err != nil {
+			return nil, err
+		}
+		return geo.Locations(points, q), nil
+	})
+	return newResult(val, err)
+}
+
+// GeoSearchStore implements caches.GeoCommand.
+func (p *Provider) GeoSearchStore(ctx context.Context, key, store string, q *caches.GeoSearchStoreQuery) caches.Result[int64] {
+	key = p.prefix + key
+	store = p.prefix + store
+	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		points, err := geoSearch(tx, key, &q.GeoSearchQuery)
+		if err != nil {
+			return 0, err
+		}
+
+		items := make(map[any]float64, len(points))
+		for _, pt := range points {
+			if q.StoreDist {
+				items[pt.Member] = pt.Dist
+			} else {
+				items[pt.Member] = pt.Score
+			}
+		}
+
+		// 目标键被覆盖, 无论其类型; 结果为空时删除目标键
+		if _, err := tx.Key().Delete(store); err != nil {
+			return 0, err
+		}
+		if len(items) > 0 {
+			if _, err := tx.ZSet().AddMany(store, items); err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(items)), nil
+	})
+	return newResult(n, err)
+}
@@ -2,18 +2,251 @@ package redka
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	rdk "github.com/nalgeon/redka"
 	"github.com/rockcookies/go-caches"
 )
 
+// hashExpiresPrefix is the prefix of the keys holding the expiration times of hash fields.
+// Redka has no field expiration: the times of the fields of a hash are stored in a separate hash,
+// named after the ID of the hash key so that it follows renames, and updated in the same transaction
+// as the fields. It is deleted with the last expiring field, and by hashExpiresSchema with the hash.
+const hashExpiresPrefix = "__caches:hexpire:"
+
+// hashExpiresSchema deletes the expiration times of the fields of a hash along with the hash,
+// whichever command or connection deletes it. It is created in Options.DB, which field expiration
+// requires: without it, the times would be left behind outside the prefix of the provider.
+const hashExpiresSchema = `
+create trigger if not exists caches_hexpire_on_delete
+after delete on rkey
+for each row when old.type = 4
+begin
+	delete from rkey where key = '` + hashExpiresPrefix + `' || old.id;
+end;`
+
+var (
+	errHSetExMode   = errors.New("redka: HSETEX mode must be FNX or FXX")
+	errHashFieldTTL = errors.New("redka: hash field expiration requires Options.DB")
+)
+
 var _ caches.HashCommand = (*Provider)(nil)
 
+// hashExpiresKey returns the key holding the expiration times of the fields of the hash with key ID kid.
+func hashExpiresKey(kid int) string {
+	return hashExpiresPrefix + strconv.Itoa(kid)
+}
+
+// isInternalKey reports whether key is used by the provider itself rather than a caller.
+func isInternalKey(key string) bool {
+	return strings.HasPrefix(key, hashExpiresPrefix)
+}
+
+// hashExpires maps the fields of a hash having an expiration to their expiration time in unix milliseconds.
+type hashExpires map[string]int64
+
+// expired reports whether field expired at now.
+func (e hashExpires) expired(field string, now int64) bool {
+	at, ok := e[field]
+	return ok && at <= now
+}
+
+// countExpired returns the number of fields expired at now.
+func (e hashExpires) countExpired(now int64) int {
+	var n int
+	for _, at := range e {
+		if at <= now {
+			n++
+		}
+	}
+	return n
+}
+
+// getHashKey returns the hash key stored at key, or a zero key when it does not exist or is not a hash.
+func getHashKey(tx *rdk.Tx, key string) (rdk.Key, error) {
+	k, err := tx.Key().Get(key)
+	if err == rdk.ErrNotFound || err == nil && k.Type != rdk.TypeHash {
+		return rdk.Key{}, nil
+	}
+	return k, err
+}
+
+// getHashExpires returns the expiration times of the fields of the hash k.
+func getHashExpires(tx *rdk.Tx, k rdk.Key) (hashExpires, error) {
+	exp := hashExpires{}
+	if !k.Exists() {
+		return exp, nil
+	}
+
+	items, err := tx.Hash().Items(hashExpiresKey(k.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	for field, v := range items {
+		at, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		exp[field] = at
+	}
+	return exp, nil
+}
+
+// getHashKeyExpires returns the hash key stored at key and the expiration times of its fields.
+func getHashKeyExpires(tx *rdk.Tx, key string) (rdk.Key, hashExpires, error) {
+	k, err := getHashKey(tx, key)
+	if err != nil {
+		return k, nil, err
+	}
+
+	exp, err := getHashExpires(tx, k)
+	return k, exp, err
+}
+
+// setHashExpires replaces the expiration times of the fields of the hash with key ID kid.
+func setHashExpires(tx *rdk.Tx, kid int, exp hashExpires) error {
+	key := hashExpiresKey(kid)
+	if _, err := tx.Key().Delete(key); err != nil || len(exp) == 0 {
+		return err
+	}
+
+	items := make(map[string]any, len(exp))
+	for field, at := range exp {
+		items[field] = strconv.FormatInt(at, 10)
+	}
+	_, err := tx.Hash().SetMany(key, items)
+	return err
+}
+
+// touchHash increments the version of the hash k, whose fields expiration times changed,
+// so that transactions watching it fail.
+func touchHash(tx *rdk.Tx, k rdk.Key) error {
+	// Redka increments the version when updating the expiration time of the key
+	if k.ETime != nil {
+		return tx.Key().ExpireAt(k.Key, time.UnixMilli(*k.ETime))
+	}
+	return tx.Key().Persist(k.Key)
+}
+
+// liveHash reports whether the key k is not a hash whose fields all expired at now.
+func liveHash(tx *rdk.Tx, k rdk.Key, now int64) (bool, error) {
+	if k.Type != rdk.TypeHash {
+		return true, nil
+	}
+
+	exp, err := getHashExpires(tx, k)
+	if err != nil || len(exp) == 0 {
+		return true, err
+	}
+
+	size, err := tx.Hash().Len(k.Key)
+	return size > exp.countExpired(now), err
+}
+
+// deleteHashFields deletes fields from the hash stored at key, and the key with its last field.
+func deleteHashFields(tx *rdk.Tx, key string, fields ...string) error {
+	n, err := tx.Hash().Delete(key, fields...)
+	if err != nil || n == 0 {
+		return err
+	}
+
+	// Redka keeps empty hashes
+	size, err := tx.Hash().Len(key)
+	if err != nil || size > 0 {
+		return err
+	}
+	_, err = tx.Key().Delete(key)
+	return err
+}
+
+// purgeHash deletes the expired fields of the hash stored at key, and the key with its last field.
+// Returns the hash key, zero when it does not exist, and the expiration times of the remaining fields.
+func purgeHash(tx *rdk.Tx, key string, now int64) (rdk.Key, hashExpires, error) {
+	k, exp, err := getHashKeyExpires(tx, key)
+	if err != nil {
+		return k, nil, err
+	}
+
+	var expired []string
+	for field := range exp {
+		if exp.expired(field, now) {
+			expired = append(expired, field)
+			delete(exp, field)
+		}
+	}
+	if len(expired) == 0 {
+		return k, exp, nil
+	}
+
+	if err := setHashExpires(tx, k.ID, exp); err != nil {
+		return k, nil, err
+	}
+	if err := deleteHashFields(tx, key, expired...); err != nil {
+		return k, nil, err
+	}
+
+	if len(exp) == 0 {
+		// 最后一个字段过期后键已删除
+		if k, err = getHashKey(tx, key); err != nil {
+			return k, nil, err
+		}
+	}
+	return k, exp, nil
+}
+
+// hashFieldExists reports whether field is a live field of the hash stored at key.
+func hashFieldExists(tx *rdk.Tx, key, field string, exp hashExpires, now int64) (bool, error) {
+	if exp.expired(field, now) {
+		return false, nil
+	}
+	return tx.Hash().Exists(key, field)
+}
+
+// createdHash discards the expiration times left behind by a deleted hash
+// with the ID of the hash created at key.
+func createdHash(tx *rdk.Tx, key string) error {
+	k, err := getHashKey(tx, key)
+	if err != nil || !k.Exists() {
+		return err
+	}
+	_, err = tx.Key().Delete(hashExpiresKey(k.ID))
+	return err
+}
+
+// hset sets the values of fields, removing their expiration.
+func hset(tx *rdk.Tx, key string, values map[string]any) (int, error) {
+	k, exp, err := purgeHash(tx, key, time.Now().UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := tx.Hash().SetMany(key, values)
+	if err != nil {
+		return 0, err
+	}
+	if !k.Exists() {
+		return count, createdHash(tx, key)
+	}
+
+	n := len(exp)
+	for field := range values {
+		delete(exp, field)
+	}
+	if len(exp) != n {
+		return count, setHashExpires(tx, k.ID, exp)
+	}
+	return count, nil
+}
+
 // HSet implements caches.HashCommand.
 func (p *Provider) HSet(ctx context.Context, key string, values map[string]any) caches.Result[int64] {
 	key = p.prefix + key
 	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
-		count, e := tx.Hash().SetMany(key, values)
+		count, e := hset(tx, key, values)
 		return int64(count), e
 	})
 	return newResult(n, err)
@@ -27,6 +260,14 @@ func (p *Provider) HGet(ctx context.Context, key, field string) caches.Result[[]
 		if e != nil {
 			return nil, e
 		}
+
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return nil, e
+		}
+		if exp.expired(field, time.Now().UnixMilli()) {
+			return nil, rdk.ErrNotFound
+		}
 		return v.Bytes(), nil
 	})
 	return newResult(val, err)
@@ -41,9 +282,17 @@ func (p *Provider) HGetAll(ctx context.Context, key string) caches.Result[map[st
 			return nil, e
 		}
 
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return nil, e
+		}
+
+		now := time.Now().UnixMilli()
 		result := make(map[string][]byte, len(vals))
 		for k, v := range vals {
-			result[k] = v.Bytes()
+			if !exp.expired(k, now) {
+				result[k] = v.Bytes()
+			}
 		}
 		return result, nil
 	})
@@ -54,8 +303,33 @@ func (p *Provider) HGetAll(ctx context.Context, key string) caches.Result[map[st
 func (p *Provider) HDel(ctx context.Context, key string, fields ...string) caches.Result[int64] {
 	key = p.prefix + key
 	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
-		count, e := tx.Hash().Delete(key, fields...)
-		return int64(count), e
+		k, exp, e := purgeHash(tx, key, time.Now().UnixMilli())
+		if e != nil || !k.Exists() {
+			return 0, e
+		}
+
+		n := len(exp)
+		deleted := make([]string, 0, len(fields))
+		for _, field := range fields {
+			ok, e := tx.Hash().Exists(key, field)
+			if e != nil {
+				return 0, e
+			}
+			if ok {
+				deleted = append(deleted, field)
+				delete(exp, field)
+			}
+		}
+		if len(deleted) == 0 {
+			return 0, nil
+		}
+
+		if len(exp) != n {
+			if e := setHashExpires(tx, k.ID, exp); e != nil {
+				return 0, e
+			}
+		}
+		return int64(len(deleted)), deleteHashFields(tx, key, deleted...)
 	})
 	return newResult(n, err)
 }
@@ -64,16 +338,368 @@ func (p *Provider) HDel(ctx context.Context, key string, fields ...string) cache
 func (p *Provider) HExists(ctx context.Context, key, field string) caches.Result[bool] {
 	key = p.prefix + key
 	exists, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (bool, error) {
-		return tx.Hash().Exists(key, field)
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return false, e
+		}
+		return hashFieldExists(tx, key, field, exp, time.Now().UnixMilli())
 	})
 	return newResult(exists, err)
 }
 
+// hexpire sets the expiration time of fields to at, in unix milliseconds,
+// if the fields satisfy the condition of expType.
+func (p *Provider) hexpire(ctx context.Context, key string, at func(now int64) int64, expType expireType, fields []string) caches.Result[[]int64] {
+	if !p.hashTTL {
+		return newResult[[]int64](nil, errHashFieldTTL)
+	}
+
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]int64, error) {
+		now := time.Now().UnixMilli()
+		k, exp, e := purgeHash(tx, key, now)
+		if e != nil {
+			return nil, e
+		}
+
+		etime := at(now)
+		result := make([]int64, len(fields))
+		changed := false
+		var deleted []string
+		for i, field := range fields {
+			ok, e := hashFieldExists(tx, key, field, exp, now)
+			if e != nil {
+				return nil, e
+			}
+			if !ok {
+				result[i] = -2
+				continue
+			}
+
+			cur, hasETime := exp[field]
+			switch {
+			case expType == expireNX && hasETime,
+				expType == expireXX && !hasETime,
+				// 没有过期时间的字段视为永不过期
+				expType == expireGT && (!hasETime || etime <= cur),
+				expType == expireLT && hasETime && etime >= cur:
+				result[i] = 0
+			case etime <= now:
+				deleted = append(deleted, field)
+				delete(exp, field)
+				result[i] = 2
+				changed = true
+			default:
+				exp[field] = etime
+				result[i] = 1
+				changed = true
+			}
+		}
+
+		// 条件不满足或字段不存在时不写入，避免改变键的版本
+		if !changed {
+			return result, nil
+		}
+
+		if e := setHashExpires(tx, k.ID, exp); e != nil {
+			return nil, e
+		}
+		if len(deleted) > 0 {
+			// 删除最后一个字段时一并删除键
+			return result, deleteHashFields(tx, key, deleted...)
+		}
+		return result, touchHash(tx, k)
+	})
+	return newResult(val, err)
+}
+
+// afterMs returns a function computing the expiration time after ms milliseconds.
+func afterMs(ms int64) func(now int64) int64 {
+	return func(now int64) int64 {
+		return now + ms
+	}
+}
+
+// HExpire implements caches.HashCommand.
+func (p *Provider) HExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatSec(expiration)*1000), expire, fields)
+}
+
+// HExpireNX implements caches.HashCommand.
+func (p *Provider) HExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatSec(expiration)*1000), expireNX, fields)
+}
+
+// HExpireXX implements caches.HashCommand.
+func (p *Provider) HExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatSec(expiration)*1000), expireXX, fields)
+}
+
+// HExpireGT implements caches.HashCommand.
+func (p *Provider) HExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatSec(expiration)*1000), expireGT, fields)
+}
+
+// HExpireLT implements caches.HashCommand.
+func (p *Provider) HExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatSec(expiration)*1000), expireLT, fields)
+}
+
+// HExpireAt implements caches.HashCommand.
+func (p *Provider) HExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) caches.Result[[]int64] {
+	etime := tm.Unix() * 1000
+	return p.hexpire(ctx, key, func(int64) int64 { return etime }, expire, fields)
+}
+
+// HPExpire implements caches.HashCommand.
+func (p *Provider) HPExpire(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatMs(expiration)), expire, fields)
+}
+
+// HPExpireNX implements caches.HashCommand.
+func (p *Provider) HPExpireNX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatMs(expiration)), expireNX, fields)
+}
+
+// HPExpireXX implements caches.HashCommand.
+func (p *Provider) HPExpireXX(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatMs(expiration)), expireXX, fields)
+}
+
+// HPExpireGT implements caches.HashCommand.
+func (p *Provider) HPExpireGT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatMs(expiration)), expireGT, fields)
+}
+
+// HPExpireLT implements caches.HashCommand.
+func (p *Provider) HPExpireLT(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[]int64] {
+	return p.hexpire(ctx, key, afterMs(formatMs(expiration)), expireLT, fields)
+}
+
+// HPExpireAt implements caches.HashCommand.
+func (p *Provider) HPExpireAt(ctx context.Context, key string, tm time.Time, fields ...string) caches.Result[[]int64] {
+	etime := tm.UnixMilli()
+	return p.hexpire(ctx, key, func(int64) int64 { return etime }, expire, fields)
+}
+
+// httl returns the remaining time to live of fields, converted by conv.
+func (p *Provider) httl(ctx context.Context, key string, fields []string, conv func(ttl time.Duration) time.Duration) caches.Result[[]time.Duration] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]time.Duration, error) {
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return nil, e
+		}
+
+		now := time.Now().UnixMilli()
+		result := make([]time.Duration, len(fields))
+		for i, field := range fields {
+			ok, e := hashFieldExists(tx, key, field, exp, now)
+			if e != nil {
+				return nil, e
+			}
+
+			at, hasETime := exp[field]
+			switch {
+			case !ok:
+				result[i] = -2
+			case !hasETime:
+				result[i] = -1
+			default:
+				result[i] = conv(time.Duration(at-now) * time.Millisecond)
+			}
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// HTTL implements caches.HashCommand.
+func (p *Provider) HTTL(ctx context.Context, key string, fields ...string) caches.Result[[]time.Duration] {
+	return p.httl(ctx, key, fields, func(ttl time.Duration) time.Duration {
+		// Redis rounds the remaining seconds up
+		return (ttl + time.Second - 1).Truncate(time.Second)
+	})
+}
+
+// HPTTL implements caches.HashCommand.
+func (p *Provider) HPTTL(ctx context.Context, key string, fields ...string) caches.Result[[]time.Duration] {
+	return p.httl(ctx, key, fields, func(ttl time.Duration) time.Duration {
+		return ttl
+	})
+}
+
+// HPersist implements caches.HashCommand.
+func (p *Provider) HPersist(ctx context.Context, key string, fields ...string) caches.Result[[]int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]int64, error) {
+		now := time.Now().UnixMilli()
+		k, exp, e := purgeHash(tx, key, now)
+		if e != nil {
+			return nil, e
+		}
+
+		result := make([]int64, len(fields))
+		persisted := false
+		for i, field := range fields {
+			ok, e := hashFieldExists(tx, key, field, exp, now)
+			if e != nil {
+				return nil, e
+			}
+
+			_, hasETime := exp[field]
+			switch {
+			case !ok:
+				result[i] = -2
+			case !hasETime:
+				result[i] = -1
+			default:
+				delete(exp, field)
+				result[i] = 1
+				persisted = true
+			}
+		}
+
+		if persisted {
+			if e := setHashExpires(tx, k.ID, exp); e != nil {
+				return nil, e
+			}
+			return result, touchHash(tx, k)
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// updateHashExpires applies expiration to the expiration times of fields, as HGetEx and HSetEx do:
+// a positive expiration sets them, 0 removes them and KeepTTL keeps them.
+// Returns whether exp changed.
+func updateHashExpires(exp hashExpires, fields []string, expiration time.Duration, now int64) bool {
+	if expiration < 0 {
+		return false
+	}
+
+	changed := false
+	for _, field := range fields {
+		if expiration > 0 {
+			exp[field] = now + formatMs(expiration)
+			changed = true
+		} else if _, ok := exp[field]; ok {
+			delete(exp, field)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// HGetEx implements caches.HashCommand.
+func (p *Provider) HGetEx(ctx context.Context, key string, expiration time.Duration, fields ...string) caches.Result[[][]byte] {
+	if expiration > 0 && !p.hashTTL {
+		return newResult[[][]byte](nil, errHashFieldTTL)
+	}
+
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([][]byte, error) {
+		now := time.Now().UnixMilli()
+		k, exp, e := purgeHash(tx, key, now)
+		if e != nil {
+			return nil, e
+		}
+
+		vals, e := tx.Hash().GetMany(key, fields...)
+		if e != nil {
+			return nil, e
+		}
+
+		result := make([][]byte, len(fields))
+		found := make([]string, 0, len(fields))
+		for i, field := range fields {
+			v, ok := vals[field]
+			if !ok {
+				continue
+			}
+			result[i] = v.Bytes()
+			found = append(found, field)
+		}
+
+		if updateHashExpires(exp, found, expiration, now) {
+			if e := setHashExpires(tx, k.ID, exp); e != nil {
+				return nil, e
+			}
+			return result, touchHash(tx, k)
+		}
+		return result, nil
+	})
+	return newResult(val, err)
+}
+
+// HSetEx implements caches.HashCommand.
+func (p *Provider) HSetEx(ctx context.Context, key string, mode string, expiration time.Duration, values map[string]any) caches.Result[bool] {
+	mode = strings.ToUpper(mode)
+	if mode != "" && mode != "FNX" && mode != "FXX" {
+		return newResult(false, errHSetExMode)
+	}
+	if expiration > 0 && !p.hashTTL {
+		return newResult(false, errHashFieldTTL)
+	}
+
+	key = p.prefix + key
+	set, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (bool, error) {
+		now := time.Now().UnixMilli()
+		k, exp, e := purgeHash(tx, key, now)
+		if e != nil {
+			return false, e
+		}
+
+		fields := make([]string, 0, len(values))
+		for field := range values {
+			fields = append(fields, field)
+		}
+		if mode != "" {
+			vals, e := tx.Hash().GetMany(key, fields...)
+			if e != nil {
+				return false, e
+			}
+			if mode == "FNX" && len(vals) > 0 || mode == "FXX" && len(vals) < len(fields) {
+				return false, nil
+			}
+		}
+
+		if _, e := tx.Hash().SetMany(key, values); e != nil {
+			return false, e
+		}
+		if !k.Exists() {
+			if e := createdHash(tx, key); e != nil {
+				return false, e
+			}
+			if k, e = getHashKey(tx, key); e != nil {
+				return false, e
+			}
+		}
+
+		if updateHashExpires(exp, fields, expiration, now) {
+			if e := setHashExpires(tx, k.ID, exp); e != nil {
+				return false, e
+			}
+		}
+		return true, nil
+	})
+	return newResult(set, err)
+}
+
 // HIncrBy implements caches.HashCommand.
 func (p *Provider) HIncrBy(ctx context.Context, key, field string, incr int64) caches.Result[int64] {
 	key = p.prefix + key
 	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		k, _, e := purgeHash(tx, key, time.Now().UnixMilli())
+		if e != nil {
+			return 0, e
+		}
+
 		val, e := tx.Hash().Incr(key, field, int(incr))
+		if e == nil && !k.Exists() {
+			e = createdHash(tx, key)
+		}
 		return int64(val), e
 	})
 	return newResult(n, err)
@@ -83,7 +709,16 @@ func (p *Provider) HIncrBy(ctx context.Context, key, field string, incr int64) c
 func (p *Provider) HIncrByFloat(ctx context.Context, key, field string, incr float64) caches.Result[float64] {
 	key = p.prefix + key
 	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (float64, error) {
-		return tx.Hash().IncrFloat(key, field, incr)
+		k, _, e := purgeHash(tx, key, time.Now().UnixMilli())
+		if e != nil {
+			return 0, e
+		}
+
+		val, e := tx.Hash().IncrFloat(key, field, incr)
+		if e == nil && !k.Exists() {
+			e = createdHash(tx, key)
+		}
+		return val, e
 	})
 	return newResult(n, err)
 }
@@ -92,7 +727,24 @@ func (p *Provider) HIncrByFloat(ctx context.Context, key, field string, incr flo
 func (p *Provider) HKeys(ctx context.Context, key string) caches.Result[[]string] {
 	key = p.prefix + key
 	keys, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]string, error) {
-		return tx.Hash().Fields(key)
+		fields, e := tx.Hash().Fields(key)
+		if e != nil {
+			return nil, e
+		}
+
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return nil, e
+		}
+
+		now := time.Now().UnixMilli()
+		result := make([]string, 0, len(fields))
+		for _, field := range fields {
+			if !exp.expired(field, now) {
+				result = append(result, field)
+			}
+		}
+		return result, nil
 	})
 	return newResult(keys, err)
 }
@@ -102,7 +754,17 @@ func (p *Provider) HLen(ctx context.Context, key string) caches.Result[int64] {
 	key = p.prefix + key
 	n, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
 		l, e := tx.Hash().Len(key)
-		return int64(l), e
+		if e != nil {
+			return 0, e
+		}
+
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return 0, e
+		}
+
+		// 不计入已过期的字段
+		return int64(l - exp.countExpired(time.Now().UnixMilli())), nil
 	})
 	return newResult(n, err)
 }
@@ -115,9 +777,18 @@ func (p *Provider) HMGet(ctx context.Context, key string, fields ...string) cach
 		if e != nil {
 			return nil, e
 		}
+
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return nil, e
+		}
+
+		now := time.Now().UnixMilli()
 		result := make(map[string][]byte, len(vals))
 		for field, val := range vals {
-			result[field] = val.Bytes()
+			if !exp.expired(field, now) {
+				result[field] = val.Bytes()
+			}
 		}
 		return result, nil
 	})
@@ -128,7 +799,7 @@ func (p *Provider) HMGet(ctx context.Context, key string, fields ...string) cach
 func (p *Provider) HMSet(ctx context.Context, key string, values map[string]any) caches.StatusResult {
 	key = p.prefix + key
 	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
-		_, e := tx.Hash().SetMany(key, values)
+		_, e := hset(tx, key, values)
 		if e != nil {
 			return nil, e
 		}
@@ -141,7 +812,16 @@ func (p *Provider) HMSet(ctx context.Context, key string, values map[string]any)
 func (p *Provider) HSetNX(ctx context.Context, key, field string, value any) caches.Result[bool] {
 	key = p.prefix + key
 	set, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (bool, error) {
-		return tx.Hash().SetNotExists(key, field, value)
+		k, _, e := purgeHash(tx, key, time.Now().UnixMilli())
+		if e != nil {
+			return false, e
+		}
+
+		set, e := tx.Hash().SetNotExists(key, field, value)
+		if e == nil && !k.Exists() {
+			e = createdHash(tx, key)
+		}
+		return set, e
 	})
 	return newResult(set, err)
 }
@@ -150,15 +830,34 @@ func (p *Provider) HSetNX(ctx context.Context, key, field string, value any) cac
 func (p *Provider) HVals(ctx context.Context, key string) caches.Result[[][]byte] {
 	key = p.prefix + key
 	vals, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([][]byte, error) {
-		values, e := tx.Hash().Values(key)
+		_, exp, e := getHashKeyExpires(tx, key)
 		if e != nil {
 			return nil, e
 		}
-		result := make([][]byte, len(values))
-		for i, v := range values {
-			result[i] = v.Bytes()
+
+		if len(exp) == 0 {
+			values, e := tx.Hash().Values(key)
+			if e != nil {
+				return nil, e
+			}
+			result := make([][]byte, len(values))
+			for i, v := range values {
+				result[i] = v.Bytes()
+			}
+			return result, nil
 		}
-		return result, nil
+
+		// Values 无法区分字段, 需要逐个字段过滤
+		now := time.Now().UnixMilli()
+		result := make([][]byte, 0)
+		scanner := tx.Hash().Scanner(key, "*", 0)
+		for scanner.Scan() {
+			item := scanner.Item()
+			if !exp.expired(item.Field, now) {
+				result = append(result, item.Value.Bytes())
+			}
+		}
+		return result, scanner.Err()
 	})
 	return newResult(vals, err)
 }
@@ -179,9 +878,17 @@ func (p *Provider) HScan(ctx context.Context, key string, cursor uint64, match s
 			return caches.HScanResult{}, e
 		}
 
+		_, exp, e := getHashKeyExpires(tx, key)
+		if e != nil {
+			return caches.HScanResult{}, e
+		}
+
+		now := time.Now().UnixMilli()
 		fields := make(map[string][]byte, len(scanRes.Items))
 		for _, item := range scanRes.Items {
-			fields[item.Field] = item.Value.Bytes()
+			if !exp.expired(item.Field, now) {
+				fields[item.Field] = item.Value.Bytes()
+			}
 		}
 
 		return caches.HScanResult{
@@ -0,0 +1,107 @@
+package redka
+
+import (
+	"context"
+	"errors"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/hll"
+)
+
+var errPFCountKeys = errors.New("redka: PFCOUNT requires at least one key")
+
+var _ caches.HyperLogLogCommand = (*Provider)(nil)
+
+// getHLL returns the HyperLogLog stored at key, empty if the key does not exist.
+func getHLL(tx *rdk.Tx, key string) (*hll.HLL, bool, error) {
+	cur, exists, err := getString(tx, key)
+	if err != nil || !exists {
+		return hll.New(), exists, err
+	}
+
+	h, err := hll.Parse(cur)
+	return h, true, err
+}
+
+// PFAdd implements caches.HyperLogLogCommand.
+func (p *Provider) PFAdd(ctx context.Context, key string, els ...any) caches.Result[int64] {
+	elems := make([][]byte, len(els))
+	for i, el := range els {
+		b, err := toBytes(el)
+		if err != nil {
+			return newResult(int64(0), err)
+		}
+		elems[i] = b
+	}
+
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		h, exists, err := getHLL(tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		changed := !exists
+		for _, elem := range elems {
+			if h.Add(elem) {
+				changed = true
+			}
+		}
+		if !changed {
+			return 0, nil
+		}
+		return 1, setString(tx, key, h.Bytes(), exists)
+	})
+
+	return newResult(val, err)
+}
+
+// PFCount implements caches.HyperLogLogCommand.
+func (p *Provider) PFCount(ctx context.Context, keys ...string) caches.Result[int64] {
+	if len(keys) == 0 {
+		return newResult(int64(0), errPFCountKeys)
+	}
+
+	keys = prefixKeys(p.prefix, keys)
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		union := hll.New()
+		for _, key := range keys {
+			h, _, err := getHLL(tx, key)
+			if err != nil {
+				return 0, err
+			}
+			union.Merge(h)
+		}
+		return union.Count(), nil
+	})
+
+	return newResult(val, err)
+}
+
+// PFMerge implements caches.HyperLogLogCommand.
+func (p *Provider) PFMerge(ctx context.Context, dest string, keys ...string) caches.StatusResult {
+	dest = p.prefix + dest
+	keys = prefixKeys(p.prefix, keys)
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
+		union, exists, err := getHLL(tx, dest)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			h, _, err := getHLL(tx, key)
+			if err != nil {
+				return nil, err
+			}
+			union.Merge(h)
+		}
+
+		if err := setString(tx, dest, union.Bytes(), exists); err != nil {
+			return nil, err
+		}
+		return []byte("OK"), nil
+	})
+
+	return newStatusResult(val, err)
+}
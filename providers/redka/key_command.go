@@ -2,6 +2,9 @@ package redka
 
 import (
 	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
 	"time"
 
 	rdk "github.com/nalgeon/redka"
@@ -18,12 +21,40 @@ const (
 	expireLT
 )
 
+// flushBatchSize is the number of keys deleted at once by FlushPrefix.
+const flushBatchSize = 500
+
 var _ caches.KeyCommand = (*Provider)(nil)
 
 // DBSize implements caches.KeyCommand.
 func (p *Provider) DBSize(ctx context.Context) caches.Result[int64] {
+	if p.prefix != "" {
+		return p.DBSizePrefix(ctx, "")
+	}
+
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int, error) {
+		n, err := tx.Key().Len()
+		if err != nil {
+			return 0, err
+		}
+
+		// 不计入保存哈希字段过期时间的键
+		internal, err := tx.Key().Keys(globPrefix(hashExpiresPrefix))
+		return n - len(internal), err
+	})
+	return newResult(int64(val), err)
+}
+
+// DBSizePrefix implements caches.KeyCommand.
+func (p *Provider) DBSizePrefix(ctx context.Context, prefix string) caches.Result[int64] {
+	pattern := globPrefix(p.prefix + prefix)
 	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int, error) {
-		return tx.Key().Len()
+		keys, err := tx.Key().Keys(pattern)
+		// 不计入保存哈希字段过期时间的键
+		keys = slices.DeleteFunc(keys, func(k rdk.Key) bool {
+			return isInternalKey(k.Key)
+		})
+		return len(keys), err
 	})
 	return newResult(int64(val), err)
 }
@@ -41,7 +72,36 @@ func (p *Provider) Del(ctx context.Context, keys ...string) caches.Result[int64]
 func (p *Provider) Exists(ctx context.Context, keys ...string) caches.Result[int64] {
 	keys = prefixKeys(p.prefix, keys)
 	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int, error) {
-		return tx.Key().Count(keys...)
+		n, err := tx.Key().Count(keys...)
+		if err != nil || n == 0 {
+			return n, err
+		}
+
+		// 所有字段都已过期的哈希视为不存在
+		now := time.Now().UnixMilli()
+		seen := make(map[string]bool, len(keys))
+		for _, key := range keys {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			k, err := tx.Key().Get(key)
+			if err == rdk.ErrNotFound {
+				continue
+			} else if err != nil {
+				return 0, err
+			}
+
+			live, err := liveHash(tx, k, now)
+			if err != nil {
+				return 0, err
+			}
+			if !live {
+				n--
+			}
+		}
+		return n, nil
 	})
 	return newResult(int64(val), err)
 }
@@ -241,6 +301,13 @@ func (p *Provider) PExpireTime(ctx context.Context, key string) caches.Result[ti
 
 // FlushAll implements caches.KeyCommand.
 func (p *Provider) FlushAll(ctx context.Context) caches.StatusResult {
+	if p.prefix != "" {
+		if err := p.FlushPrefix(ctx, "").Err(); err != nil {
+			return newStatusResult(nil, err)
+		}
+		return newStatusResult([]byte("OK"), nil)
+	}
+
 	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (res []byte, err error) {
 		// 直接执行 tx.Key().DeleteAll().Error() 会报错： SQL logic error: cannot VACUUM from within a transaction (1)
 		err = tx.Key().DeleteAll()
@@ -253,6 +320,41 @@ func (p *Provider) FlushAll(ctx context.Context) caches.StatusResult {
 	return newStatusResult(val, err)
 }
 
+// FlushPrefix implements caches.KeyCommand.
+func (p *Provider) FlushPrefix(ctx context.Context, prefix string) caches.Result[int64] {
+	pattern := globPrefix(p.prefix + prefix)
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int, error) {
+		keys, err := tx.Key().Keys(pattern)
+		if err != nil {
+			return 0, err
+		}
+
+		// 分批删除，避免超出 SQLite 的参数数量限制
+		var n int
+		for start := 0; start < len(keys); start += flushBatchSize {
+			end := min(start+flushBatchSize, len(keys))
+			names := make([]string, 0, end-start)
+			for _, key := range keys[start:end] {
+				// 保存哈希字段过期时间的键由触发器随哈希一起删除
+				if !isInternalKey(key.Key) {
+					names = append(names, key.Key)
+				}
+			}
+			if len(names) == 0 {
+				continue
+			}
+
+			deleted, err := tx.Key().Delete(names...)
+			if err != nil {
+				return 0, err
+			}
+			n += deleted
+		}
+		return n, nil
+	})
+	return newResult(int64(val), err)
+}
+
 // Keys implements caches.KeyCommand.
 func (p *Provider) Keys(ctx context.Context, pattern string) caches.Result[[]string] {
 	pattern = p.prefix + pattern
@@ -262,12 +364,17 @@ func (p *Provider) Keys(ctx context.Context, pattern string) caches.Result[[]str
 			res = make([]string, len(keys))
 			prefixLen := len(p.prefix)
 
-			for i, key := range keys {
+			res = res[:0]
+			for _, key := range keys {
+				if isInternalKey(key.Key) {
+					continue
+				}
+
 				// 去除前缀，返回原始键名
 				if prefixLen > 0 && len(key.Key) > prefixLen {
-					res[i] = key.Key[prefixLen:]
+					res = append(res, key.Key[prefixLen:])
 				} else {
-					res[i] = key.Key
+					res = append(res, key.Key)
 				}
 			}
 		}
@@ -388,6 +495,10 @@ func (p *Provider) Type(ctx context.Context, key string) caches.Result[string] {
 			return "", err
 		}
 
+		if live, err := liveHash(tx, keyInfo, time.Now().UnixMilli()); err != nil || !live {
+			return "none", err
+		}
+
 		// 将 TypeID 转换为字符串
 		var typeStr string
 		switch keyInfo.Type {
@@ -401,6 +512,8 @@ func (p *Provider) Type(ctx context.Context, key string) caches.Result[string] {
 			typeStr = "hash"
 		case rdk.TypeZSet: // ZSet
 			typeStr = "zset"
+		case typeStream: // Stream
+			typeStr = "stream"
 		default:
 			typeStr = "unknown"
 		}
@@ -423,19 +536,22 @@ func (p *Provider) RandomKey(ctx context.Context) caches.Result[string] {
 
 		// 去除前缀
 		key := keyInfo.Key
-		prefixLen := len(p.prefix)
+		if !isInternalKey(key) && strings.HasPrefix(key, p.prefix) {
+			return key[len(p.prefix):], nil
+		}
 
-		// 检查键是否匹配前缀
-		if prefixLen > 0 {
-			if len(key) >= prefixLen && key[:prefixLen] == p.prefix {
-				return key[prefixLen:], nil
-			}
-			// 键不匹配前缀，返回空（避免泄露其他应用的键）
+		// 内部键或不匹配前缀的键（避免泄露其他应用的键），改为从前缀下的键中随机选择
+		keys, err := tx.Key().Keys(p.prefix + "*")
+		if err != nil {
+			return "", err
+		}
+		keys = slices.DeleteFunc(keys, func(k rdk.Key) bool {
+			return isInternalKey(k.Key)
+		})
+		if len(keys) == 0 {
 			return "", rdk.ErrNotFound
 		}
-
-		// 无前缀，直接返回
-		return key, nil
+		return keys[rand.IntN(len(keys))].Key[len(p.prefix):], nil
 	})
 	return newResult(val, err)
 }
@@ -480,6 +596,11 @@ func (p *Provider) Scan(ctx context.Context, cursor uint64, match string, count
 			}
 
 			key := scanner.Key()
+			scanned++
+			if isInternalKey(key.Key) {
+				continue
+			}
+
 			// 去除前缀
 			prefixLen := len(p.prefix)
 			if prefixLen > 0 && len(key.Key) > prefixLen {
@@ -487,7 +608,6 @@ func (p *Provider) Scan(ctx context.Context, cursor uint64, match string, count
 			} else {
 				keys = append(keys, key.Key)
 			}
-			scanned++
 		}
 
 		// 检查扫描错误
@@ -2,13 +2,103 @@ package redka
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	rdk "github.com/nalgeon/redka"
 	"github.com/rockcookies/go-caches"
 )
 
+var errInvalidCount = errors.New("redka: count should be greater than 0")
+
 var _ caches.ListCommand = (*Provider)(nil)
 
+// listMove pops an element from the srcpos end of source and pushes it to the destpos end of destination.
+// The destination type is checked first, so that nothing is popped when the push would fail.
+func listMove(tx *rdk.Tx, source, destination string, srcpos, destpos caches.ListDirection) ([]byte, error) {
+	k, err := tx.Key().Get(destination)
+	if err != nil && err != rdk.ErrNotFound {
+		return nil, err
+	}
+	if k.Exists() && k.Type != rdk.TypeList {
+		return nil, rdk.ErrKeyType
+	}
+
+	var v rdk.Value
+	if srcpos == caches.ListRight {
+		v, err = tx.List().PopBack(source)
+	} else {
+		v, err = tx.List().PopFront(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if destpos == caches.ListRight {
+		_, err = tx.List().PushBack(destination, v.Bytes())
+	} else {
+		_, err = tx.List().PushFront(destination, v.Bytes())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.Bytes(), nil
+}
+
+// BLMove implements caches.ListCommand.
+func (p *Provider) BLMove(ctx context.Context, source, destination string, srcpos, destpos caches.ListDirection, timeout time.Duration) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	val, err := block(ctx, p.waiter, timeout, func() ([]byte, error) {
+		return updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
+			return listMove(tx, source, destination, srcpos, destpos)
+		})
+	})
+	return newResult(val, err)
+}
+
+// blockingPop pops an element from the first non-empty list among keys, waiting for one if needed.
+func (p *Provider) blockingPop(ctx context.Context, timeout time.Duration, keys []string, back bool) caches.Result[caches.ListPopResult] {
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := block(ctx, p.waiter, timeout, func() (caches.ListPopResult, error) {
+		return updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.ListPopResult, error) {
+			for i, key := range prefixed {
+				var v rdk.Value
+				var e error
+				if back {
+					v, e = tx.List().PopBack(key)
+				} else {
+					v, e = tx.List().PopFront(key)
+				}
+				if e == rdk.ErrNotFound {
+					continue
+				}
+				if e != nil {
+					return caches.ListPopResult{}, e
+				}
+				return caches.ListPopResult{Key: keys[i], Element: v.Bytes()}, nil
+			}
+			return caches.ListPopResult{}, rdk.ErrNotFound
+		})
+	})
+	return newResult(res, err)
+}
+
+// BLPop implements caches.ListCommand.
+func (p *Provider) BLPop(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ListPopResult] {
+	return p.blockingPop(ctx, timeout, keys, false)
+}
+
+// BRPop implements caches.ListCommand.
+func (p *Provider) BRPop(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ListPopResult] {
+	return p.blockingPop(ctx, timeout, keys, true)
+}
+
+// BRPopLPush implements caches.ListCommand.
+func (p *Provider) BRPopLPush(ctx context.Context, source, destination string, timeout time.Duration) caches.Result[[]byte] {
+	return p.BLMove(ctx, source, destination, caches.ListRight, caches.ListLeft, timeout)
+}
+
 // LIndex implements caches.ListCommand.
 func (p *Provider) LIndex(ctx context.Context, key string, index int64) caches.Result[[]byte] {
 	key = p.prefix + key
@@ -52,6 +142,52 @@ func (p *Provider) LLen(ctx context.Context, key string) caches.Result[int64] {
 	return newResult(n, err)
 }
 
+// LMove implements caches.ListCommand.
+func (p *Provider) LMove(ctx context.Context, source, destination string, srcpos, destpos caches.ListDirection) caches.Result[[]byte] {
+	source = p.prefix + source
+	destination = p.prefix + destination
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
+		return listMove(tx, source, destination, srcpos, destpos)
+	})
+	return newResult(val, err)
+}
+
+// LMPop implements caches.ListCommand.
+func (p *Provider) LMPop(ctx context.Context, direction caches.ListDirection, count int64, keys ...string) caches.Result[caches.ListMPopResult] {
+	if count <= 0 {
+		return newResult(caches.ListMPopResult{}, errInvalidCount)
+	}
+
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.ListMPopResult, error) {
+		for i, key := range prefixed {
+			var elements [][]byte
+			for int64(len(elements)) < count {
+				var v rdk.Value
+				var e error
+				if direction == caches.ListRight {
+					v, e = tx.List().PopBack(key)
+				} else {
+					v, e = tx.List().PopFront(key)
+				}
+				if e == rdk.ErrNotFound {
+					break
+				}
+				if e != nil {
+					return caches.ListMPopResult{}, e
+				}
+				elements = append(elements, v.Bytes())
+			}
+
+			if len(elements) > 0 {
+				return caches.ListMPopResult{Key: keys[i], Elements: elements}, nil
+			}
+		}
+		return caches.ListMPopResult{}, rdk.ErrNotFound
+	})
+	return newResult(res, err)
+}
+
 // LPop implements caches.ListCommand.
 func (p *Provider) LPop(ctx context.Context, key string) caches.Result[[]byte] {
 	key = p.prefix + key
@@ -85,6 +221,92 @@ func (p *Provider) LPopCount(ctx context.Context, key string, count int) caches.
 	return newResult(vals, err)
 }
 
+// listElements returns all the elements of the list stored at key, or nothing when key does not exist.
+func listElements(tx *rdk.Tx, key string) ([]rdk.Value, error) {
+	k, err := tx.Key().Get(key)
+	if err == rdk.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.Type != rdk.TypeList {
+		return nil, rdk.ErrKeyType
+	}
+	return tx.List().Range(key, 0, -1)
+}
+
+// listPositions returns the indexes of the elements equal to element, following LPOS semantics.
+// A zero count returns all matches.
+func listPositions(elems []rdk.Value, element string, rank, count, maxLen int64) []int64 {
+	if rank == 0 {
+		rank = 1
+	}
+
+	n := int64(len(elems))
+	if maxLen <= 0 || maxLen > n {
+		maxLen = n
+	}
+
+	// 跳过前 |rank|-1 个匹配项
+	skip := rank - 1
+	if rank < 0 {
+		skip = -rank - 1
+	}
+
+	result := []int64{}
+	for i := int64(0); i < maxLen; i++ {
+		idx := i
+		if rank < 0 {
+			idx = n - 1 - i
+		}
+		if elems[idx].String() != element {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		result = append(result, idx)
+		if count > 0 && int64(len(result)) == count {
+			break
+		}
+	}
+	return result
+}
+
+// LPos implements caches.ListCommand.
+func (p *Provider) LPos(ctx context.Context, key string, element string, args caches.LPosArgs) caches.Result[int64] {
+	key = p.prefix + key
+	pos, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		elems, e := listElements(tx, key)
+		if e != nil {
+			return 0, e
+		}
+
+		positions := listPositions(elems, element, args.Rank, 1, args.MaxLen)
+		if len(positions) == 0 {
+			return 0, rdk.ErrNotFound
+		}
+		return positions[0], nil
+	})
+	return newResult(pos, err)
+}
+
+// LPosCount implements caches.ListCommand.
+func (p *Provider) LPosCount(ctx context.Context, key string, element string, count int64, args caches.LPosArgs) caches.Result[[]int64] {
+	key = p.prefix + key
+	positions, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]int64, error) {
+		elems, e := listElements(tx, key)
+		if e != nil {
+			return nil, e
+		}
+		return listPositions(elems, element, args.Rank, count, args.MaxLen), nil
+	})
+	return newResult(positions, err)
+}
+
 // LPush implements caches.ListCommand.
 func (p *Provider) LPush(ctx context.Context, key string, elements ...any) caches.Result[int64] {
 	key = p.prefix + key
@@ -101,6 +323,42 @@ func (p *Provider) LPush(ctx context.Context, key string, elements ...any) cache
 	return newResult(n, err)
 }
 
+// pushX pushes elements to an existing list, and does nothing when key does not exist.
+func (p *Provider) pushX(ctx context.Context, key string, elements []any, back bool) caches.Result[int64] {
+	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		k, e := tx.Key().Get(key)
+		if e == rdk.ErrNotFound {
+			return 0, nil
+		}
+		if e != nil {
+			return 0, e
+		}
+		if k.Type != rdk.TypeList {
+			return 0, rdk.ErrKeyType
+		}
+
+		var length int
+		for _, elem := range elements {
+			if back {
+				length, e = tx.List().PushBack(key, elem)
+			} else {
+				length, e = tx.List().PushFront(key, elem)
+			}
+			if e != nil {
+				return 0, e
+			}
+		}
+		return int64(length), nil
+	})
+	return newResult(n, err)
+}
+
+// LPushX implements caches.ListCommand.
+func (p *Provider) LPushX(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	return p.pushX(ctx, key, elements, false)
+}
+
 // LRange implements caches.ListCommand.
 func (p *Provider) LRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
 	key = p.prefix + key
@@ -219,11 +477,13 @@ func (p *Provider) RPopLPush(ctx context.Context, source, destination string) ca
 	source = p.prefix + source
 	destination = p.prefix + destination
 	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
-		v, e := tx.List().PopBackPushFront(source, destination)
-		if e != nil {
-			return nil, e
-		}
-		return v.Bytes(), nil
+		return listMove(tx, source, destination, caches.ListRight, caches.ListLeft)
 	})
 	return newResult(val, err)
 }
+
+// RPushX implements caches.ListCommand.
+func (p *Provider) RPushX(ctx context.Context, key string, elements ...any) caches.Result[int64] {
+	key = p.prefix + key
+	return p.pushX(ctx, key, elements, true)
+}
@@ -0,0 +1,42 @@
+package redka
+
+import (
+	"context"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.PipelineCommand = (*Provider)(nil)
+
+// newPipeline returns a pipeline that applies the queued commands
+// within a single transaction, once check succeeds.
+func (p *Provider) newPipeline(check func(tx *rdk.Tx) error) caches.TxPipeliner {
+	return caches.NewPipeline(p.Capabilities(), func(ctx context.Context, run func(c caches.Cache)) error {
+		return p.db.UpdateContext(ctx, func(tx *rdk.Tx) error {
+			if check != nil {
+				if err := check(tx); err != nil {
+					return err
+				}
+			}
+			run(p.withTx(tx))
+			return nil
+		})
+	})
+}
+
+// Pipeline implements caches.PipelineCommand.
+//
+// Queued commands are applied within a single transaction on Exec.
+func (p *Provider) Pipeline() caches.Pipeliner {
+	return p.newPipeline(nil)
+}
+
+// Pipelined implements caches.PipelineCommand.
+func (p *Provider) Pipelined(ctx context.Context, fn func(pipe caches.Pipeliner) error) error {
+	pipe := p.Pipeline()
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	return pipe.Exec(ctx)
+}
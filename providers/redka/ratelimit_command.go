@@ -0,0 +1,184 @@
+package redka
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+	"strconv"
+	"time"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.RateLimitCommand = (*Provider)(nil)
+
+// denied returns the outcome of a denied request,
+// which can be retried after retryAfter unless it exceeds the limit.
+func denied(remaining int64, retryAfter time.Duration, exceeded bool) caches.RateLimit {
+	if exceeded {
+		retryAfter = -1
+	}
+	return caches.RateLimit{Remaining: max(remaining, 0), RetryAfter: retryAfter}
+}
+
+// getInt returns the integer stored at key, and false if the key does not exist.
+func getInt(tx *rdk.Tx, key string) (int64, bool, error) {
+	val, err := tx.Str().Get(key)
+	if err == rdk.ErrNotFound {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	n, err := strconv.ParseInt(val.String(), 10, 64)
+	if err != nil {
+		return 0, false, rdk.ErrValueType
+	}
+	return n, true, nil
+}
+
+// RateLimitFixedWindow implements caches.RateLimitCommand.
+func (p *Provider) RateLimitFixedWindow(ctx context.Context, key string, limit int64, window time.Duration, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	ms := formatMs(window)
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.RateLimit, error) {
+		count, _, err := getInt(tx, key)
+		if err != nil {
+			return caches.RateLimit{}, err
+		}
+
+		var etime *int64
+		if count > 0 {
+			k, err := tx.Key().Get(key)
+			if err != nil {
+				return caches.RateLimit{}, err
+			}
+			etime = k.ETime
+		}
+
+		if count+n > limit {
+			retryAfter := window
+			if etime != nil {
+				retryAfter = time.Until(time.UnixMilli(*etime))
+			}
+			return denied(limit-count, retryAfter, n > limit), nil
+		}
+
+		switch {
+		case n == 0:
+		case count == 0:
+			// 新窗口: 过期的键仍保留其过期时间, 需要重新设置
+			if err := tx.Str().SetExpire(key, strconv.FormatInt(n, 10), time.Duration(ms)*time.Millisecond); err != nil {
+				return caches.RateLimit{}, err
+			}
+		default:
+			// Incr 保留键的过期时间
+			if _, err := tx.Str().Incr(key, int(n)); err != nil {
+				return caches.RateLimit{}, err
+			}
+			if etime == nil {
+				if err := tx.Key().Expire(key, time.Duration(ms)*time.Millisecond); err != nil {
+					return caches.RateLimit{}, err
+				}
+			}
+		}
+		return caches.RateLimit{Allowed: true, Remaining: limit - count - n}, nil
+	})
+	return newResult(val, err)
+}
+
+// RateLimitSlidingLog implements caches.RateLimitCommand.
+//
+// Unlike Redis, the log is not a sorted set: Redka keeps expired keys until they are removed,
+// and adding members to an expired sorted set leaves it expired, so the requests logged after
+// an idle window would be lost and the limit would allow everything until the key is removed.
+// The log is a string of big-endian microsecond timestamps instead, set along with its
+// expiration like the fixed window counter, which resets the expiration of an expired key.
+func (p *Provider) RateLimitSlidingLog(ctx context.Context, key string, limit int64, window time.Duration, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	ms := formatMs(window)
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.RateLimit, error) {
+		val, err := tx.Str().Get(key)
+		if err != nil && err != rdk.ErrNotFound {
+			return caches.RateLimit{}, err
+		}
+		b := val.Bytes()
+		if len(b)%8 != 0 {
+			return caches.RateLimit{}, rdk.ErrValueType
+		}
+
+		// Requests logged at or before now - window are out of the window
+		now := time.Now().UnixMicro()
+		start := now - window.Microseconds()
+		times := make([]int64, 0, len(b)/8+int(max(n, 0)))
+		for i := 0; i < len(b); i += 8 {
+			if t := int64(binary.BigEndian.Uint64(b[i:])); t > start {
+				times = append(times, t)
+			}
+		}
+		slices.Sort(times)
+
+		count := int64(len(times))
+		if count+n > limit {
+			// The request fits once the oldest count + n - limit requests left the window
+			var retryAfter time.Duration
+			if n <= limit {
+				retryAfter = time.Duration(times[count+n-limit-1]-now)*time.Microsecond + window
+			}
+			return denied(limit-count, retryAfter, n > limit), nil
+		}
+
+		if n > 0 {
+			// The log expires once its last request left the window
+			out := make([]byte, 0, (count+n)*8)
+			for _, t := range times {
+				out = binary.BigEndian.AppendUint64(out, uint64(t))
+			}
+			for i := int64(0); i < n; i++ {
+				out = binary.BigEndian.AppendUint64(out, uint64(now))
+			}
+			if err := tx.Str().SetExpire(key, out, time.Duration(ms)*time.Millisecond); err != nil {
+				return caches.RateLimit{}, err
+			}
+		}
+		return caches.RateLimit{Allowed: true, Remaining: limit - count - n}, nil
+	})
+	return newResult(val, err)
+}
+
+// RateLimitGCRA implements caches.RateLimitCommand.
+func (p *Provider) RateLimitGCRA(ctx context.Context, key string, limit int64, period time.Duration, burst, n int64) caches.Result[caches.RateLimit] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.RateLimit, error) {
+		stored, ok, err := getInt(tx, key)
+		if err != nil {
+			return caches.RateLimit{}, err
+		}
+
+		// Times are in microseconds, tat being the theoretical arrival time of the next request
+		interval := max(period.Microseconds()/limit, 1)
+		offset := interval * burst
+		now := time.Now().UnixMicro()
+		tat := now
+		if ok {
+			tat = max(stored, now)
+		}
+
+		newTat := tat + interval*n
+		diff := now - (newTat - offset)
+		if diff < 0 {
+			return denied((now-tat+offset)/interval, time.Duration(-diff)*time.Microsecond, n > burst), nil
+		}
+
+		if n > 0 {
+			// The key expires once the theoretical arrival time is reached, rounded up to the millisecond
+			ttl := (time.Duration(newTat-now)*time.Microsecond + time.Millisecond - 1).Truncate(time.Millisecond)
+			if err := tx.Str().SetExpire(key, strconv.FormatInt(newTat, 10), ttl); err != nil {
+				return caches.RateLimit{}, err
+			}
+		}
+		return caches.RateLimit{Allowed: true, Remaining: diff / interval}, nil
+	})
+	return newResult(val, err)
+}
@@ -1,18 +1,116 @@
 package redka
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"strings"
+	"time"
 
 	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
 )
 
 type Options struct {
 	Prefix string
+
+	// DB is the read-write SQL database Redka was opened with, see rdk.OpenDB.
+	// It is required by the features Redka lacks: streams are emulated with
+	// tables created in this database, and the sweeper removes expired keys from it.
+	// Stream commands and hash field expiration fail when it is not set: a trigger
+	// created in it deletes the expiration times of hash fields along with their hash.
+	//
+	// Redka deletes values along with their keys through foreign keys, which SQLite
+	// enables per connection. When DB is set, the provider enables them before each update,
+	// and NewWithOptions panics if they cannot be enabled. Enabling them in the connection
+	// string as well, e.g. with `_foreign_keys=1` for mattn/go-sqlite3, also covers
+	// the expired keys Redka removes in the background.
+	DB *sql.DB
+
+	// SweepInterval enables a background sweeper removing expired keys every SweepInterval.
+	// Redka only removes expired keys lazily, so DBSize counts them until then.
+	// The sweeper requires DB and is stopped by Provider.Close.
+	SweepInterval time.Duration
+
+	// SweepBatchSize is the maximum number of keys removed at once, 100 by default.
+	SweepBatchSize int
+
+	// SweepMaxRuntime bounds the time spent removing batches of keys per sweep, 50ms by default.
+	SweepMaxRuntime time.Duration
+
+	// OnExpire is called by the sweeper with the names of the removed keys, without the prefix.
+	OnExpire func(keys []string)
+}
+
+var errForeignKeys = errors.New("redka: foreign keys are not enabled on Options.DB")
+
+// sqlConn is implemented by *sql.DB and *sql.Conn.
+type sqlConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// enableForeignKeys enables the foreign keys of the connection behind conn and checks them.
+// rdk.OpenDB only enables them on the connection it opens, while database/sql replaces
+// connections interrupted by a canceled context. Without them, deleting a key leaves its values behind.
+func enableForeignKeys(ctx context.Context, conn sqlConn) error {
+	if _, err := conn.ExecContext(ctx, "pragma foreign_keys = on"); err != nil {
+		return err
+	}
+
+	var enabled bool
+	if err := conn.QueryRowContext(ctx, "pragma foreign_keys").Scan(&enabled); err != nil {
+		return err
+	}
+	if !enabled {
+		return errForeignKeys
+	}
+	return nil
 }
 
 type Provider struct {
-	db     *rdk.DB
+	db     database
 	prefix string
+
+	// waiter wakes up blocked commands, it is nil within a transaction
+	waiter *waiter
+
+	// broker delivers published messages to the subscribers of the provider
+	broker *caches.Broker
+
+	// streams runs stream commands, it is nil without Options.DB
+	streams *streamStore
+
+	// hashTTL reports whether hash fields can expire, which requires Options.DB
+	hashTTL bool
+
+	// sweeper removes expired keys, it is nil unless Options.SweepInterval is set
+	sweeper *sweeper
+
+	// scripts holds the Go implementations of the scripts run by Eval
+	scripts *caches.ScriptRegistry
+}
+
+// database is the subset of *rdk.DB used by the provider,
+// so that commands can also run within an open transaction.
+type database interface {
+	ViewContext(ctx context.Context, f func(tx *rdk.Tx) error) error
+	UpdateContext(ctx context.Context, f func(tx *rdk.Tx) error) error
+}
+
+// txDB runs every command within the same transaction.
+type txDB struct {
+	tx *rdk.Tx
+}
+
+// ViewContext implements database.
+func (d txDB) ViewContext(ctx context.Context, f func(tx *rdk.Tx) error) error {
+	return f(d.tx)
+}
+
+// UpdateContext implements database.
+func (d txDB) UpdateContext(ctx context.Context, f func(tx *rdk.Tx) error) error {
+	return f(d.tx)
 }
 
 func New(db *rdk.DB) *Provider {
@@ -28,12 +126,82 @@ func NewWithOptions(db *rdk.DB, opts *Options) *Provider {
 		opts = &Options{}
 	}
 
-	return &Provider{
-		db:     db,
-		prefix: strings.TrimSpace(opts.Prefix),
+	if opts.SweepInterval > 0 && opts.DB == nil {
+		panic("sweeper requires Options.DB")
+	}
+	if opts.DB != nil {
+		if err := enableForeignKeys(context.Background(), opts.DB); err != nil {
+			panic(err)
+		}
+		if _, err := opts.DB.Exec(hashExpiresSchema); err != nil {
+			panic(err)
+		}
+	}
+
+	w := newWaiter()
+	p := &Provider{
+		db:      notifyDB{DB: db, sql: opts.DB, waiter: w},
+		prefix:  strings.TrimSpace(opts.Prefix),
+		waiter:  w,
+		broker:  caches.NewBroker(),
+		streams: newStreamStore(opts.DB),
+		hashTTL: opts.DB != nil,
+		scripts: caches.NewScriptRegistry(),
+	}
+
+	if opts.SweepInterval > 0 {
+		p.sweeper = newSweeper(opts.DB, p.prefix, opts)
 	}
+	return p
+}
+
+// Close stops the background sweeper.
+// The Redka database is not closed.
+func (p *Provider) Close() error {
+	if p.sweeper != nil {
+		p.sweeper.close()
+	}
+	return nil
 }
 
 func (p *Provider) Prefix() string {
 	return p.prefix
 }
+
+var _ caches.Cache = (*Provider)(nil)
+
+// Capabilities implements caches.Capable.
+//
+// Redka ignores ZStore weights and treats `(` score bounds as inclusive.
+// Streams and hash field expiration require Options.DB, and scripts are Go functions
+// registered with the provider.
+func (p *Provider) Capabilities() caches.Capabilities {
+	caps := caches.CapKeepTTL |
+		caches.CapSetGet |
+		caches.CapZRangeByLex |
+		caches.CapHyperLogLog |
+		caches.CapGeo |
+		caches.CapBitmap |
+		caches.CapCompare |
+		caches.CapRateLimit |
+		caches.CapScript |
+		caches.CapPipeline |
+		caches.CapTx |
+		caches.CapPubSub
+	if p.streams != nil {
+		caps |= caches.CapStreams
+	}
+	if p.hashTTL {
+		caps |= caches.CapHashFieldTTL
+	}
+	return caps
+}
+
+// withTx returns a copy of the provider that runs all commands within tx.
+// Blocking commands do not wait within a transaction, as in Redis MULTI.
+func (p *Provider) withTx(tx *rdk.Tx) *Provider {
+	bound := *p
+	bound.db = txDB{tx: tx}
+	bound.waiter = nil
+	return &bound
+}
@@ -0,0 +1,69 @@
+package redka
+
+import (
+	"context"
+	"errors"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+var errUnknownScript = errors.New("redka: script is not registered")
+
+var _ caches.ScriptCommand = (*Provider)(nil)
+
+// RegisterScript registers fn as the implementation of script, run by Eval and EvalSha
+// within a single transaction. script is either the Lua source of a script, as returned by
+// caches.Script.Source, or a name only used with this provider.
+// An error returned by fn rolls back the commands it ran.
+func (p *Provider) RegisterScript(script string, fn caches.ScriptFunc) {
+	p.scripts.Register(script, fn)
+}
+
+// Eval implements caches.ScriptCommand.
+//
+// The script must have been registered with RegisterScript.
+func (p *Provider) Eval(ctx context.Context, script string, keys []string, args ...any) caches.Result[any] {
+	fn, ok := p.scripts.Lookup(caches.ScriptHash(script))
+	if !ok {
+		return newResult[any](nil, errUnknownScript)
+	}
+	return p.runScript(ctx, fn, keys, args)
+}
+
+// EvalSha implements caches.ScriptCommand.
+func (p *Provider) EvalSha(ctx context.Context, sha1 string, keys []string, args ...any) caches.Result[any] {
+	fn, ok := p.scripts.Lookup(sha1)
+	if !ok {
+		return newResult[any](nil, caches.ErrNoScript)
+	}
+	return p.runScript(ctx, fn, keys, args)
+}
+
+// runScript runs fn within a single transaction.
+// Keys are prefixed by the commands fn runs, as they are bound to the provider.
+func (p *Provider) runScript(ctx context.Context, fn caches.ScriptFunc, keys []string, args []any) caches.Result[any] {
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (any, error) {
+		return fn(ctx, p.withTx(tx), keys, args)
+	})
+	if val == nil && err == nil {
+		err = caches.Nil
+	}
+	return newResult(val, err)
+}
+
+// ScriptLoad implements caches.ScriptCommand.
+//
+// Scripts cannot be loaded, so ScriptLoad only checks that script is registered.
+func (p *Provider) ScriptLoad(ctx context.Context, script string) caches.Result[string] {
+	sha1 := caches.ScriptHash(script)
+	if _, ok := p.scripts.Lookup(sha1); !ok {
+		return newResult("", errUnknownScript)
+	}
+	return newResult(sha1, nil)
+}
+
+// ScriptExists implements caches.ScriptCommand.
+func (p *Provider) ScriptExists(ctx context.Context, hashes ...string) caches.Result[[]bool] {
+	return newResult(p.scripts.Exists(hashes...), nil)
+}
@@ -1,16 +1,248 @@
 package redka
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	rdk "github.com/nalgeon/redka"
 	"github.com/rockcookies/go-caches"
 )
 
+var (
+	errZMPopOrder = errors.New("redka: order must be MIN or MAX")
+	errInvalidLex = errors.New("redka: min or max not valid string range item")
+	errZNoKeys    = errors.New("redka: at least 1 input key is needed")
+	errZLimit     = errors.New("redka: LIMIT can't be negative")
+)
+
 var _ caches.SortedSetCommand = (*Provider)(nil)
 
+// zsetItems returns all the members of the sorted set stored at key, ordered by score.
+func zsetItems(tx *rdk.Tx, key string) ([]caches.ZMember, error) {
+	size, err := tx.ZSet().Len(key)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	items, err := tx.ZSet().Range(key, 0, size-1)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]caches.ZMember, len(items))
+	for i, item := range items {
+		members[i] = caches.ZMember{Member: item.Elem.Bytes(), Score: item.Score}
+	}
+	return members, nil
+}
+
+// zMemberNames returns the members without their scores.
+func zMemberNames(members []caches.ZMember) [][]byte {
+	names := make([][]byte, len(members))
+	for i, m := range members {
+		names[i] = m.Member
+	}
+	return names
+}
+
+// rangeZSetByLex selects members of the sorted set stored at key between args.Start and args.Stop
+// in lexicographical order. Redka has no lex index, so the members are filtered in Go.
+func rangeZSetByLex(tx *rdk.Tx, key string, args caches.ZRangeArgs) ([]caches.ZMember, error) {
+	r, err := parseLexRange(args.Start, args.Stop)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkZSet(tx, key); err != nil {
+		return nil, err
+	}
+	members, err := zsetItems(tx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同分值成员按字典序排列, 与 Redis 一致
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].Score != members[j].Score {
+			return members[i].Score < members[j].Score
+		}
+		return bytes.Compare(members[i].Member, members[j].Member) < 0
+	})
+
+	selected := []caches.ZMember{}
+	for _, m := range members {
+		if r.contains(string(m.Member)) {
+			selected = append(selected, m)
+		}
+	}
+	if args.Rev {
+		slices.Reverse(selected)
+	}
+	if args.Offset != 0 || args.Count != 0 {
+		selected = limitZMembers(selected, args.Offset, args.Count)
+	}
+	return selected, nil
+}
+
+// deleteZMembers removes members from the sorted set stored at key, deleting the key once it is empty.
+func deleteZMembers(tx *rdk.Tx, key string, members []caches.ZMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	elems := make([]any, len(members))
+	for i, m := range members {
+		elems[i] = m.Member
+	}
+	if _, err := tx.ZSet().Delete(key, elems...); err != nil {
+		return err
+	}
+
+	// 删除最后一个成员时删除键, 与 Redis 一致
+	size, err := tx.ZSet().Len(key)
+	if err != nil || size > 0 {
+		return err
+	}
+	_, err = tx.Key().Delete(key)
+	return err
+}
+
+// storeZSet overwrites destination with members, deleting it when there are none.
+// Returns the number of members stored.
+func storeZSet(tx *rdk.Tx, destination string, members []caches.ZMember) (int64, error) {
+	// 目标键被覆盖, 无论其类型
+	if _, err := tx.Key().Delete(destination); err != nil {
+		return 0, err
+	}
+	if len(members) == 0 {
+		return 0, nil
+	}
+
+	items := make(map[any]float64, len(members))
+	for _, m := range members {
+		items[string(m.Member)] = m.Score
+	}
+	count, err := tx.ZSet().AddMany(destination, items)
+	return int64(count), err
+}
+
+// diffZSets returns the members of the first sorted set among keys that are not in the following ones,
+// ordered by score. Redka has no ZDIFF, so the difference is computed in Go.
+func diffZSets(tx *rdk.Tx, keys []string) ([]caches.ZMember, error) {
+	if len(keys) == 0 {
+		return nil, errZNoKeys
+	}
+
+	excluded := make(map[string]bool)
+	for _, key := range keys[1:] {
+		if _, err := checkZSet(tx, key); err != nil {
+			return nil, err
+		}
+		members, err := zsetItems(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			excluded[string(m.Member)] = true
+		}
+	}
+
+	if _, err := checkZSet(tx, keys[0]); err != nil {
+		return nil, err
+	}
+	members, err := zsetItems(tx, keys[0])
+	if err != nil {
+		return nil, err
+	}
+	result := []caches.ZMember{}
+	for _, m := range members {
+		if !excluded[string(m.Member)] {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// popZSet removes and returns up to count members with the lowest, or highest, scores
+// of the sorted set stored at key. Returns rdk.ErrNotFound when the key does not exist.
+func popZSet(tx *rdk.Tx, key string, count int64, max bool) ([]caches.ZMember, error) {
+	exists, err := checkZSet(tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, rdk.ErrNotFound
+	}
+	if count == 0 {
+		return []caches.ZMember{}, nil
+	}
+
+	cmd := tx.ZSet().RangeWith(key).ByRank(0, int(count-1))
+	if max {
+		cmd = cmd.Desc()
+	}
+	items, err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
+	// Redka 会保留空的有序集合
+	if len(items) == 0 {
+		return nil, rdk.ErrNotFound
+	}
+
+	members := make([]caches.ZMember, len(items))
+	for i, item := range items {
+		members[i] = caches.ZMember{Member: item.Elem.Bytes(), Score: item.Score}
+	}
+	return members, deleteZMembers(tx, key, members)
+}
+
+// parseZMPopOrder reports whether order, MIN or MAX, pops the highest scores.
+func parseZMPopOrder(order string) (bool, error) {
+	switch strings.ToUpper(order) {
+	case "MIN":
+		return false, nil
+	case "MAX":
+		return true, nil
+	}
+	return false, errZMPopOrder
+}
+
+// blockingZPop pops a member from the first non-empty sorted set among keys, waiting for one if needed.
+func (p *Provider) blockingZPop(ctx context.Context, timeout time.Duration, keys []string, max bool) caches.Result[caches.ZPopResult] {
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := block(ctx, p.waiter, timeout, func() (caches.ZPopResult, error) {
+		return updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.ZPopResult, error) {
+			for i, key := range prefixed {
+				members, e := popZSet(tx, key, 1, max)
+				if e == rdk.ErrNotFound {
+					continue
+				}
+				if e != nil {
+					return caches.ZPopResult{}, e
+				}
+				return caches.ZPopResult{Key: keys[i], Member: members[0]}, nil
+			}
+			return caches.ZPopResult{}, rdk.ErrNotFound
+		})
+	})
+	return newResult(res, err)
+}
+
+// BZPopMax implements caches.SortedSetCommand.
+func (p *Provider) BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ZPopResult] {
+	return p.blockingZPop(ctx, timeout, keys, true)
+}
+
+// BZPopMin implements caches.SortedSetCommand.
+func (p *Provider) BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) caches.Result[caches.ZPopResult] {
+	return p.blockingZPop(ctx, timeout, keys, false)
+}
+
 // ZAdd implements caches.SortedSetCommand.
 func (p *Provider) ZAdd(ctx context.Context, key string, members ...caches.ZMember) caches.Result[int64] {
 	key = p.prefix + key
@@ -98,6 +330,39 @@ func (p *Provider) ZCount(ctx context.Context, key string, min, max string) cach
 	return newResult(n, err)
 }
 
+func (p *Provider) zdiff(ctx context.Context, keys []string) ([]caches.ZMember, error) {
+	keys = prefixKeys(p.prefix, keys)
+	return viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]caches.ZMember, error) {
+		return diffZSets(tx, keys)
+	})
+}
+
+// ZDiff implements caches.SortedSetCommand.
+func (p *Provider) ZDiff(ctx context.Context, keys ...string) caches.Result[[][]byte] {
+	members, err := p.zdiff(ctx, keys)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZDiffWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZDiffWithScores(ctx context.Context, keys ...string) caches.Result[[]caches.ZMember] {
+	members, err := p.zdiff(ctx, keys)
+	return newResult(members, err)
+}
+
+// ZDiffStore implements caches.SortedSetCommand.
+func (p *Provider) ZDiffStore(ctx context.Context, destination string, keys ...string) caches.Result[int64] {
+	destination = p.prefix + destination
+	keys = prefixKeys(p.prefix, keys)
+	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		members, e := diffZSets(tx, keys)
+		if e != nil {
+			return 0, e
+		}
+		return storeZSet(tx, destination, members)
+	})
+	return newResult(n, err)
+}
+
 // ZIncrBy implements caches.SortedSetCommand.
 func (p *Provider) ZIncrBy(ctx context.Context, key string, increment float64, member string) caches.Result[float64] {
 	key = p.prefix + key
@@ -166,6 +431,29 @@ func (p *Provider) ZInterWithScores(ctx context.Context, store caches.ZStore) ca
 	return newResult(members, err)
 }
 
+// ZInterCard implements caches.SortedSetCommand.
+func (p *Provider) ZInterCard(ctx context.Context, limit int64, keys ...string) caches.Result[int64] {
+	if len(keys) == 0 {
+		return newResult(int64(0), errZNoKeys)
+	}
+	if limit < 0 {
+		return newResult(int64(0), errZLimit)
+	}
+
+	keys = prefixKeys(p.prefix, keys)
+	n, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		items, e := tx.ZSet().InterWith(keys...).Run()
+		if e != nil {
+			return 0, e
+		}
+		if limit > 0 {
+			return min(int64(len(items)), limit), nil
+		}
+		return int64(len(items)), nil
+	})
+	return newResult(n, err)
+}
+
 // ZInterStore implements caches.SortedSetCommand.
 func (p *Provider) ZInterStore(ctx context.Context, destination string, store caches.ZStore) caches.Result[int64] {
 	destination = p.prefix + destination
@@ -187,6 +475,127 @@ func (p *Provider) ZInterStore(ctx context.Context, destination string, store ca
 	return newResult(n, err)
 }
 
+// ZLexCount implements caches.SortedSetCommand.
+func (p *Provider) ZLexCount(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		members, e := rangeZSetByLex(tx, key, caches.ZRangeArgs{Start: min, Stop: max})
+		return int64(len(members)), e
+	})
+	return newResult(n, err)
+}
+
+// ZMPop implements caches.SortedSetCommand.
+func (p *Provider) ZMPop(ctx context.Context, order string, count int64, keys ...string) caches.Result[caches.ZMPopResult] {
+	max, err := parseZMPopOrder(order)
+	if err != nil {
+		return newResult(caches.ZMPopResult{}, err)
+	}
+	if count <= 0 {
+		return newResult(caches.ZMPopResult{}, errInvalidCount)
+	}
+
+	prefixed := prefixKeys(p.prefix, keys)
+	res, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (caches.ZMPopResult, error) {
+		for i, key := range prefixed {
+			members, e := popZSet(tx, key, count, max)
+			if e == rdk.ErrNotFound {
+				continue
+			}
+			if e != nil {
+				return caches.ZMPopResult{}, e
+			}
+			return caches.ZMPopResult{Key: keys[i], Members: members}, nil
+		}
+		return caches.ZMPopResult{}, rdk.ErrNotFound
+	})
+	return newResult(res, err)
+}
+
+func (p *Provider) zpop(ctx context.Context, key string, count int64, max bool) caches.Result[[]caches.ZMember] {
+	if count < 0 {
+		return newResult([]caches.ZMember(nil), errInvalidCount)
+	}
+
+	key = p.prefix + key
+	members, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]caches.ZMember, error) {
+		members, e := popZSet(tx, key, count, max)
+		if e == rdk.ErrNotFound {
+			return []caches.ZMember{}, nil
+		}
+		return members, e
+	})
+	return newResult(members, err)
+}
+
+// ZMScore implements caches.SortedSetCommand.
+func (p *Provider) ZMScore(ctx context.Context, key string, members ...string) caches.Result[[]*float64] {
+	key = p.prefix + key
+	scores, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]*float64, error) {
+		result := make([]*float64, len(members))
+		for i, member := range members {
+			score, e := tx.ZSet().GetScore(key, member)
+			if e == rdk.ErrNotFound {
+				continue
+			} else if e != nil {
+				return nil, e
+			}
+			result[i] = &score
+		}
+		return result, nil
+	})
+	return newResult(scores, err)
+}
+
+// ZPopMax implements caches.SortedSetCommand.
+func (p *Provider) ZPopMax(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	return p.zpop(ctx, key, count, true)
+}
+
+// ZPopMin implements caches.SortedSetCommand.
+func (p *Provider) ZPopMin(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	return p.zpop(ctx, key, count, false)
+}
+
+func (p *Provider) zrandMember(ctx context.Context, key string, count int64) ([]caches.ZMember, error) {
+	key = p.prefix + key
+	return viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]caches.ZMember, error) {
+		if _, e := checkZSet(tx, key); e != nil {
+			return nil, e
+		}
+		members, e := zsetItems(tx, key)
+		if e != nil || len(members) == 0 {
+			return []caches.ZMember{}, e
+		}
+
+		// 正数返回不重复的成员, 负数允许重复
+		if count >= 0 {
+			rand.Shuffle(len(members), func(i, j int) {
+				members[i], members[j] = members[j], members[i]
+			})
+			return members[:min(count, int64(len(members)))], nil
+		}
+
+		result := make([]caches.ZMember, -count)
+		for i := range result {
+			result[i] = members[rand.Intn(len(members))]
+		}
+		return result, nil
+	})
+}
+
+// ZRandMember implements caches.SortedSetCommand.
+func (p *Provider) ZRandMember(ctx context.Context, key string, count int64) caches.Result[[][]byte] {
+	members, err := p.zrandMember(ctx, key, count)
+	return newResult(zMemberNames(members), err)
+}
+
+// ZRandMemberWithScores implements caches.SortedSetCommand.
+func (p *Provider) ZRandMemberWithScores(ctx context.Context, key string, count int64) caches.Result[[]caches.ZMember] {
+	members, err := p.zrandMember(ctx, key, count)
+	return newResult(members, err)
+}
+
 // ZRange implements caches.SortedSetCommand.
 func (p *Provider) ZRange(ctx context.Context, key string, start, stop int64) caches.Result[[][]byte] {
 	key = p.prefix + key
@@ -258,40 +667,49 @@ func (p *Provider) ZRangeWithScores(ctx context.Context, key string, start, stop
 	return newResult(members, err)
 }
 
-// ZRangeArgs implements caches.SortedSetCommand.
-func (p *Provider) ZRangeArgs(ctx context.Context, key string, args caches.ZRangeArgs) caches.Result[[][]byte] {
-	key = p.prefix + key
-	vals, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([][]byte, error) {
-		cmd := tx.ZSet().RangeWith(key)
+// rangeZSet selects members of the sorted set stored at key as described by args.
+func rangeZSet(tx *rdk.Tx, key string, args caches.ZRangeArgs) ([]caches.ZMember, error) {
+	if args.ByLex {
+		return rangeZSetByLex(tx, key, args)
+	}
 
-		if args.ByScore {
-			minScore, maxScore, e := parseScoreRangeAny(args.Start, args.Stop)
-			if e != nil {
-				return nil, e
-			}
-			cmd = cmd.ByScore(minScore, maxScore)
-		} else {
-			start, stop := parseIndexRange(args.Start, args.Stop)
-			cmd = cmd.ByRank(start, stop)
+	cmd := tx.ZSet().RangeWith(key)
+	if args.ByScore {
+		minScore, maxScore, err := parseScoreRangeAny(args.Start, args.Stop)
+		if err != nil {
+			return nil, err
 		}
+		cmd = cmd.ByScore(minScore, maxScore)
+	} else {
+		start, stop := parseIndexRange(args.Start, args.Stop)
+		cmd = cmd.ByRank(start, stop)
+	}
 
-		if args.Rev {
-			cmd = cmd.Desc()
-		}
-		if args.Offset > 0 || args.Count > 0 {
-			cmd = cmd.Offset(int(args.Offset)).Count(int(args.Count))
-		}
+	if args.Rev {
+		cmd = cmd.Desc()
+	}
+	if args.Offset > 0 || args.Count > 0 {
+		cmd = cmd.Offset(int(args.Offset)).Count(int(args.Count))
+	}
 
-		items, e := cmd.Run()
-		if e != nil {
-			return nil, e
-		}
+	items, err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
 
-		result := make([][]byte, len(items))
-		for i, item := range items {
-			result[i] = item.Elem.Bytes()
-		}
-		return result, nil
+	members := make([]caches.ZMember, len(items))
+	for i, item := range items {
+		members[i] = caches.ZMember{Member: item.Elem.Bytes(), Score: item.Score}
+	}
+	return members, nil
+}
+
+// ZRangeArgs implements caches.SortedSetCommand.
+func (p *Provider) ZRangeArgs(ctx context.Context, key string, args caches.ZRangeArgs) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([][]byte, error) {
+		members, e := rangeZSet(tx, key, args)
+		return zMemberNames(members), e
 	})
 	return newResult(vals, err)
 }
@@ -300,41 +718,33 @@ func (p *Provider) ZRangeArgs(ctx context.Context, key string, args caches.ZRang
 func (p *Provider) ZRangeArgsWithScores(ctx context.Context, key string, args caches.ZRangeArgs) caches.Result[[]caches.ZMember] {
 	key = p.prefix + key
 	members, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]caches.ZMember, error) {
-		cmd := tx.ZSet().RangeWith(key)
-
-		if args.ByScore {
-			minScore, maxScore, e := parseScoreRangeAny(args.Start, args.Stop)
-			if e != nil {
-				return nil, e
-			}
-			cmd = cmd.ByScore(minScore, maxScore)
-		} else {
-			start, stop := parseIndexRange(args.Start, args.Stop)
-			cmd = cmd.ByRank(start, stop)
-		}
-
-		if args.Rev {
-			cmd = cmd.Desc()
-		}
-		if args.Offset > 0 || args.Count > 0 {
-			cmd = cmd.Offset(int(args.Offset)).Count(int(args.Count))
-		}
+		return rangeZSet(tx, key, args)
+	})
+	return newResult(members, err)
+}
 
-		items, e := cmd.Run()
+// ZRangeStore implements caches.SortedSetCommand.
+func (p *Provider) ZRangeStore(ctx context.Context, destination, key string, args caches.ZRangeArgs) caches.Result[int64] {
+	destination = p.prefix + destination
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		members, e := rangeZSet(tx, key, args)
 		if e != nil {
-			return nil, e
+			return 0, e
 		}
+		return storeZSet(tx, destination, members)
+	})
+	return newResult(n, err)
+}
 
-		result := make([]caches.ZMember, len(items))
-		for i, item := range items {
-			result[i] = caches.ZMember{
-				Member: item.Elem.Bytes(),
-				Score:  item.Score,
-			}
-		}
-		return result, nil
+// ZRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRangeByLex(ctx context.Context, key string, min, max string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([][]byte, error) {
+		members, e := rangeZSetByLex(tx, key, caches.ZRangeArgs{Start: min, Stop: max})
+		return zMemberNames(members), e
 	})
-	return newResult(members, err)
+	return newResult(vals, err)
 }
 
 // ZRangeByScore implements caches.SortedSetCommand.
@@ -422,6 +832,19 @@ func (p *Provider) ZRem(ctx context.Context, key string, members ...any) caches.
 	return newResult(n, err)
 }
 
+// ZRemRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRemRangeByLex(ctx context.Context, key string, min, max string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		members, e := rangeZSetByLex(tx, key, caches.ZRangeArgs{Start: min, Stop: max})
+		if e != nil {
+			return 0, e
+		}
+		return int64(len(members)), deleteZMembers(tx, key, members)
+	})
+	return newResult(n, err)
+}
+
 // ZRemRangeByRank implements caches.SortedSetCommand.
 func (p *Provider) ZRemRangeByRank(ctx context.Context, key string, start, stop int64) caches.Result[int64] {
 	key = p.prefix + key
@@ -517,6 +940,16 @@ func (p *Provider) ZRevRangeWithScores(ctx context.Context, key string, start, s
 	return newResult(members, err)
 }
 
+// ZRevRangeByLex implements caches.SortedSetCommand.
+func (p *Provider) ZRevRangeByLex(ctx context.Context, key string, max, min string) caches.Result[[][]byte] {
+	key = p.prefix + key
+	vals, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([][]byte, error) {
+		members, e := rangeZSetByLex(tx, key, caches.ZRangeArgs{Start: min, Stop: max, Rev: true})
+		return zMemberNames(members), e
+	})
+	return newResult(vals, err)
+}
+
 // ZRevRangeByScore implements caches.SortedSetCommand.
 func (p *Provider) ZRevRangeByScore(ctx context.Context, key string, max, min string) caches.Result[[][]byte] {
 	key = p.prefix + key
@@ -786,3 +1219,88 @@ func parseIndexRange(start, stop any) (int, int) {
 
 	return startIdx, stopIdx
 }
+
+// lexBound is one end of a lexicographical interval such as "[a", "(a", "-" or "+".
+type lexBound struct {
+	value     string
+	exclusive bool
+	inf       int
+}
+
+func parseLexBound(val any) (lexBound, error) {
+	s, ok := val.(string)
+	if !ok || s == "" {
+		return lexBound{}, errInvalidLex
+	}
+
+	switch s[0] {
+	case '-':
+		if len(s) != 1 {
+			return lexBound{}, errInvalidLex
+		}
+		return lexBound{inf: -1}, nil
+	case '+':
+		if len(s) != 1 {
+			return lexBound{}, errInvalidLex
+		}
+		return lexBound{inf: 1}, nil
+	case '[':
+		return lexBound{value: s[1:]}, nil
+	case '(':
+		return lexBound{value: s[1:], exclusive: true}, nil
+	default:
+		return lexBound{}, errInvalidLex
+	}
+}
+
+// lexRange is an interval of members built from min and max bounds.
+type lexRange struct {
+	min, max lexBound
+}
+
+func parseLexRange(min, max any) (lexRange, error) {
+	lo, err := parseLexBound(min)
+	if err != nil {
+		return lexRange{}, err
+	}
+	hi, err := parseLexBound(max)
+	if err != nil {
+		return lexRange{}, err
+	}
+	return lexRange{min: lo, max: hi}, nil
+}
+
+func (r lexRange) contains(member string) bool {
+	switch r.min.inf {
+	case 1:
+		return false
+	case 0:
+		if c := strings.Compare(member, r.min.value); c < 0 || (c == 0 && r.min.exclusive) {
+			return false
+		}
+	}
+
+	switch r.max.inf {
+	case -1:
+		return false
+	case 0:
+		if c := strings.Compare(member, r.max.value); c > 0 || (c == 0 && r.max.exclusive) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// limitZMembers applies the LIMIT offset count clause. A negative count returns
+// all remaining members.
+func limitZMembers(members []caches.ZMember, offset, count int64) []caches.ZMember {
+	if offset < 0 || offset >= int64(len(members)) {
+		return members[:0]
+	}
+	members = members[offset:]
+	if count >= 0 && count < int64(len(members)) {
+		members = members[:count]
+	}
+	return members
+}
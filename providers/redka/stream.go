@@ -0,0 +1,405 @@
+package redka
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+// Redka has no streams, they are emulated with the tables below.
+// Each stream has a key of typeStream in the Redka keyspace, so that key commands see it,
+// and its rows are deleted along with the key.
+const streamSchema = `
+create table if not exists caches_stream (
+	kid      integer not null primary key references rkey (id) on delete cascade,
+	last_ms  integer not null,
+	last_seq integer not null
+);
+
+create table if not exists caches_stream_entry (
+	kid    integer not null references rkey (id) on delete cascade,
+	ms     integer not null,
+	seq    integer not null,
+	fields blob    not null,
+	primary key (kid, ms, seq)
+) without rowid;
+
+create table if not exists caches_stream_group (
+	kid      integer not null references rkey (id) on delete cascade,
+	name     text    not null,
+	last_ms  integer not null,
+	last_seq integer not null,
+	primary key (kid, name)
+) without rowid;
+
+create table if not exists caches_stream_pending (
+	kid            integer not null references rkey (id) on delete cascade,
+	grp            text    not null,
+	ms             integer not null,
+	seq            integer not null,
+	consumer       text    not null,
+	delivered_at   integer not null,
+	delivery_count integer not null,
+	primary key (kid, grp, ms, seq)
+) without rowid;`
+
+// typeStream is the Redka key type of the emulated streams, after the types of Redka.
+const typeStream = rdk.TypeID(6)
+
+var (
+	errStreamsDisabled  = errors.New("redka: streams require Options.DB")
+	errInvalidStreamID  = errors.New("redka: invalid stream ID")
+	errStreamIDTooSmall = errors.New("redka: the ID specified in XADD is equal or smaller than the target stream top item")
+	errStreamIDZero     = errors.New("redka: the ID specified in XADD must be greater than 0-0")
+	errStreamValues     = errors.New("redka: XADD requires at least one field-value pair")
+	errStreamArgs       = errors.New("redka: each stream requires an ID")
+	errNoStream         = errors.New("redka: the stream does not exist")
+	errBusyGroup        = errors.New("redka: consumer group name already exists")
+	errNoGroup          = errors.New("redka: no such key or consumer group")
+)
+
+// streamID is a stream entry ID.
+type streamID struct {
+	ms  int64
+	seq int64
+}
+
+var maxStreamID = streamID{ms: math.MaxInt64, seq: math.MaxInt64}
+
+func (id streamID) String() string {
+	return strconv.FormatInt(id.ms, 10) + "-" + strconv.FormatInt(id.seq, 10)
+}
+
+func (id streamID) less(other streamID) bool {
+	return id.ms < other.ms || (id.ms == other.ms && id.seq < other.seq)
+}
+
+// next returns the smallest ID greater than id, false when id is the greatest ID.
+func (id streamID) next() (streamID, bool) {
+	switch {
+	case id.seq < math.MaxInt64:
+		return streamID{ms: id.ms, seq: id.seq + 1}, true
+	case id.ms < math.MaxInt64:
+		return streamID{ms: id.ms + 1}, true
+	}
+	return id, false
+}
+
+// prev returns the greatest ID smaller than id, false when id is 0-0.
+func (id streamID) prev() (streamID, bool) {
+	switch {
+	case id.seq > 0:
+		return streamID{ms: id.ms, seq: id.seq - 1}, true
+	case id.ms > 0:
+		return streamID{ms: id.ms - 1, seq: math.MaxInt64}, true
+	}
+	return id, false
+}
+
+// parseStreamID parses an ID in the `<ms>-<seq>` form.
+// The sequence number may be omitted, seq is used instead.
+func parseStreamID(s string, seq int64) (streamID, error) {
+	msPart, seqPart, hasSeq := strings.Cut(s, "-")
+
+	ms, err := strconv.ParseInt(msPart, 10, 64)
+	if err != nil || ms < 0 {
+		return streamID{}, errInvalidStreamID
+	}
+
+	if hasSeq {
+		seq, err = strconv.ParseInt(seqPart, 10, 64)
+		if err != nil || seq < 0 {
+			return streamID{}, errInvalidStreamID
+		}
+	}
+
+	return streamID{ms: ms, seq: seq}, nil
+}
+
+// parseRangeStart parses the start of a range of IDs.
+// `-` is the smallest ID and a `(` prefix excludes the bound.
+func parseRangeStart(s string) (streamID, error) {
+	switch s {
+	case "-":
+		return streamID{}, nil
+	case "+":
+		return maxStreamID, nil
+	}
+
+	exclusive := strings.HasPrefix(s, "(")
+	id, err := parseStreamID(strings.TrimPrefix(s, "("), 0)
+	if err != nil || !exclusive {
+		return id, err
+	}
+
+	id, ok := id.next()
+	if !ok {
+		return id, errInvalidStreamID
+	}
+	return id, nil
+}
+
+// parseRangeEnd parses the end of a range of IDs.
+// `+` is the greatest ID and a `(` prefix excludes the bound.
+func parseRangeEnd(s string) (streamID, error) {
+	switch s {
+	case "-":
+		return streamID{}, nil
+	case "+":
+		return maxStreamID, nil
+	}
+
+	exclusive := strings.HasPrefix(s, "(")
+	id, err := parseStreamID(strings.TrimPrefix(s, "("), math.MaxInt64)
+	if err != nil || !exclusive {
+		return id, err
+	}
+
+	id, ok := id.prev()
+	if !ok {
+		return id, errInvalidStreamID
+	}
+	return id, nil
+}
+
+// streamStore runs stream commands against the SQL database of Redka.
+type streamStore struct {
+	db *sql.DB
+
+	once sync.Once
+	err  error
+}
+
+func newStreamStore(db *sql.DB) *streamStore {
+	if db == nil {
+		return nil
+	}
+	return &streamStore{db: db}
+}
+
+// update runs f within a transaction, creating the stream tables on first use.
+func (s *streamStore) update(ctx context.Context, f func(tx *sql.Tx) error) error {
+	if s == nil {
+		return errStreamsDisabled
+	}
+
+	s.once.Do(func() {
+		_, s.err = s.db.Exec(streamSchema)
+	})
+	if s.err != nil {
+		return s.err
+	}
+	if err := enableForeignKeys(ctx, s.db); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func streamAndReturn[T any](ctx context.Context, s *streamStore, cb func(tx *sql.Tx) (T, error)) (res T, err error) {
+	err = s.update(ctx, func(tx *sql.Tx) (e error) {
+		res, e = cb(tx)
+		return
+	})
+	return
+}
+
+// streamKeyID returns the ID of the stream key, rdk.ErrNotFound when the key does not exist
+// and rdk.ErrKeyType when the key is not a stream.
+func streamKeyID(tx *sql.Tx, key string) (int64, error) {
+	var kid int64
+	var typ rdk.TypeID
+	err := tx.QueryRow(`select id, type from rkey where key = ? and (etime is null or etime > ?)`,
+		key, time.Now().UnixMilli()).Scan(&kid, &typ)
+	if err == sql.ErrNoRows {
+		return 0, rdk.ErrNotFound
+	} else if err != nil {
+		return 0, err
+	}
+
+	if typ != typeStream {
+		return 0, rdk.ErrKeyType
+	}
+	return kid, nil
+}
+
+// createStreamKey returns the ID of the stream key, creating an empty stream when the key does not exist.
+func createStreamKey(tx *sql.Tx, key string) (int64, error) {
+	kid, err := streamKeyID(tx, key)
+	if err != rdk.ErrNotFound {
+		return kid, err
+	}
+
+	// 过期的键可能尚未删除，先删除再创建
+	if _, err := tx.Exec(`delete from rkey where key = ?`, key); err != nil {
+		return 0, err
+	}
+
+	res, err := tx.Exec(`insert into rkey (key, type, version, mtime) values (?, ?, 1, ?)`,
+		key, typeStream, time.Now().UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+
+	if kid, err = res.LastInsertId(); err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`insert into caches_stream (kid, last_ms, last_seq) values (?, 0, 0)`, kid)
+	return kid, err
+}
+
+// touchStreamKey increments the version of the stream key, so that transactions watching it fail.
+func touchStreamKey(tx *sql.Tx, kid int64) error {
+	_, err := tx.Exec(`update rkey set version = version + 1, mtime = ? where id = ?`,
+		time.Now().UnixMilli(), kid)
+	return err
+}
+
+// streamLastID returns the ID of the last entry added to the stream.
+func streamLastID(tx *sql.Tx, kid int64) (streamID, error) {
+	var id streamID
+	err := tx.QueryRow(`select last_ms, last_seq from caches_stream where kid = ?`, kid).Scan(&id.ms, &id.seq)
+	return id, err
+}
+
+// setStreamLastID updates the ID of the last entry added to the stream.
+func setStreamLastID(tx *sql.Tx, kid int64, id streamID) error {
+	_, err := tx.Exec(`update caches_stream set last_ms = ?, last_seq = ? where kid = ?`, id.ms, id.seq, kid)
+	return err
+}
+
+// streamGroup returns the ID of the stream key and the ID of the last entry delivered to the group,
+// errNoGroup when the stream or the group does not exist.
+func streamGroup(tx *sql.Tx, key, group string) (int64, streamID, error) {
+	kid, err := streamKeyID(tx, key)
+	if err == rdk.ErrNotFound {
+		return 0, streamID{}, errNoGroup
+	} else if err != nil {
+		return 0, streamID{}, err
+	}
+
+	var last streamID
+	err = tx.QueryRow(`select last_ms, last_seq from caches_stream_group where kid = ? and name = ?`,
+		kid, group).Scan(&last.ms, &last.seq)
+	if err == sql.ErrNoRows {
+		return 0, last, errNoGroup
+	}
+	return kid, last, err
+}
+
+// queryStreamEntries returns the entries selected by query,
+// which selects the ms, seq and fields columns in that order.
+// A NULL fields column is returned as an entry without values.
+func queryStreamEntries(tx *sql.Tx, query string, args ...any) ([]caches.XMessage, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	msgs := make([]caches.XMessage, 0)
+	for rows.Next() {
+		var id streamID
+		var fields []byte
+		if err := rows.Scan(&id.ms, &id.seq, &fields); err != nil {
+			return nil, err
+		}
+
+		msg := caches.XMessage{ID: id.String()}
+		if fields != nil {
+			if err := json.Unmarshal(fields, &msg.Values); err != nil {
+				return nil, err
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, rows.Err()
+}
+
+// rangeStreamEntries returns up to limit entries with an ID between start and end.
+// A negative limit returns all of them.
+func rangeStreamEntries(tx *sql.Tx, kid int64, start, end streamID, rev bool, limit int64) ([]caches.XMessage, error) {
+	order := "asc"
+	if rev {
+		order = "desc"
+	}
+
+	return queryStreamEntries(tx, `select ms, seq, fields from caches_stream_entry
+		where kid = ? and (ms, seq) >= (?, ?) and (ms, seq) <= (?, ?)
+		order by ms `+order+`, seq `+order+` limit ?`,
+		kid, start.ms, start.seq, end.ms, end.seq, limit)
+}
+
+// trimStreamMaxLen removes the oldest entries so that at most maxLen remain.
+func trimStreamMaxLen(tx *sql.Tx, kid int64, maxLen int64) (int64, error) {
+	var count int64
+	err := tx.QueryRow(`select count(*) from caches_stream_entry where kid = ?`, kid).Scan(&count)
+	if err != nil || count <= maxLen {
+		return 0, err
+	}
+
+	res, err := tx.Exec(`delete from caches_stream_entry where kid = ? and (ms, seq) in (
+		select ms, seq from caches_stream_entry where kid = ? order by ms, seq limit ?)`,
+		kid, kid, count-maxLen)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// trimStreamMinID removes the entries with an ID lower than minID.
+func trimStreamMinID(tx *sql.Tx, kid int64, minID streamID) (int64, error) {
+	res, err := tx.Exec(`delete from caches_stream_entry where kid = ? and (ms, seq) < (?, ?)`,
+		kid, minID.ms, minID.seq)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// claimStreamEntry assigns a pending entry to consumer and returns the entry.
+// Pending entries deleted from the stream are removed from the group and rdk.ErrNotFound is returned.
+func claimStreamEntry(tx *sql.Tx, kid int64, group, consumer string, id streamID, now int64) (caches.XMessage, error) {
+	_, err := tx.Exec(`update caches_stream_pending
+		set consumer = ?, delivered_at = ?, delivery_count = delivery_count + 1
+		where kid = ? and grp = ? and ms = ? and seq = ?`,
+		consumer, now, kid, group, id.ms, id.seq)
+	if err != nil {
+		return caches.XMessage{}, err
+	}
+
+	msgs, err := queryStreamEntries(tx, `select ms, seq, fields from caches_stream_entry
+		where kid = ? and ms = ? and seq = ?`, kid, id.ms, id.seq)
+	if err != nil {
+		return caches.XMessage{}, err
+	}
+
+	if len(msgs) == 0 {
+		_, err = tx.Exec(`delete from caches_stream_pending where kid = ? and grp = ? and ms = ? and seq = ?`,
+			kid, group, id.ms, id.seq)
+		if err != nil {
+			return caches.XMessage{}, err
+		}
+		return caches.XMessage{}, rdk.ErrNotFound
+	}
+	return msgs[0], nil
+}
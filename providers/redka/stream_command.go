@@ -0,0 +1,818 @@
+package redka
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.StreamCommand = (*Provider)(nil)
+
+// nextStreamID returns the ID of an entry added after last, as specified by the XADD id argument.
+func nextStreamID(s string, last streamID) (streamID, error) {
+	if s == "" || s == "*" {
+		ms := time.Now().UnixMilli()
+		if ms > last.ms {
+			return streamID{ms: ms}, nil
+		}
+
+		// 时钟回拨或同一毫秒内，沿用最后一个 ID 的时间戳
+		id, ok := last.next()
+		if !ok {
+			return id, errStreamIDTooSmall
+		}
+		return id, nil
+	}
+
+	if msPart, ok := strings.CutSuffix(s, "-*"); ok {
+		id, err := parseStreamID(msPart, 0)
+		if err != nil {
+			return id, err
+		}
+
+		switch {
+		case id.ms < last.ms:
+			return id, errStreamIDTooSmall
+		case id.ms == last.ms:
+			id, ok = last.next()
+			if !ok || id.ms != last.ms {
+				return id, errStreamIDTooSmall
+			}
+		case id.ms == 0:
+			id.seq = 1
+		}
+		return id, nil
+	}
+
+	id, err := parseStreamID(s, 0)
+	if err != nil {
+		return id, err
+	}
+
+	if id == (streamID{}) {
+		return id, errStreamIDZero
+	}
+	if !last.less(id) {
+		return id, errStreamIDTooSmall
+	}
+	return id, nil
+}
+
+// readStreams calls read once when timeout is zero.
+// Otherwise, it waits for read to find entries for up to timeout, or until ctx is done when timeout is negative.
+func readStreams(ctx context.Context, w *waiter, timeout time.Duration, read func() ([]caches.XStream, error)) ([]caches.XStream, error) {
+	if timeout == 0 {
+		return read()
+	}
+
+	if timeout < 0 {
+		timeout = 0
+	}
+	return block(ctx, w, timeout, read)
+}
+
+// XAck implements caches.StreamCommand.
+func (p *Provider) XAck(ctx context.Context, key, group string, ids ...string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (int64, error) {
+		kid, err := streamKeyID(tx, key)
+		if err == rdk.ErrNotFound {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		var n int64
+		for _, s := range ids {
+			id, err := parseStreamID(s, 0)
+			if err != nil {
+				return 0, err
+			}
+
+			res, err := tx.Exec(`delete from caches_stream_pending where kid = ? and grp = ? and ms = ? and seq = ?`,
+				kid, group, id.ms, id.seq)
+			if err != nil {
+				return 0, err
+			}
+
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return 0, err
+			}
+			n += affected
+		}
+
+		if n > 0 {
+			return n, touchStreamKey(tx, kid)
+		}
+		return n, nil
+	})
+	return newResult(n, err)
+}
+
+// XAdd implements caches.StreamCommand.
+func (p *Provider) XAdd(ctx context.Context, key string, args caches.XAddArgs) caches.Result[string] {
+	key = p.prefix + key
+	if len(args.Values) == 0 {
+		return newResult("", errStreamValues)
+	}
+
+	values := make(map[string][]byte, len(args.Values))
+	for field, value := range args.Values {
+		b, err := toBytes(value)
+		if err != nil {
+			return newResult("", err)
+		}
+		values[field] = b
+	}
+
+	fields, err := json.Marshal(values)
+	if err != nil {
+		return newResult("", err)
+	}
+
+	id, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (streamID, error) {
+		var kid int64
+		var err error
+		if args.NoMkStream {
+			kid, err = streamKeyID(tx, key)
+		} else {
+			kid, err = createStreamKey(tx, key)
+		}
+		if err != nil {
+			return streamID{}, err
+		}
+
+		last, err := streamLastID(tx, kid)
+		if err != nil {
+			return last, err
+		}
+
+		id, err := nextStreamID(args.ID, last)
+		if err != nil {
+			return id, err
+		}
+
+		_, err = tx.Exec(`insert into caches_stream_entry (kid, ms, seq, fields) values (?, ?, ?, ?)`,
+			kid, id.ms, id.seq, fields)
+		if err != nil {
+			return id, err
+		}
+
+		if err := setStreamLastID(tx, kid, id); err != nil {
+			return id, err
+		}
+		if err := touchStreamKey(tx, kid); err != nil {
+			return id, err
+		}
+
+		switch {
+		case args.MaxLen > 0:
+			_, err = trimStreamMaxLen(tx, kid, args.MaxLen)
+		case args.MinID != "":
+			var minID streamID
+			if minID, err = parseStreamID(args.MinID, 0); err == nil {
+				_, err = trimStreamMinID(tx, kid, minID)
+			}
+		}
+		return id, err
+	})
+	if err != nil {
+		return newResult("", err)
+	}
+
+	if p.waiter != nil {
+		p.waiter.notify()
+	}
+	return newResult(id.String(), nil)
+}
+
+// XAutoClaim implements caches.StreamCommand.
+func (p *Provider) XAutoClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, start string, count int64) caches.Result[caches.XAutoClaimResult] {
+	key = p.prefix + key
+	if count <= 0 {
+		count = 100
+	}
+
+	result, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (caches.XAutoClaimResult, error) {
+		result := caches.XAutoClaimResult{Next: "0-0", Messages: make([]caches.XMessage, 0)}
+
+		startID, err := parseRangeStart(start)
+		if err != nil {
+			return result, err
+		}
+
+		kid, _, err := streamGroup(tx, key, group)
+		if err != nil {
+			return result, err
+		}
+
+		now := time.Now().UnixMilli()
+		rows, err := tx.Query(`select ms, seq from caches_stream_pending
+			where kid = ? and grp = ? and (ms, seq) >= (?, ?) and delivered_at <= ?
+			order by ms, seq limit ?`,
+			kid, group, startID.ms, startID.seq, now-minIdle.Milliseconds(), count+1)
+		if err != nil {
+			return result, err
+		}
+
+		var ids []streamID
+		for rows.Next() {
+			var id streamID
+			if err := rows.Scan(&id.ms, &id.seq); err != nil {
+				rows.Close()
+				return result, err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return result, err
+		}
+
+		if int64(len(ids)) > count {
+			result.Next = ids[count].String()
+			ids = ids[:count]
+		}
+
+		for _, id := range ids {
+			msg, err := claimStreamEntry(tx, kid, group, consumer, id, now)
+			if err == rdk.ErrNotFound {
+				continue
+			} else if err != nil {
+				return result, err
+			}
+			result.Messages = append(result.Messages, msg)
+		}
+
+		if len(ids) > 0 {
+			return result, touchStreamKey(tx, kid)
+		}
+		return result, nil
+	})
+	return newResult(result, err)
+}
+
+// XClaim implements caches.StreamCommand.
+func (p *Provider) XClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, ids ...string) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	msgs, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]caches.XMessage, error) {
+		kid, _, err := streamGroup(tx, key, group)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UnixMilli()
+		msgs := make([]caches.XMessage, 0, len(ids))
+		for _, s := range ids {
+			id, err := parseStreamID(s, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			var idle bool
+			err = tx.QueryRow(`select delivered_at <= ? from caches_stream_pending
+				where kid = ? and grp = ? and ms = ? and seq = ?`,
+				now-minIdle.Milliseconds(), kid, group, id.ms, id.seq).Scan(&idle)
+			if err == sql.ErrNoRows {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if !idle {
+				continue
+			}
+
+			msg, err := claimStreamEntry(tx, kid, group, consumer, id, now)
+			if err == rdk.ErrNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, msg)
+		}
+
+		if len(msgs) > 0 {
+			return msgs, touchStreamKey(tx, kid)
+		}
+		return msgs, nil
+	})
+	return newResult(msgs, err)
+}
+
+// XDel implements caches.StreamCommand.
+func (p *Provider) XDel(ctx context.Context, key string, ids ...string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (int64, error) {
+		kid, err := streamKeyID(tx, key)
+		if err == rdk.ErrNotFound {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		var n int64
+		for _, s := range ids {
+			id, err := parseStreamID(s, 0)
+			if err != nil {
+				return 0, err
+			}
+
+			res, err := tx.Exec(`delete from caches_stream_entry where kid = ? and ms = ? and seq = ?`,
+				kid, id.ms, id.seq)
+			if err != nil {
+				return 0, err
+			}
+
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return 0, err
+			}
+			n += affected
+		}
+
+		if n > 0 {
+			return n, touchStreamKey(tx, kid)
+		}
+		return n, nil
+	})
+	return newResult(n, err)
+}
+
+// xGroupCreate creates a consumer group, and the stream when mkStream is set.
+func (p *Provider) xGroupCreate(ctx context.Context, key, group, start string, mkStream bool) caches.StatusResult {
+	key = p.prefix + key
+	val, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]byte, error) {
+		var kid int64
+		var err error
+		if mkStream {
+			kid, err = createStreamKey(tx, key)
+		} else if kid, err = streamKeyID(tx, key); err == rdk.ErrNotFound {
+			err = errNoStream
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		last, err := streamLastID(tx, kid)
+		if err != nil {
+			return nil, err
+		}
+
+		id := last
+		if start != "$" {
+			if id, err = parseStreamID(start, 0); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := tx.Exec(`insert into caches_stream_group (kid, name, last_ms, last_seq) values (?, ?, ?, ?)
+			on conflict (kid, name) do nothing`, kid, group, id.ms, id.seq)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, errBusyGroup
+		}
+		return []byte("OK"), touchStreamKey(tx, kid)
+	})
+	return newStatusResult(val, err)
+}
+
+// XGroupCreate implements caches.StreamCommand.
+func (p *Provider) XGroupCreate(ctx context.Context, key, group, start string) caches.StatusResult {
+	return p.xGroupCreate(ctx, key, group, start, false)
+}
+
+// XGroupCreateMkStream implements caches.StreamCommand.
+func (p *Provider) XGroupCreateMkStream(ctx context.Context, key, group, start string) caches.StatusResult {
+	return p.xGroupCreate(ctx, key, group, start, true)
+}
+
+// XGroupDestroy implements caches.StreamCommand.
+func (p *Provider) XGroupDestroy(ctx context.Context, key, group string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (int64, error) {
+		kid, err := streamKeyID(tx, key)
+		if err == rdk.ErrNotFound {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(`delete from caches_stream_pending where kid = ? and grp = ?`, kid, group)
+		if err != nil {
+			return 0, err
+		}
+
+		res, err := tx.Exec(`delete from caches_stream_group where kid = ? and name = ?`, kid, group)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil || n == 0 {
+			return n, err
+		}
+		return n, touchStreamKey(tx, kid)
+	})
+	return newResult(n, err)
+}
+
+// XLen implements caches.StreamCommand.
+func (p *Provider) XLen(ctx context.Context, key string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (n int64, err error) {
+		kid, err := streamKeyID(tx, key)
+		if err == rdk.ErrNotFound {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		err = tx.QueryRow(`select count(*) from caches_stream_entry where kid = ?`, kid).Scan(&n)
+		return
+	})
+	return newResult(n, err)
+}
+
+// XPending implements caches.StreamCommand.
+func (p *Provider) XPending(ctx context.Context, key, group string) caches.Result[caches.XPending] {
+	key = p.prefix + key
+	pending, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (caches.XPending, error) {
+		pending := caches.XPending{Consumers: make(map[string]int64)}
+		kid, _, err := streamGroup(tx, key, group)
+		if err != nil {
+			return pending, err
+		}
+
+		rows, err := tx.Query(`select consumer, count(*) from caches_stream_pending
+			where kid = ? and grp = ? group by consumer`, kid, group)
+		if err != nil {
+			return pending, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var consumer string
+			var count int64
+			if err := rows.Scan(&consumer, &count); err != nil {
+				return pending, err
+			}
+			pending.Consumers[consumer] = count
+			pending.Count += count
+		}
+		if err := rows.Err(); err != nil || pending.Count == 0 {
+			return pending, err
+		}
+
+		var lower, higher streamID
+		err = tx.QueryRow(`select ms, seq from caches_stream_pending
+			where kid = ? and grp = ? order by ms, seq limit 1`, kid, group).Scan(&lower.ms, &lower.seq)
+		if err != nil {
+			return pending, err
+		}
+
+		err = tx.QueryRow(`select ms, seq from caches_stream_pending
+			where kid = ? and grp = ? order by ms desc, seq desc limit 1`, kid, group).Scan(&higher.ms, &higher.seq)
+		if err != nil {
+			return pending, err
+		}
+
+		pending.Lower = lower.String()
+		pending.Higher = higher.String()
+		return pending, nil
+	})
+	return newResult(pending, err)
+}
+
+// XPendingExt implements caches.StreamCommand.
+func (p *Provider) XPendingExt(ctx context.Context, key, group string, args caches.XPendingExtArgs) caches.Result[[]caches.XPendingExt] {
+	key = p.prefix + key
+	if args.Start == "" {
+		args.Start = "-"
+	}
+	if args.End == "" {
+		args.End = "+"
+	}
+
+	pending, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]caches.XPendingExt, error) {
+		start, err := parseRangeStart(args.Start)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := parseRangeEnd(args.End)
+		if err != nil {
+			return nil, err
+		}
+
+		kid, _, err := streamGroup(tx, key, group)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UnixMilli()
+		rows, err := tx.Query(`select ms, seq, consumer, delivered_at, delivery_count from caches_stream_pending
+			where kid = ? and grp = ? and (ms, seq) >= (?, ?) and (ms, seq) <= (?, ?)
+			and (? = '' or consumer = ?) and delivered_at <= ?
+			order by ms, seq limit ?`,
+			kid, group, start.ms, start.seq, end.ms, end.seq,
+			args.Consumer, args.Consumer, now-args.Idle.Milliseconds(), max(args.Count, 0))
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		pending := make([]caches.XPendingExt, 0)
+		for rows.Next() {
+			var id streamID
+			var entry caches.XPendingExt
+			var deliveredAt int64
+			if err := rows.Scan(&id.ms, &id.seq, &entry.Consumer, &deliveredAt, &entry.RetryCount); err != nil {
+				return nil, err
+			}
+
+			entry.ID = id.String()
+			entry.Idle = time.Duration(now-deliveredAt) * time.Millisecond
+			pending = append(pending, entry)
+		}
+		return pending, rows.Err()
+	})
+	return newResult(pending, err)
+}
+
+// xRange returns up to count entries with an ID between start and end, a negative count returning all of them.
+func (p *Provider) xRange(ctx context.Context, key, start, end string, count int64, rev bool) caches.Result[[]caches.XMessage] {
+	key = p.prefix + key
+	msgs, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]caches.XMessage, error) {
+		startID, err := parseRangeStart(start)
+		if err != nil {
+			return nil, err
+		}
+
+		endID, err := parseRangeEnd(end)
+		if err != nil {
+			return nil, err
+		}
+
+		kid, err := streamKeyID(tx, key)
+		if err == rdk.ErrNotFound {
+			return make([]caches.XMessage, 0), nil
+		} else if err != nil {
+			return nil, err
+		}
+		return rangeStreamEntries(tx, kid, startID, endID, rev, count)
+	})
+	return newResult(msgs, err)
+}
+
+// XRange implements caches.StreamCommand.
+func (p *Provider) XRange(ctx context.Context, key, start, end string) caches.Result[[]caches.XMessage] {
+	return p.xRange(ctx, key, start, end, -1, false)
+}
+
+// XRangeN implements caches.StreamCommand.
+func (p *Provider) XRangeN(ctx context.Context, key, start, end string, count int64) caches.Result[[]caches.XMessage] {
+	return p.xRange(ctx, key, start, end, max(count, 0), false)
+}
+
+// XRead implements caches.StreamCommand.
+func (p *Provider) XRead(ctx context.Context, args caches.XReadArgs) caches.Result[[]caches.XStream] {
+	if len(args.Streams) != len(args.IDs) {
+		return newResult[[]caches.XStream](nil, errStreamArgs)
+	}
+
+	limit := args.Count
+	if limit <= 0 {
+		limit = -1
+	}
+
+	keys := prefixKeys(p.prefix, args.Streams)
+
+	// `$` 在调用时解析，阻塞期间新增的条目才能被读取
+	ids, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]streamID, error) {
+		ids := make([]streamID, len(keys))
+		for i, s := range args.IDs {
+			if s != "$" {
+				id, err := parseStreamID(s, 0)
+				if err != nil {
+					return nil, err
+				}
+				ids[i] = id
+				continue
+			}
+
+			kid, err := streamKeyID(tx, keys[i])
+			if err == rdk.ErrNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			if ids[i], err = streamLastID(tx, kid); err != nil {
+				return nil, err
+			}
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return newResult[[]caches.XStream](nil, err)
+	}
+
+	streams, err := readStreams(ctx, p.waiter, args.Block, func() ([]caches.XStream, error) {
+		return streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]caches.XStream, error) {
+			var streams []caches.XStream
+			for i, key := range keys {
+				start, ok := ids[i].next()
+				if !ok {
+					continue
+				}
+
+				kid, err := streamKeyID(tx, key)
+				if err == rdk.ErrNotFound {
+					continue
+				} else if err != nil {
+					return nil, err
+				}
+
+				msgs, err := rangeStreamEntries(tx, kid, start, maxStreamID, false, limit)
+				if err != nil {
+					return nil, err
+				}
+				if len(msgs) > 0 {
+					streams = append(streams, caches.XStream{Stream: args.Streams[i], Messages: msgs})
+				}
+			}
+
+			if len(streams) == 0 {
+				return nil, rdk.ErrNotFound
+			}
+			return streams, nil
+		})
+	})
+	return newResult(streams, err)
+}
+
+// XReadGroup implements caches.StreamCommand.
+func (p *Provider) XReadGroup(ctx context.Context, args caches.XReadGroupArgs) caches.Result[[]caches.XStream] {
+	if len(args.Streams) != len(args.IDs) {
+		return newResult[[]caches.XStream](nil, errStreamArgs)
+	}
+
+	limit := args.Count
+	if limit <= 0 {
+		limit = -1
+	}
+
+	keys := prefixKeys(p.prefix, args.Streams)
+	streams, err := readStreams(ctx, p.waiter, args.Block, func() ([]caches.XStream, error) {
+		return streamAndReturn(ctx, p.streams, func(tx *sql.Tx) ([]caches.XStream, error) {
+			now := time.Now().UnixMilli()
+
+			var streams []caches.XStream
+			for i, key := range keys {
+				kid, last, err := streamGroup(tx, key, args.Group)
+				if err != nil {
+					return nil, err
+				}
+
+				// 指定 ID 时读取该消费者的待确认条目，已删除的条目没有字段值
+				if args.IDs[i] != ">" {
+					id, err := parseStreamID(args.IDs[i], 0)
+					if err != nil {
+						return nil, err
+					}
+
+					start, _ := id.next()
+					msgs, err := queryStreamEntries(tx, `select p.ms, p.seq, e.fields from caches_stream_pending p
+						left join caches_stream_entry e on e.kid = p.kid and e.ms = p.ms and e.seq = p.seq
+						where p.kid = ? and p.grp = ? and p.consumer = ? and (p.ms, p.seq) >= (?, ?)
+						order by p.ms, p.seq limit ?`,
+						kid, args.Group, args.Consumer, start.ms, start.seq, limit)
+					if err != nil {
+						return nil, err
+					}
+					streams = append(streams, caches.XStream{Stream: args.Streams[i], Messages: msgs})
+					continue
+				}
+
+				start, ok := last.next()
+				if !ok {
+					continue
+				}
+
+				msgs, err := rangeStreamEntries(tx, kid, start, maxStreamID, false, limit)
+				if err != nil {
+					return nil, err
+				}
+				if len(msgs) == 0 {
+					continue
+				}
+
+				delivered, err := parseStreamID(msgs[len(msgs)-1].ID, 0)
+				if err != nil {
+					return nil, err
+				}
+
+				_, err = tx.Exec(`update caches_stream_group set last_ms = ?, last_seq = ? where kid = ? and name = ?`,
+					delivered.ms, delivered.seq, kid, args.Group)
+				if err != nil {
+					return nil, err
+				}
+				if err := touchStreamKey(tx, kid); err != nil {
+					return nil, err
+				}
+
+				if !args.NoAck {
+					for _, msg := range msgs {
+						id, err := parseStreamID(msg.ID, 0)
+						if err != nil {
+							return nil, err
+						}
+
+						_, err = tx.Exec(`insert into caches_stream_pending
+							(kid, grp, ms, seq, consumer, delivered_at, delivery_count) values (?, ?, ?, ?, ?, ?, 1)
+							on conflict (kid, grp, ms, seq) do update set consumer = excluded.consumer,
+							delivered_at = excluded.delivered_at, delivery_count = delivery_count + 1`,
+							kid, args.Group, id.ms, id.seq, args.Consumer, now)
+						if err != nil {
+							return nil, err
+						}
+					}
+				}
+
+				streams = append(streams, caches.XStream{Stream: args.Streams[i], Messages: msgs})
+			}
+
+			if len(streams) == 0 {
+				return nil, rdk.ErrNotFound
+			}
+			return streams, nil
+		})
+	})
+	return newResult(streams, err)
+}
+
+// XRevRange implements caches.StreamCommand.
+func (p *Provider) XRevRange(ctx context.Context, key, end, start string) caches.Result[[]caches.XMessage] {
+	return p.xRange(ctx, key, start, end, -1, true)
+}
+
+// XRevRangeN implements caches.StreamCommand.
+func (p *Provider) XRevRangeN(ctx context.Context, key, end, start string, count int64) caches.Result[[]caches.XMessage] {
+	return p.xRange(ctx, key, start, end, max(count, 0), true)
+}
+
+// trimStream removes the entries of the stream selected by trim, returning the number of removed entries.
+func trimStream(tx *sql.Tx, key string, trim func(kid int64) (int64, error)) (int64, error) {
+	kid, err := streamKeyID(tx, key)
+	if err == rdk.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	n, err := trim(kid)
+	if err != nil || n == 0 {
+		return n, err
+	}
+	return n, touchStreamKey(tx, kid)
+}
+
+// XTrimMaxLen implements caches.StreamCommand.
+func (p *Provider) XTrimMaxLen(ctx context.Context, key string, maxLen int64) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (int64, error) {
+		return trimStream(tx, key, func(kid int64) (int64, error) {
+			return trimStreamMaxLen(tx, kid, maxLen)
+		})
+	})
+	return newResult(n, err)
+}
+
+// XTrimMinID implements caches.StreamCommand.
+func (p *Provider) XTrimMinID(ctx context.Context, key string, minID string) caches.Result[int64] {
+	key = p.prefix + key
+	n, err := streamAndReturn(ctx, p.streams, func(tx *sql.Tx) (int64, error) {
+		id, err := parseStreamID(minID, 0)
+		if err != nil {
+			return 0, err
+		}
+
+		return trimStream(tx, key, func(kid int64) (int64, error) {
+			return trimStreamMinID(tx, kid, id)
+		})
+	})
+	return newResult(n, err)
+}
@@ -7,10 +7,63 @@ import (
 
 	rdk "github.com/nalgeon/redka"
 	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/internal/str"
 )
 
 var _ caches.StringCommand = (*Provider)(nil)
 
+// getString returns the string stored at key, and false if the key does not exist.
+// Unlike Str().Get, it returns rdk.ErrKeyType when the key holds another type.
+func getString(tx *rdk.Tx, key string) ([]byte, bool, error) {
+	val, err := tx.Str().Get(key)
+	if err == nil {
+		return val.Bytes(), true, nil
+	} else if err != rdk.ErrNotFound {
+		return nil, false, err
+	}
+
+	k, err := tx.Key().Get(key)
+	if err != nil && err != rdk.ErrNotFound {
+		return nil, false, err
+	}
+	if k.Exists() {
+		return nil, false, rdk.ErrKeyType
+	}
+	return nil, false, nil
+}
+
+// setString stores b as the string value of key, keeping the expiration of an existing key.
+func setString(tx *rdk.Tx, key string, b []byte, exists bool) error {
+	if !exists {
+		// 过期的键仍保留其过期时间, 需要用 Set 重新设置
+		return tx.Str().Set(key, b)
+	}
+	_, err := tx.Str().SetWith(key, b).KeepTTL().Run()
+	return err
+}
+
+// Append implements caches.StringCommand.
+func (p *Provider) Append(ctx context.Context, key string, value string) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		cur, exists, err := getString(tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		b, err := str.Append(cur, value)
+		if err != nil {
+			return 0, err
+		}
+		if err := setString(tx, key, b, exists); err != nil {
+			return 0, err
+		}
+		return int64(len(b)), nil
+	})
+
+	return newResult(val, err)
+}
+
 func (p *Provider) incr(ctx context.Context, key string, value int) caches.Result[int64] {
 	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int, error) {
 		return tx.Str().Incr(key, value)
@@ -45,6 +98,70 @@ func (p *Provider) Get(ctx context.Context, key string) caches.Result[[]byte] {
 	return newResult(val, err)
 }
 
+// GetDel implements caches.StringCommand.
+func (p *Provider) GetDel(ctx context.Context, key string) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
+		val, exists, err := getString(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, caches.Nil
+		}
+
+		_, err = tx.Key().Delete(key)
+		return val, err
+	})
+
+	return newResult(val, err)
+}
+
+// GetEx implements caches.StringCommand.
+func (p *Provider) GetEx(ctx context.Context, key string, args caches.GetExArgs) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
+		val, exists, err := getString(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, caches.Nil
+		}
+
+		switch {
+		case args.TTL > 0:
+			err = tx.Key().Expire(key, args.TTL)
+		case !args.ExpireAt.IsZero():
+			if !args.ExpireAt.After(time.Now()) {
+				// 过期时间已过时删除键, 与 Redis 一致
+				_, err = tx.Key().Delete(key)
+			} else {
+				err = tx.Key().ExpireAt(key, args.ExpireAt)
+			}
+		case args.Persist:
+			err = tx.Key().Persist(key)
+		}
+		return val, err
+	})
+
+	return newResult(val, err)
+}
+
+// GetRange implements caches.StringCommand.
+func (p *Provider) GetRange(ctx context.Context, key string, start, end int64) caches.Result[[]byte] {
+	key = p.prefix + key
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]byte, error) {
+		cur, _, err := getString(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		return str.GetRange(cur, start, end), nil
+	})
+
+	return newResult(val, err)
+}
+
 // Incr implements caches.StringCommand.
 func (p *Provider) Incr(ctx context.Context, key string) caches.Result[int64] {
 	key = p.prefix + key
@@ -71,6 +188,25 @@ func (p *Provider) IncrByFloat(ctx context.Context, key string, value float64) c
 	return p.incrFloat(ctx, key, value)
 }
 
+// LCS implements caches.StringCommand.
+func (p *Provider) LCS(ctx context.Context, q *caches.LCSQuery) caches.Result[*caches.LCSMatch] {
+	key1 := p.prefix + q.Key1
+	key2 := p.prefix + q.Key2
+	val, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) (*caches.LCSMatch, error) {
+		a, _, err := getString(tx, key1)
+		if err != nil {
+			return nil, err
+		}
+		b, _, err := getString(tx, key2)
+		if err != nil {
+			return nil, err
+		}
+		return str.LCS(a, b, q)
+	})
+
+	return newResult(val, err)
+}
+
 // Set implements caches.StringCommand.
 func (p *Provider) Set(ctx context.Context, key string, value any, expiration time.Duration) caches.StatusResult {
 	key = p.prefix + key
@@ -103,6 +239,32 @@ func (p *Provider) SetNX(ctx context.Context, key string, value any, expiration
 	return newResult(ok, err)
 }
 
+// SetRange implements caches.StringCommand.
+func (p *Provider) SetRange(ctx context.Context, key string, offset int64, value string) caches.Result[int64] {
+	key = p.prefix + key
+	val, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (int64, error) {
+		cur, exists, err := getString(tx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		b, err := str.SetRange(cur, offset, value)
+		if err != nil {
+			return 0, err
+		}
+		// 空值既不修改也不创建键
+		if len(value) == 0 {
+			return int64(len(cur)), nil
+		}
+		if err := setString(tx, key, b, exists); err != nil {
+			return 0, err
+		}
+		return int64(len(b)), nil
+	})
+
+	return newResult(val, err)
+}
+
 // SetXX implements caches.StringCommand.
 func (p *Provider) SetXX(ctx context.Context, key string, value any, expiration time.Duration) caches.Result[bool] {
 	key = p.prefix + key
@@ -243,29 +405,29 @@ func (p *Provider) MSetNX(ctx context.Context, values map[string]any) caches.Res
 		prefixedValues[p.prefix+key] = value
 	}
 
-	err := p.db.UpdateContext(ctx, func(tx *rdk.Tx) error {
-		// 使用 SetArgs 设置所有键，如果任何键已存在则失败
+	keys := make([]string, 0, len(prefixedValues))
+	for key := range prefixedValues {
+		keys = append(keys, key)
+	}
+
+	ok, err := updateAndReturn(ctx, p.db, func(tx *rdk.Tx) (bool, error) {
+		// 先检查所有键，任何键已存在则不做任何修改
+		// 不依赖事务回滚，以便在流水线的共享事务中也能正确执行
+		n, err := tx.Key().Count(keys...)
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return false, nil
+		}
+
 		for key, value := range prefixedValues {
-			set := tx.Str().SetWith(key, value).IfNotExists()
-			res, err := set.Run()
-			if err != nil {
-				return err
-			}
-			// 如果没有创建成功（键已存在），返回错误以回滚事务
-			if !res.Created {
-				return rdk.ErrNotFound
+			if err := tx.Str().Set(key, value); err != nil {
+				return false, err
 			}
 		}
-		return nil
+		return true, nil
 	})
 
-	if err == rdk.ErrNotFound {
-		// 有键已存在，返回 false
-		return newResult(false, nil)
-	} else if err != nil {
-		// 其他错误
-		return newResult(false, err)
-	}
-
-	return newResult(true, nil)
+	return newResult(ok, err)
 }
@@ -0,0 +1,142 @@
+package redka
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultSweepBatchSize  = 100
+	defaultSweepMaxRuntime = 50 * time.Millisecond
+)
+
+// sweeper removes expired keys in the background.
+// Redka only removes expired keys when they are accessed,
+// so they are still counted by DBSize and take disk space until then.
+type sweeper struct {
+	db     *sql.DB
+	prefix string
+
+	interval   time.Duration
+	batchSize  int
+	maxRuntime time.Duration
+	onExpire   func(keys []string)
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func newSweeper(db *sql.DB, prefix string, opts *Options) *sweeper {
+	s := &sweeper{
+		db:         db,
+		prefix:     prefix,
+		interval:   opts.SweepInterval,
+		batchSize:  opts.SweepBatchSize,
+		maxRuntime: opts.SweepMaxRuntime,
+		onExpire:   opts.OnExpire,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	if s.batchSize <= 0 {
+		s.batchSize = defaultSweepBatchSize
+	}
+	if s.maxRuntime <= 0 {
+		s.maxRuntime = defaultSweepMaxRuntime
+	}
+
+	go s.run()
+	return s
+}
+
+func (s *sweeper) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.sweep()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// sweep removes batches of expired keys until none is left or the max runtime is exceeded.
+// Errors are ignored, the remaining keys are removed on the next tick.
+func (s *sweeper) sweep() {
+	deadline := time.Now().Add(s.maxRuntime)
+	for {
+		keys, err := s.deleteExpired()
+		if err != nil {
+			return
+		}
+
+		if len(keys) > 0 && s.onExpire != nil {
+			s.onExpire(keys)
+		}
+
+		if len(keys) < s.batchSize || time.Now().After(deadline) {
+			return
+		}
+
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+	}
+}
+
+// deleteExpired removes up to batchSize expired keys with the provider prefix.
+// Returns the removed keys without the prefix.
+func (s *sweeper) deleteExpired() ([]string, error) {
+	ctx := context.Background()
+
+	// 删除 rkey 中的键会级联删除对应的值，需要在启用外键的连接上执行
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := enableForeignKeys(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, `delete from rkey where rowid in (
+		select rowid from rkey
+		where etime <= ? and substr(key, 1, ?) = ?
+		limit ?
+	) returning key`,
+		time.Now().UnixMilli(), utf8.RuneCountInString(s.prefix), s.prefix, s.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key[len(s.prefix):])
+	}
+	return keys, rows.Err()
+}
+
+// close stops the sweeper and waits for the running sweep to complete.
+func (s *sweeper) close() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
@@ -0,0 +1,105 @@
+package redka
+
+import (
+	"context"
+
+	rdk "github.com/nalgeon/redka"
+	"github.com/rockcookies/go-caches"
+)
+
+var _ caches.TxCommand = (*Provider)(nil)
+
+// watchTx implements caches.Tx by comparing the versions of the watched keys
+// with the ones observed when Watch was called.
+type watchTx struct {
+	*Provider
+	keys     []string
+	versions []rdk.Key
+}
+
+var _ caches.Tx = (*watchTx)(nil)
+
+// watchKeys returns the current state of keys.
+// Missing or expired keys are reported as an empty rdk.Key.
+func watchKeys(tx *rdk.Tx, keys []string) ([]rdk.Key, error) {
+	versions := make([]rdk.Key, len(keys))
+	for i, key := range keys {
+		k, err := tx.Key().Get(key)
+		if err != nil && err != rdk.ErrNotFound {
+			return nil, err
+		}
+		versions[i] = k
+	}
+	return versions, nil
+}
+
+// keysChanged reports whether any key was created, deleted or modified.
+func keysChanged(prev, cur []rdk.Key) bool {
+	for i := range prev {
+		p, c := prev[i], cur[i]
+		if p.ID != c.ID || p.Version != c.Version || p.MTime != c.MTime || !sameETime(p.ETime, c.ETime) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameETime(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// TxPipeline implements caches.TxCommand.
+func (p *Provider) TxPipeline() caches.TxPipeliner {
+	return p.newPipeline(nil)
+}
+
+// TxPipelined implements caches.TxCommand.
+func (p *Provider) TxPipelined(ctx context.Context, fn func(pipe caches.TxPipeliner) error) error {
+	return txPipelined(ctx, p.TxPipeline(), fn)
+}
+
+// Watch implements caches.TxCommand.
+func (p *Provider) Watch(ctx context.Context, fn func(tx caches.Tx) error, keys ...string) error {
+	keys = prefixKeys(p.prefix, keys)
+	versions, err := viewAndReturn(ctx, p.db, func(tx *rdk.Tx) ([]rdk.Key, error) {
+		return watchKeys(tx, keys)
+	})
+	if err != nil {
+		return err
+	}
+
+	return fn(&watchTx{
+		Provider: p,
+		keys:     keys,
+		versions: versions,
+	})
+}
+
+// TxPipeline implements caches.Tx.
+func (t *watchTx) TxPipeline() caches.TxPipeliner {
+	return t.newPipeline(func(tx *rdk.Tx) error {
+		cur, err := watchKeys(tx, t.keys)
+		if err != nil {
+			return err
+		}
+		if keysChanged(t.versions, cur) {
+			return caches.ErrTxFailed
+		}
+		return nil
+	})
+}
+
+// TxPipelined implements caches.Tx.
+func (t *watchTx) TxPipelined(ctx context.Context, fn func(pipe caches.TxPipeliner) error) error {
+	return txPipelined(ctx, t.TxPipeline(), fn)
+}
+
+func txPipelined(ctx context.Context, pipe caches.TxPipeliner, fn func(pipe caches.TxPipeliner) error) error {
+	if err := fn(pipe); err != nil {
+		return err
+	}
+	return pipe.Exec(ctx)
+}
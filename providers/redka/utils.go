@@ -2,6 +2,8 @@ package redka
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	rdk "github.com/nalgeon/redka"
@@ -41,7 +43,25 @@ func prefixKeys(prefix string, keys []string) []string {
 	return prefixed
 }
 
-func viewAndReturn[T any](ctx context.Context, db *rdk.DB, cb func(tx *rdk.Tx) (T, error)) (res T, err error) {
+// globPrefix returns a glob pattern matching the keys starting with prefix.
+// Special characters of the prefix are matched literally by enclosing them in brackets.
+func globPrefix(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		switch r {
+		case '*', '?', '[':
+			b.WriteByte('[')
+			b.WriteRune(r)
+			b.WriteByte(']')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('*')
+	return b.String()
+}
+
+func viewAndReturn[T any](ctx context.Context, db database, cb func(tx *rdk.Tx) (T, error)) (res T, err error) {
 	err = db.ViewContext(ctx, func(tx *rdk.Tx) (e error) {
 		res, e = cb(tx)
 		return
@@ -49,7 +69,7 @@ func viewAndReturn[T any](ctx context.Context, db *rdk.DB, cb func(tx *rdk.Tx) (
 	return
 }
 
-func updateAndReturn[T any](ctx context.Context, db *rdk.DB, cb func(tx *rdk.Tx) (T, error)) (res T, err error) {
+func updateAndReturn[T any](ctx context.Context, db database, cb func(tx *rdk.Tx) (T, error)) (res T, err error) {
 	err = db.UpdateContext(ctx, func(tx *rdk.Tx) (e error) {
 		res, e = cb(tx)
 		return
@@ -70,3 +90,24 @@ func formatSec(dur time.Duration) int64 {
 	}
 	return int64(dur / time.Second)
 }
+
+// toBytes converts a value to its stored representation,
+// following the same rules as Redka for stored values.
+func toBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), nil
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	return nil, rdk.ErrValueType
+}
@@ -0,0 +1,108 @@
+package redka
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	rdk "github.com/nalgeon/redka"
+)
+
+// blockPollInterval bounds how long a blocked command sleeps before checking
+// the database again, so that writes made by other processes are noticed too.
+const blockPollInterval = 100 * time.Millisecond
+
+// waiter wakes up blocked commands when an update is committed through the provider.
+type waiter struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newWaiter() *waiter {
+	return &waiter{}
+}
+
+// wait returns a channel that is closed by the next notify.
+func (w *waiter) wait() <-chan struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ch == nil {
+		w.ch = make(chan struct{})
+	}
+	return w.ch
+}
+
+// notify wakes up all pending waits.
+func (w *waiter) notify() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ch != nil {
+		close(w.ch)
+		w.ch = nil
+	}
+}
+
+// notifyDB notifies the waiter after each committed update.
+type notifyDB struct {
+	*rdk.DB
+
+	// sql is the read-write database of Redka, its foreign keys are enabled before each update
+	sql    *sql.DB
+	waiter *waiter
+}
+
+// UpdateContext implements database.
+func (d notifyDB) UpdateContext(ctx context.Context, f func(tx *rdk.Tx) error) error {
+	if d.sql != nil {
+		// Redka uses a single read-write connection, which may have been replaced since the last update
+		if err := enableForeignKeys(ctx, d.sql); err != nil {
+			return err
+		}
+	}
+
+	err := d.DB.UpdateContext(ctx, f)
+	if err == nil {
+		d.waiter.notify()
+	}
+	return err
+}
+
+// block calls pop until it returns something other than rdk.ErrNotFound,
+// the timeout expires or ctx is done. A zero timeout blocks indefinitely.
+// Without a waiter, as within a transaction, pop is only called once.
+func block[T any](ctx context.Context, w *waiter, timeout time.Duration, pop func() (T, error)) (T, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	poll := time.NewTicker(blockPollInterval)
+	defer poll.Stop()
+
+	for {
+		// 先获取通知通道再尝试弹出，避免错过两者之间提交的写入
+		var wake <-chan struct{}
+		if w != nil {
+			wake = w.wait()
+		}
+
+		val, err := pop()
+		if err != rdk.ErrNotFound || w == nil {
+			return val, err
+		}
+
+		select {
+		case <-wake:
+		case <-poll.C:
+		case <-expired:
+			return val, rdk.ErrNotFound
+		case <-ctx.Done():
+			return val, ctx.Err()
+		}
+	}
+}
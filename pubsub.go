@@ -0,0 +1,271 @@
+package caches
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rockcookies/go-caches/internal/glob"
+)
+
+// messageChannelSize is the buffer size of the channel returned by Subscription.Channel.
+const messageChannelSize = 100
+
+// messageSendTimeout is how long Broker.Publish waits for room in the channel of a subscription
+// before dropping the message for it, so that a subscriber that stopped reading does not block publishers.
+const messageSendTimeout = time.Minute
+
+// Message is a message received by a Subscription.
+type Message struct {
+	// Channel is the channel the message was published to.
+	Channel string
+	// Pattern is the matching pattern for messages received through PSubscribe, empty otherwise.
+	Pattern string
+	// Payload is the published message.
+	Payload []byte
+}
+
+// Subscription receives the messages published to its channels and patterns.
+type Subscription interface {
+	// Subscribe adds channels to the subscription.
+	Subscribe(ctx context.Context, channels ...string) error
+
+	// PSubscribe adds glob-style patterns to the subscription.
+	PSubscribe(ctx context.Context, patterns ...string) error
+
+	// Unsubscribe removes channels from the subscription, or all channels when none are given.
+	Unsubscribe(ctx context.Context, channels ...string) error
+
+	// PUnsubscribe removes patterns from the subscription, or all patterns when none are given.
+	PUnsubscribe(ctx context.Context, patterns ...string) error
+
+	// Channel returns the channel of received messages.
+	// It is closed when the subscription is closed.
+	Channel() <-chan *Message
+
+	// Close closes the subscription.
+	Close() error
+}
+
+// PubSub defines publish/subscribe operations.
+// Channels are namespaced with the provider prefix, like keys.
+type PubSub interface {
+	// Publish posts message to channel.
+	// Returns the number of subscribers that received the message.
+	Publish(ctx context.Context, channel string, message any) Result[int64]
+
+	// Subscribe subscribes to channels.
+	// Messages published once Subscribe has returned are delivered to the subscription.
+	Subscribe(ctx context.Context, channels ...string) Subscription
+
+	// PSubscribe subscribes to glob-style patterns.
+	// Messages published once PSubscribe has returned are delivered to the subscription.
+	PSubscribe(ctx context.Context, patterns ...string) Subscription
+}
+
+// Broker is an in-process message broker.
+// This is intended to be used by providers without native publish/subscribe support,
+// so that subscribers of the same provider receive the messages it publishes.
+type Broker struct {
+	mu   sync.RWMutex
+	subs map[*brokerSubscription]struct{}
+}
+
+// NewBroker creates a new Broker.
+func NewBroker() *Broker {
+	return &Broker{
+		subs: make(map[*brokerSubscription]struct{}),
+	}
+}
+
+// Publish delivers payload to all subscriptions matching channel.
+// It blocks while the channel of a matching subscription is full, until ctx is done or for up to
+// a minute, after which the message is dropped for that subscription.
+// Returns the number of delivered messages, and the error of ctx if it is done before all are delivered.
+func (b *Broker) Publish(ctx context.Context, channel string, payload []byte) (int64, error) {
+	b.mu.RLock()
+	subs := make([]*brokerSubscription, 0, len(b.subs))
+	for sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.mu.RUnlock()
+
+	var n int64
+	for _, sub := range subs {
+		for _, msg := range sub.match(channel, payload) {
+			ok, err := sub.deliver(ctx, msg)
+			if err != nil {
+				return n, err
+			}
+			if ok {
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// Subscribe creates a subscription to channels.
+func (b *Broker) Subscribe(channels ...string) Subscription {
+	sub := b.newSubscription()
+	sub.add(sub.channels, channels)
+	return sub
+}
+
+// PSubscribe creates a subscription to glob-style patterns.
+func (b *Broker) PSubscribe(patterns ...string) Subscription {
+	sub := b.newSubscription()
+	sub.add(sub.patterns, patterns)
+	return sub
+}
+
+func (b *Broker) newSubscription() *brokerSubscription {
+	sub := &brokerSubscription{
+		broker:   b,
+		channels: make(map[string]struct{}),
+		patterns: make(map[string]struct{}),
+		ch:       make(chan *Message, messageChannelSize),
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+type brokerSubscription struct {
+	broker *Broker
+
+	// mu guards channels and patterns
+	mu       sync.Mutex
+	channels map[string]struct{}
+	patterns map[string]struct{}
+
+	// sendMu guards ch against being closed during a delivery
+	sendMu sync.Mutex
+	ch     chan *Message
+	done   chan struct{}
+	once   sync.Once
+}
+
+var _ Subscription = (*brokerSubscription)(nil)
+
+// match returns the messages to deliver for a publication to channel.
+func (s *brokerSubscription) match(channel string, payload []byte) []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msgs []*Message
+	if _, ok := s.channels[channel]; ok {
+		msgs = append(msgs, &Message{Channel: channel, Payload: payload})
+	}
+	for pattern := range s.patterns {
+		if glob.Match(pattern, channel) {
+			msgs = append(msgs, &Message{Channel: channel, Pattern: pattern, Payload: payload})
+		}
+	}
+	return msgs
+}
+
+// deliver sends msg to the subscription channel and reports whether it was delivered.
+// The message is dropped when the subscription is closed or its channel stays full for messageSendTimeout,
+// and an error is returned when ctx is done first.
+func (s *brokerSubscription) deliver(ctx context.Context, msg *Message) (bool, error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	select {
+	case <-s.done:
+		return false, nil
+	default:
+	}
+
+	select {
+	case s.ch <- msg:
+		return true, nil
+	default:
+	}
+
+	timer := time.NewTimer(messageSendTimeout)
+	defer timer.Stop()
+
+	select {
+	case s.ch <- msg:
+		return true, nil
+	case <-s.done:
+		return false, nil
+	case <-timer.C:
+		return false, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (s *brokerSubscription) add(set map[string]struct{}, names []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+}
+
+func (s *brokerSubscription) remove(set map[string]struct{}, names []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(names) == 0 {
+		clear(set)
+		return
+	}
+	for _, name := range names {
+		delete(set, name)
+	}
+}
+
+// Subscribe implements Subscription.
+func (s *brokerSubscription) Subscribe(ctx context.Context, channels ...string) error {
+	s.add(s.channels, channels)
+	return nil
+}
+
+// PSubscribe implements Subscription.
+func (s *brokerSubscription) PSubscribe(ctx context.Context, patterns ...string) error {
+	s.add(s.patterns, patterns)
+	return nil
+}
+
+// Unsubscribe implements Subscription.
+func (s *brokerSubscription) Unsubscribe(ctx context.Context, channels ...string) error {
+	s.remove(s.channels, channels)
+	return nil
+}
+
+// PUnsubscribe implements Subscription.
+func (s *brokerSubscription) PUnsubscribe(ctx context.Context, patterns ...string) error {
+	s.remove(s.patterns, patterns)
+	return nil
+}
+
+// Channel implements Subscription.
+func (s *brokerSubscription) Channel() <-chan *Message {
+	return s.ch
+}
+
+// Close implements Subscription.
+func (s *brokerSubscription) Close() error {
+	s.once.Do(func() {
+		s.broker.mu.Lock()
+		delete(s.broker.subs, s)
+		s.broker.mu.Unlock()
+
+		// Release pending deliveries before closing the channel
+		close(s.done)
+		s.sendMu.Lock()
+		close(s.ch)
+		s.sendMu.Unlock()
+	})
+	return nil
+}
@@ -0,0 +1,136 @@
+// Package ratelimit provides rate limiters on top of the cache commands.
+//
+// The state of the limits is stored in the cache and updated atomically by the providers,
+// so that the limits are shared by all the processes using the same cache.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+// ErrInvalidLimit is returned when a limit or a number of requests is not valid.
+var ErrInvalidLimit = errors.New("ratelimit: invalid limit")
+
+// Client is the set of commands used by limiters, implemented by the providers.
+type Client interface {
+	caches.RateLimitCommand
+}
+
+// Result is the outcome of a rate limited request.
+type Result = caches.RateLimit
+
+// Algorithm is the rate limiting algorithm of a Limiter.
+type Algorithm int
+
+const (
+	// GCRA spaces requests evenly over the period, allowing bursts of up to Limit.Burst requests.
+	// It stores a single timestamp per key.
+	GCRA Algorithm = iota
+
+	// FixedWindow allows Limit.Rate requests per period, starting with the first request.
+	// It stores a single counter per key, but allows up to twice the rate around the end of a period.
+	FixedWindow
+
+	// SlidingLog allows Limit.Rate requests within any period.
+	// It is exact, but stores the time of every allowed request.
+	SlidingLog
+)
+
+// Limit is a number of requests allowed per period.
+type Limit struct {
+	// Rate is the number of requests allowed per Period.
+	Rate int64
+
+	// Period is the duration over which Rate requests are allowed.
+	Period time.Duration
+
+	// Burst is the number of requests GCRA allows at once, Rate by default.
+	// It is ignored by the other algorithms.
+	Burst int64
+}
+
+// PerSecond returns a Limit allowing rate requests per second.
+func PerSecond(rate int64) Limit {
+	return Limit{Rate: rate, Period: time.Second}
+}
+
+// PerMinute returns a Limit allowing rate requests per minute.
+func PerMinute(rate int64) Limit {
+	return Limit{Rate: rate, Period: time.Minute}
+}
+
+// PerHour returns a Limit allowing rate requests per hour.
+func PerHour(rate int64) Limit {
+	return Limit{Rate: rate, Period: time.Hour}
+}
+
+// Options configures a Limiter.
+type Options struct {
+	// Prefix is prepended to the limited keys, "ratelimit:" by default.
+	Prefix string
+
+	// Algorithm is the rate limiting algorithm, GCRA by default.
+	Algorithm Algorithm
+}
+
+// Limiter limits the rate of requests per key.
+type Limiter struct {
+	client    Client
+	prefix    string
+	algorithm Algorithm
+}
+
+// New returns a Limiter storing its state with client.
+func New(client Client, opts *Options) *Limiter {
+	if client == nil {
+		panic("client is nil")
+	}
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	prefix := strings.TrimSpace(opts.Prefix)
+	if prefix == "" {
+		prefix = "ratelimit:"
+	}
+
+	return &Limiter{
+		client:    client,
+		prefix:    prefix,
+		algorithm: opts.Algorithm,
+	}
+}
+
+// Allow reports whether a request for key is allowed by limit, counting it if so.
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	return l.AllowN(ctx, key, limit, 1)
+}
+
+// AllowN reports whether n requests for key are allowed by limit, counting them if so.
+// Denied requests are not counted, and n = 0 returns the state of the limit.
+func (l *Limiter) AllowN(ctx context.Context, key string, limit Limit, n int64) (Result, error) {
+	if limit.Rate <= 0 || limit.Period <= 0 || limit.Burst < 0 || n < 0 {
+		return Result{}, ErrInvalidLimit
+	}
+
+	key = l.prefix + key
+	switch l.algorithm {
+	case FixedWindow:
+		return l.client.RateLimitFixedWindow(ctx, key, limit.Rate, limit.Period, n).Result()
+	case SlidingLog:
+		return l.client.RateLimitSlidingLog(ctx, key, limit.Rate, limit.Period, n).Result()
+	case GCRA:
+		burst := limit.Burst
+		if burst == 0 {
+			burst = limit.Rate
+		}
+		return l.client.RateLimitGCRA(ctx, key, limit.Rate, limit.Period, burst, n).Result()
+	}
+	return Result{}, errors.New("ratelimit: unknown algorithm")
+}
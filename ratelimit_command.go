@@ -0,0 +1,39 @@
+package caches
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit is the outcome of a rate limited request.
+type RateLimit struct {
+	// Allowed reports whether the request is allowed.
+	Allowed bool
+
+	// Remaining is the number of requests still allowed after this one.
+	Remaining int64
+
+	// RetryAfter is the time to wait before the request could be allowed, zero when it is allowed,
+	// and -1 when the request exceeds the limit and can never be allowed.
+	RetryAfter time.Duration
+}
+
+// RateLimitCommand defines rate limiting operations, each counting n requests against key
+// and allowing them only if they fit in the limit. Denied requests are not counted,
+// and n = 0 returns the state of the limit without counting anything.
+// The state is read and updated atomically, so that concurrent clients share the same limit.
+// The limits, bursts and durations must be positive.
+type RateLimitCommand interface {
+	// RateLimitFixedWindow allows limit requests per window, counted in a string key.
+	// A window starts with its first request and the key expires with it.
+	RateLimitFixedWindow(ctx context.Context, key string, limit int64, window time.Duration, n int64) Result[RateLimit]
+
+	// RateLimitSlidingLog allows limit requests within any window, logging the time of each request
+	// in a key expiring with the last request. It is exact but stores one entry per allowed request.
+	RateLimitSlidingLog(ctx context.Context, key string, limit int64, window time.Duration, n int64) Result[RateLimit]
+
+	// RateLimitGCRA allows limit requests per period with the generic cell rate algorithm,
+	// spacing them evenly while allowing bursts of up to burst requests.
+	// The theoretical arrival time of the next request is stored in a string key.
+	RateLimitGCRA(ctx context.Context, key string, limit int64, period time.Duration, burst, n int64) Result[RateLimit]
+}
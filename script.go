@@ -0,0 +1,61 @@
+package caches
+
+import (
+	"context"
+	"errors"
+)
+
+// Script is a server-side script, run with EvalSha and loaded on demand.
+type Script struct {
+	src  string
+	hash string
+}
+
+// NewScript returns a Script running src.
+func NewScript(src string) *Script {
+	return &Script{
+		src:  src,
+		hash: ScriptHash(src),
+	}
+}
+
+// Source returns the source of the script,
+// under which its Go implementation is registered for providers without Lua.
+func (s *Script) Source() string {
+	return s.src
+}
+
+// Hash returns the SHA1 digest of the script.
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// Load loads the script without running it.
+func (s *Script) Load(ctx context.Context, c ScriptCommand) Result[string] {
+	return c.ScriptLoad(ctx, s.src)
+}
+
+// Exists reports whether the script is loaded.
+func (s *Script) Exists(ctx context.Context, c ScriptCommand) Result[[]bool] {
+	return c.ScriptExists(ctx, s.hash)
+}
+
+// Eval runs the script by sending its source.
+func (s *Script) Eval(ctx context.Context, c ScriptCommand, keys []string, args ...any) Result[any] {
+	return c.Eval(ctx, s.src, keys, args...)
+}
+
+// EvalSha runs the script by its digest.
+func (s *Script) EvalSha(ctx context.Context, c ScriptCommand, keys []string, args ...any) Result[any] {
+	return c.EvalSha(ctx, s.hash, keys, args...)
+}
+
+// Run runs the script by its digest, and by sending its source when it is not loaded yet.
+// Within a pipeline the reply of EvalSha is unknown until Exec, so Eval must be used instead.
+func (s *Script) Run(ctx context.Context, c ScriptCommand, keys []string, args ...any) Result[any] {
+	res := s.EvalSha(ctx, c, keys, args...)
+	if errors.Is(res.Err(), ErrNoScript) {
+		return s.Eval(ctx, c, keys, args...)
+	}
+	return res
+}
@@ -0,0 +1,89 @@
+package caches
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"sync"
+)
+
+// ScriptCommand defines server-side scripting operations.
+// Keys are passed to the scripts with the provider prefix applied.
+//
+// Redis runs Lua scripts. Providers without Lua run Go functions registered with a ScriptRegistry
+// under the script source, within a single transaction.
+type ScriptCommand interface {
+	// Eval runs script with keys and args.
+	Eval(ctx context.Context, script string, keys []string, args ...any) Result[any]
+
+	// EvalSha runs the script whose SHA1 digest is sha1 with keys and args.
+	// Returns ErrNoScript if no script matches the digest.
+	EvalSha(ctx context.Context, sha1 string, keys []string, args ...any) Result[any]
+
+	// ScriptLoad loads script without running it and returns its SHA1 digest.
+	ScriptLoad(ctx context.Context, script string) Result[string]
+
+	// ScriptExists reports whether scripts matching the digests are loaded.
+	ScriptExists(ctx context.Context, hashes ...string) Result[[]bool]
+}
+
+// ScriptHash returns the SHA1 digest of script, as used by EvalSha.
+func ScriptHash(script string) string {
+	sum := sha1.Sum([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScriptFunc is the Go implementation of a script.
+// It runs within a transaction, c being bound to it, and receives the keys as passed to Eval:
+// the commands of c apply the provider prefix to them.
+// A nil result is reported as Nil, as a Lua script returning nil.
+type ScriptFunc func(ctx context.Context, c Cache, keys []string, args []any) (any, error)
+
+// ScriptRegistry holds the Go implementations of scripts.
+// This is intended to be used by providers without Lua support,
+// so that a script can have a Lua body for Redis and a Go body for them behind the same call site.
+type ScriptRegistry struct {
+	mu      sync.RWMutex
+	scripts map[string]ScriptFunc
+}
+
+// NewScriptRegistry creates a new ScriptRegistry.
+func NewScriptRegistry() *ScriptRegistry {
+	return &ScriptRegistry{
+		scripts: make(map[string]ScriptFunc),
+	}
+}
+
+// Register registers fn as the implementation of script,
+// which is either the Lua source of the script or a name only used with providers without Lua.
+func (r *ScriptRegistry) Register(script string, fn ScriptFunc) {
+	if fn == nil {
+		panic("fn is nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scripts[ScriptHash(script)] = fn
+}
+
+// Lookup returns the implementation of the script whose SHA1 digest is sha1.
+func (r *ScriptRegistry) Lookup(sha1 string) (ScriptFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.scripts[sha1]
+	return fn, ok
+}
+
+// Exists reports whether scripts matching the digests are registered.
+func (r *ScriptRegistry) Exists(hashes ...string) []bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	exists := make([]bool, len(hashes))
+	for i, h := range hashes {
+		_, exists[i] = r.scripts[h]
+	}
+	return exists
+}
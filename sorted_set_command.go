@@ -1,6 +1,9 @@
 package caches
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ZMember represents a member with its score in a sorted set.
 type ZMember struct {
@@ -43,6 +46,18 @@ type ZStore struct {
 // SortedSetCommand defines operations for Redis sorted set data structure.
 // Sorted sets (zsets) are collections of unique strings ordered by each string's associated score.
 type SortedSetCommand interface {
+	// BZPopMax is the blocking variant of ZPopMax, popping the member with the highest score
+	// of the first non-empty sorted set among keys, which are checked in the given order.
+	// When all sorted sets are empty, it blocks until a member is added, the timeout expires or ctx is done.
+	// A zero timeout blocks indefinitely. Returns Nil when the timeout expires.
+	BZPopMax(ctx context.Context, timeout time.Duration, keys ...string) Result[ZPopResult]
+
+	// BZPopMin is the blocking variant of ZPopMin, popping the member with the lowest score
+	// of the first non-empty sorted set among keys, which are checked in the given order.
+	// When all sorted sets are empty, it blocks until a member is added, the timeout expires or ctx is done.
+	// A zero timeout blocks indefinitely. Returns Nil when the timeout expires.
+	BZPopMin(ctx context.Context, timeout time.Duration, keys ...string) Result[ZPopResult]
+
 	// ZAdd adds one or more members with scores to a sorted set.
 	// If a member already exists, its score is updated.
 	// Returns the number of members added (not including members whose score was updated).
@@ -62,6 +77,18 @@ type SortedSetCommand interface {
 	// min and max can be inclusive or exclusive (use "(" prefix for exclusive).
 	ZCount(ctx context.Context, key string, min, max string) Result[int64]
 
+	// ZDiff returns the members of the first sorted set that are not in any of the following ones,
+	// ordered by ascending scores. Missing keys are treated as empty sorted sets.
+	ZDiff(ctx context.Context, keys ...string) Result[[][]byte]
+
+	// ZDiffWithScores returns the difference with the scores of the first sorted set.
+	ZDiffWithScores(ctx context.Context, keys ...string) Result[[]ZMember]
+
+	// ZDiffStore stores the difference of the sorted sets in a destination key,
+	// which is overwritten or deleted if the difference is empty.
+	// Returns the number of members in the resulting sorted set.
+	ZDiffStore(ctx context.Context, destination string, keys ...string) Result[int64]
+
 	// ZIncrBy increments the score of a member in a sorted set by increment.
 	// If the member does not exist, it is added with increment as its score.
 	// Returns the new score of the member.
@@ -78,6 +105,40 @@ type SortedSetCommand interface {
 	// Returns the number of members in the resulting sorted set.
 	ZInterStore(ctx context.Context, destination string, store ZStore) Result[int64]
 
+	// ZInterCard returns the number of members in the intersection of the sorted sets.
+	// A positive limit stops counting once it is reached; 0 means no limit.
+	ZInterCard(ctx context.Context, limit int64, keys ...string) Result[int64]
+
+	// ZLexCount returns the number of members in a sorted set between min and max in lexicographical order.
+	// min and max are "[" (inclusive) or "(" (exclusive) prefixed members, or "-" and "+" for the
+	// lowest and highest possible member. All members are expected to share the same score.
+	ZLexCount(ctx context.Context, key string, min, max string) Result[int64]
+
+	// ZMScore returns the scores of members in a sorted set, nil for the members that do not exist.
+	ZMScore(ctx context.Context, key string, members ...string) Result[[]*float64]
+
+	// ZMPop pops up to count members from the first non-empty sorted set among keys.
+	// order is "MIN" to pop the members with the lowest scores, or "MAX" for the highest.
+	// Returns Nil when all sorted sets are empty.
+	ZMPop(ctx context.Context, order string, count int64, keys ...string) Result[ZMPopResult]
+
+	// ZPopMax removes and returns up to count members with the highest scores in a sorted set,
+	// ordered by descending scores. Returns an empty slice if the key does not exist.
+	ZPopMax(ctx context.Context, key string, count int64) Result[[]ZMember]
+
+	// ZPopMin removes and returns up to count members with the lowest scores in a sorted set,
+	// ordered by ascending scores. Returns an empty slice if the key does not exist.
+	ZPopMin(ctx context.Context, key string, count int64) Result[[]ZMember]
+
+	// ZRandMember returns count random members from a sorted set without removing them.
+	// If count is positive, returns distinct members, at most the size of the sorted set.
+	// If count is negative, returns -count members, possibly repeated.
+	// Returns an empty slice if the key does not exist.
+	ZRandMember(ctx context.Context, key string, count int64) Result[[][]byte]
+
+	// ZRandMemberWithScores returns random members with their scores, as ZRandMember does.
+	ZRandMemberWithScores(ctx context.Context, key string, count int64) Result[[]ZMember]
+
 	// ZRange returns members in a sorted set within a range of indexes.
 	// start and stop are zero-based indexes (can be negative to indicate offsets from the end).
 	// Returns members in ascending order by score.
@@ -92,6 +153,15 @@ type SortedSetCommand interface {
 	// ZRangeArgsWithScores returns members with scores based on custom range arguments.
 	ZRangeArgsWithScores(ctx context.Context, key string, args ZRangeArgs) Result[[]ZMember]
 
+	// ZRangeStore stores the members of key selected by args in a destination key,
+	// which is overwritten or deleted if no member is selected.
+	// Returns the number of members in the resulting sorted set.
+	ZRangeStore(ctx context.Context, destination, key string, args ZRangeArgs) Result[int64]
+
+	// ZRangeByLex returns members in a sorted set between min and max in lexicographical order.
+	// Bounds are given as for ZLexCount.
+	ZRangeByLex(ctx context.Context, key string, min, max string) Result[[][]byte]
+
 	// ZRangeByScore returns members in a sorted set within a range of scores.
 	// min and max can be inclusive or exclusive (use "(" prefix for exclusive).
 	ZRangeByScore(ctx context.Context, key string, min, max string) Result[[][]byte]
@@ -116,6 +186,10 @@ type SortedSetCommand interface {
 	// Returns the number of members removed.
 	ZRemRangeByRank(ctx context.Context, key string, start, stop int64) Result[int64]
 
+	// ZRemRangeByLex removes members in a sorted set between min and max in lexicographical order.
+	// Bounds are given as for ZLexCount. Returns the number of members removed.
+	ZRemRangeByLex(ctx context.Context, key string, min, max string) Result[int64]
+
 	// ZRemRangeByScore removes members in a sorted set within a range of scores.
 	// min and max can be inclusive or exclusive (use "(" prefix for exclusive).
 	// Returns the number of members removed.
@@ -128,6 +202,10 @@ type SortedSetCommand interface {
 	// ZRevRangeWithScores returns members with scores in reverse order.
 	ZRevRangeWithScores(ctx context.Context, key string, start, stop int64) Result[[]ZMember]
 
+	// ZRevRangeByLex returns members in a sorted set between max and min in reverse lexicographical order.
+	// Bounds are given as for ZLexCount.
+	ZRevRangeByLex(ctx context.Context, key string, max, min string) Result[[][]byte]
+
 	// ZRevRangeByScore returns members in a sorted set within a range of scores in reverse order.
 	ZRevRangeByScore(ctx context.Context, key string, max, min string) Result[[][]byte]
 
@@ -165,6 +243,22 @@ type SortedSetCommand interface {
 	ZUnionStore(ctx context.Context, destination string, store ZStore) Result[int64]
 }
 
+// ZPopResult holds a member popped from one of several sorted sets.
+type ZPopResult struct {
+	// Key is the sorted set the member was popped from.
+	Key string
+	// Member is the popped member with its score.
+	Member ZMember
+}
+
+// ZMPopResult holds the members popped from one of several sorted sets.
+type ZMPopResult struct {
+	// Key is the sorted set the members were popped from.
+	Key string
+	// Members are the popped members with their scores.
+	Members []ZMember
+}
+
 // ZRankScore represents the rank and score of a member.
 type ZRankScore struct {
 	// Rank is the rank (index) of the member.
@@ -0,0 +1,196 @@
+package caches
+
+import (
+	"context"
+	"time"
+)
+
+// XMessage represents an entry of a stream.
+type XMessage struct {
+	// ID is the entry ID, in the `<milliseconds>-<sequence>` form.
+	ID string
+	// Values are the field-value pairs of the entry.
+	// It is nil for pending entries that have been deleted from the stream.
+	Values map[string][]byte
+}
+
+// XStream holds the entries read from a stream.
+type XStream struct {
+	// Stream is the key of the stream.
+	Stream string
+	// Messages are the entries read from the stream.
+	Messages []XMessage
+}
+
+// XAddArgs provides arguments for XAdd.
+type XAddArgs struct {
+	// ID is the entry ID. Empty or `*` generates an ID from the current time,
+	// `<milliseconds>-*` generates the sequence number only.
+	ID string
+	// Values are the field-value pairs of the entry.
+	Values map[string]any
+	// NoMkStream does not create the stream when it does not exist, Nil is returned instead.
+	NoMkStream bool
+	// MaxLen trims the stream to at most MaxLen entries after the addition. Zero means no trimming.
+	MaxLen int64
+	// MinID trims the entries with an ID lower than MinID after the addition. Ignored when MaxLen is set.
+	MinID string
+	// Approx allows the trimming to be less precise for efficiency.
+	// Providers may still trim exactly.
+	Approx bool
+}
+
+// XReadArgs provides arguments for XRead.
+type XReadArgs struct {
+	// Streams are the keys of the streams to read.
+	Streams []string
+	// IDs are the IDs after which entries are read, one per stream.
+	// `$` reads only the entries added after the call.
+	IDs []string
+	// Count is the maximum number of entries read from each stream. Zero means no limit.
+	Count int64
+	// Block waits up to Block for an entry when none is available.
+	// Zero does not block, a negative duration blocks until ctx is done.
+	Block time.Duration
+}
+
+// XReadGroupArgs provides arguments for XReadGroup.
+type XReadGroupArgs struct {
+	// Group is the consumer group name.
+	Group string
+	// Consumer is the consumer name, created on first use.
+	Consumer string
+	// Streams are the keys of the streams to read.
+	Streams []string
+	// IDs are the IDs to read from, one per stream.
+	// `>` reads entries never delivered to the group, other IDs read the
+	// pending entries of the consumer after that ID.
+	IDs []string
+	// Count is the maximum number of entries read from each stream. Zero means no limit.
+	Count int64
+	// Block waits up to Block for an entry when none is available.
+	// Zero does not block, a negative duration blocks until ctx is done.
+	Block time.Duration
+	// NoAck does not add the delivered entries to the pending entries list.
+	NoAck bool
+}
+
+// XPending summarizes the pending entries of a consumer group.
+type XPending struct {
+	// Count is the number of pending entries.
+	Count int64
+	// Lower is the smallest pending entry ID, empty when there is no pending entry.
+	Lower string
+	// Higher is the greatest pending entry ID, empty when there is no pending entry.
+	Higher string
+	// Consumers is the number of pending entries of each consumer.
+	Consumers map[string]int64
+}
+
+// XPendingExtArgs provides arguments for XPendingExt.
+type XPendingExtArgs struct {
+	// Start is the smallest returned entry ID, `-` for the first one.
+	Start string
+	// End is the greatest returned entry ID, `+` for the last one.
+	End string
+	// Count is the maximum number of returned entries.
+	Count int64
+	// Consumer only returns the entries of this consumer when set.
+	Consumer string
+	// Idle only returns the entries not delivered for at least Idle.
+	Idle time.Duration
+}
+
+// XPendingExt represents a pending entry of a consumer group.
+type XPendingExt struct {
+	// ID is the entry ID.
+	ID string
+	// Consumer is the consumer the entry was delivered to.
+	Consumer string
+	// Idle is the time elapsed since the entry was last delivered.
+	Idle time.Duration
+	// RetryCount is the number of times the entry was delivered.
+	RetryCount int64
+}
+
+// XAutoClaimResult holds the result of XAutoClaim.
+type XAutoClaimResult struct {
+	// Next is the ID to start the next call from, `0-0` once all pending entries have been scanned.
+	Next string
+	// Messages are the claimed entries.
+	Messages []XMessage
+}
+
+// StreamCommand defines operations for Redis stream data structure.
+// Streams are append-only logs of entries, consumed directly or through consumer groups.
+type StreamCommand interface {
+	// XAck removes entries from the pending entries list of a consumer group.
+	// Returns the number of acknowledged entries.
+	XAck(ctx context.Context, key, group string, ids ...string) Result[int64]
+
+	// XAdd appends an entry to the stream stored at key, creating the stream if needed.
+	// Returns the ID of the added entry, or Nil when NoMkStream is set and the stream does not exist.
+	XAdd(ctx context.Context, key string, args XAddArgs) Result[string]
+
+	// XAutoClaim transfers to consumer the pending entries idle for at least minIdle,
+	// scanning up to count entries from start.
+	XAutoClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, start string, count int64) Result[XAutoClaimResult]
+
+	// XClaim transfers to consumer the pending entries among ids idle for at least minIdle.
+	// Returns the claimed entries.
+	XClaim(ctx context.Context, key, group, consumer string, minIdle time.Duration, ids ...string) Result[[]XMessage]
+
+	// XDel removes entries from the stream stored at key.
+	// Returns the number of removed entries.
+	XDel(ctx context.Context, key string, ids ...string) Result[int64]
+
+	// XGroupCreate creates a consumer group delivering the entries after start, `$` for new entries only.
+	// The stream must exist.
+	XGroupCreate(ctx context.Context, key, group, start string) StatusResult
+
+	// XGroupCreateMkStream is like XGroupCreate, but creates an empty stream when it does not exist.
+	XGroupCreateMkStream(ctx context.Context, key, group, start string) StatusResult
+
+	// XGroupDestroy removes a consumer group and its pending entries.
+	// Returns 1 if the group was removed, 0 if it does not exist.
+	XGroupDestroy(ctx context.Context, key, group string) Result[int64]
+
+	// XLen returns the number of entries in the stream stored at key.
+	// Returns 0 if the key does not exist.
+	XLen(ctx context.Context, key string) Result[int64]
+
+	// XPending returns a summary of the pending entries of a consumer group.
+	XPending(ctx context.Context, key, group string) Result[XPending]
+
+	// XPendingExt returns the pending entries of a consumer group within a range of IDs.
+	XPendingExt(ctx context.Context, key, group string, args XPendingExtArgs) Result[[]XPendingExt]
+
+	// XRange returns the entries of the stream stored at key with an ID between start and end.
+	// `-` and `+` are the smallest and greatest IDs, and a `(` prefix excludes the bound.
+	XRange(ctx context.Context, key, start, end string) Result[[]XMessage]
+
+	// XRangeN is like XRange, but returns at most count entries.
+	XRangeN(ctx context.Context, key, start, end string, count int64) Result[[]XMessage]
+
+	// XRead returns the entries added to streams after the given IDs.
+	// Returns Nil when no entry is available and the block duration expires.
+	XRead(ctx context.Context, args XReadArgs) Result[[]XStream]
+
+	// XReadGroup returns entries of streams on behalf of a consumer of a group.
+	// Returns Nil when no entry is available and the block duration expires.
+	XReadGroup(ctx context.Context, args XReadGroupArgs) Result[[]XStream]
+
+	// XRevRange is like XRange, but returns the entries in reverse order, from end down to start.
+	XRevRange(ctx context.Context, key, end, start string) Result[[]XMessage]
+
+	// XRevRangeN is like XRevRange, but returns at most count entries.
+	XRevRangeN(ctx context.Context, key, end, start string, count int64) Result[[]XMessage]
+
+	// XTrimMaxLen removes the oldest entries so that the stream has at most maxLen entries.
+	// Returns the number of removed entries.
+	XTrimMaxLen(ctx context.Context, key string, maxLen int64) Result[int64]
+
+	// XTrimMinID removes the entries with an ID lower than minID.
+	// Returns the number of removed entries.
+	XTrimMinID(ctx context.Context, key string, minID string) Result[int64]
+}
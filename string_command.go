@@ -22,10 +22,71 @@ type SetArgs struct {
 	KeepTTL bool
 }
 
+// GetExArgs provides arguments for the GetEx function.
+// Only one of TTL, ExpireAt and Persist should be set; when none is, the expiration is left unchanged.
+type GetExArgs struct {
+	// TTL sets the expiration time relative to now, the EX or PX option.
+	TTL time.Duration
+
+	// ExpireAt sets the expiration time, the EXAT or PXAT option.
+	// A time in the past deletes the key after its value is read.
+	ExpireAt time.Time
+
+	// Persist removes the expiration time of the key.
+	Persist bool
+}
+
+// LCSQuery provides arguments for the LCS function.
+type LCSQuery struct {
+	Key1 string
+	Key2 string
+
+	// Len returns only the length of the longest common subsequence, the LEN option.
+	Len bool
+
+	// Idx returns the positions of the matching ranges and the length, the IDX option.
+	// It cannot be combined with Len.
+	Idx bool
+
+	// MinMatchLen skips the ranges shorter than this length, with Idx.
+	MinMatchLen int
+
+	// WithMatchLen also returns the length of each range, with Idx.
+	WithMatchLen bool
+}
+
+// LCSMatch is the result of the LCS function.
+// MatchString is set by default, Len with the Len option, Matches and Len with the Idx option.
+type LCSMatch struct {
+	MatchString string
+	Matches     []LCSMatchedPosition
+	Len         int64
+}
+
+// LCSMatchedPosition is a range of the longest common subsequence in both strings.
+// The ranges are listed from the end of the strings to their start, as in Redis.
+type LCSMatchedPosition struct {
+	Key1 LCSPosition
+	Key2 LCSPosition
+
+	// MatchLen is only set with the WithMatchLen option.
+	MatchLen int64
+}
+
+// LCSPosition is a range of bytes, both ends included.
+type LCSPosition struct {
+	Start int64
+	End   int64
+}
+
 // StringCommand defines operations for string values in the cache.
 // Strings are the most basic Redis data type and can contain text, JSON, serialized objects,
 // or binary data. All string operations are atomic.
 type StringCommand interface {
+	// Append appends value to the string stored at key, creating it if the key does not exist.
+	// Returns the length of the string after the append operation. The expiration is kept.
+	Append(ctx context.Context, key string, value string) Result[int64]
+
 	// Decr decrements the integer value of a key by one.
 	// If the key does not exist, it is set to 0 before performing the operation.
 	// Returns an error if the key contains a value that cannot be interpreted as an integer.
@@ -40,6 +101,18 @@ type StringCommand interface {
 	// Returns nil if the key does not exist.
 	Get(ctx context.Context, key string) Result[[]byte]
 
+	// GetDel retrieves the value of a key and deletes the key.
+	// Returns nil if the key does not exist.
+	GetDel(ctx context.Context, key string) Result[[]byte]
+
+	// GetEx retrieves the value of a key and updates its expiration time as specified in GetExArgs.
+	// Returns nil if the key does not exist.
+	GetEx(ctx context.Context, key string, args GetExArgs) Result[[]byte]
+
+	// GetRange returns the substring of the string stored at key between the start and end offsets, both included.
+	// Negative offsets are counted from the end of the string. Returns an empty value if the key does not exist.
+	GetRange(ctx context.Context, key string, start, end int64) Result[[]byte]
+
 	// Incr increments the integer value of a key by one.
 	// If the key does not exist, it is set to 0 before performing the operation.
 	// Returns an error if the key contains a value that cannot be interpreted as an integer.
@@ -55,6 +128,10 @@ type StringCommand interface {
 	// Returns an error if the key contains a value that cannot be interpreted as a float.
 	IncrByFloat(ctx context.Context, key string, value float64) Result[float64]
 
+	// LCS returns the longest common subsequence of the strings stored at two keys.
+	// Missing keys are treated as empty strings. See LCSQuery for the LEN and IDX options.
+	LCS(ctx context.Context, q *LCSQuery) Result[*LCSMatch]
+
 	// Set sets the value of a key with an optional expiration time.
 	// expiration of 0 means the key has no expiration time.
 	// Overwrites any existing value and clears any existing TTL.
@@ -69,6 +146,11 @@ type StringCommand interface {
 	// expiration of 0 means the key has no expiration time.
 	SetNX(ctx context.Context, key string, value any, expiration time.Duration) Result[bool]
 
+	// SetRange overwrites the string stored at key from offset with value, padding it with zero bytes as needed.
+	// Returns the length of the string after the operation. The expiration is kept.
+	// An empty value leaves the key unchanged, and does not create it.
+	SetRange(ctx context.Context, key string, offset int64, value string) Result[int64]
+
 	// SetXX sets the value of a key only if the key already exists.
 	// Returns true if the key was set, false if the key does not exist.
 	// expiration of 0 means the key has no expiration time.
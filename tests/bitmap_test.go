@@ -0,0 +1,419 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// BitmapCommandProvider provides the commands used by the BitmapCommand tests
+type BitmapCommandProvider interface {
+	GetBitmapCommand() caches.BitmapCommand
+	GetStringCommand() caches.StringCommand
+	GetHashCommand() caches.HashCommand
+	GetKeyCommand() caches.KeyCommand
+	GetContext() context.Context
+}
+
+// RunBitmapCommandTests runs all BitmapCommand tests
+func RunBitmapCommandTests(t *testing.T, provider BitmapCommandProvider) {
+	t.Run("SetBit_GetBit", func(t *testing.T) {
+		testSetBitGetBit(t, provider)
+	})
+	t.Run("SetBit_Grow", func(t *testing.T) {
+		testSetBitGrow(t, provider)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		testBitOutOfRange(t, provider)
+	})
+	t.Run("BitCount", func(t *testing.T) {
+		testBitCount(t, provider)
+	})
+	t.Run("BitPos", func(t *testing.T) {
+		testBitPos(t, provider)
+	})
+	t.Run("BitOp", func(t *testing.T) {
+		testBitOp(t, provider)
+	})
+	t.Run("BitOp_Overwrite", func(t *testing.T) {
+		testBitOpOverwrite(t, provider)
+	})
+	t.Run("BitField", func(t *testing.T) {
+		testBitField(t, provider)
+	})
+	t.Run("BitField_Overflow", func(t *testing.T) {
+		testBitFieldOverflow(t, provider)
+	})
+	t.Run("BitField_Signed", func(t *testing.T) {
+		testBitFieldSigned(t, provider)
+	})
+}
+
+// int64Ptrs returns pointers to vals, nil standing for a nil pointer
+func int64Ptrs(vals ...any) []*int64 {
+	ptrs := make([]*int64, len(vals))
+	for i, v := range vals {
+		if v != nil {
+			n := int64(v.(int))
+			ptrs[i] = &n
+		}
+	}
+	return ptrs
+}
+
+// testSetBitGetBit tests setting and reading single bits
+func testSetBitGetBit(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:setbit"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	bit, err := bitCmd.GetBit(ctx, key, 7).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), bit)
+
+	prev, err := bitCmd.SetBit(ctx, key, 7, 1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), prev)
+
+	bit, err = bitCmd.GetBit(ctx, key, 7).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), bit)
+
+	// Bits are numbered from the most significant bit of the first byte
+	val, err := strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, val)
+
+	// Beyond the end of the string
+	bit, err = bitCmd.GetBit(ctx, key, 100).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), bit)
+
+	prev, err = bitCmd.SetBit(ctx, key, 7, 0).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), prev)
+
+	val, err = strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00}, val)
+}
+
+// testSetBitGrow tests SetBit pads the string with zeros and keeps its TTL
+func testSetBitGrow(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:grow"
+	defer keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, key, "a", 0).Err())
+	require.NoError(t, keyCmd.Expire(ctx, key, time.Minute).Err())
+
+	_, err := bitCmd.SetBit(ctx, key, 23, 1).Result()
+	require.NoError(t, err)
+
+	val, err := strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte{'a', 0x00, 0x01}, val)
+
+	ttl, err := keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, time.Duration(0))
+}
+
+// testBitOutOfRange tests offsets and bits out of range are rejected
+func testBitOutOfRange(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:range"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	require.Error(t, bitCmd.SetBit(ctx, key, -1, 1).Err())
+	require.Error(t, bitCmd.SetBit(ctx, key, 1<<32, 1).Err())
+	require.Error(t, bitCmd.SetBit(ctx, key, 0, 2).Err())
+	require.Error(t, bitCmd.GetBit(ctx, key, -1).Err())
+	require.Error(t, bitCmd.GetBit(ctx, key, 1<<32).Err())
+	require.Error(t, bitCmd.BitField(ctx, key, "SET", "u8", -1, 1).Err())
+	require.Error(t, bitCmd.BitField(ctx, key, "SET", "u8", 1<<32, 1).Err())
+	require.Error(t, bitCmd.BitField(ctx, key, "GET", "u64", 0).Err())
+	require.Error(t, bitCmd.BitField(ctx, key, "GET", "i65", 0).Err())
+	require.Error(t, bitCmd.BitPos(ctx, key, 2).Err())
+
+	// Nothing was written
+	exists, err := keyCmd.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
+
+// testBitCount tests counting bits over byte and bit ranges
+func testBitCount(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:count"
+	defer keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, key, "foobar", 0).Err())
+
+	tests := []struct {
+		bitCount *caches.BitCount
+		want     int64
+	}{
+		{nil, 26},
+		{&caches.BitCount{Start: 0, End: 0}, 4},
+		{&caches.BitCount{Start: 1, End: 1}, 6},
+		{&caches.BitCount{Start: -2, End: -1}, 7},
+		{&caches.BitCount{Start: 2, End: 100}, 16},
+		{&caches.BitCount{Start: 3, End: 1}, 0},
+		{&caches.BitCount{Start: 5, End: 30, Unit: caches.BitCountIndexBit}, 17},
+	}
+	for _, tc := range tests {
+		n, err := bitCmd.BitCount(ctx, key, tc.bitCount).Result()
+		require.NoError(t, err)
+		require.Equal(t, tc.want, n, "range %+v", tc.bitCount)
+	}
+
+	n, err := bitCmd.BitCount(ctx, "test:bitmap:count_missing", nil).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+}
+
+// testBitPos tests finding the first set or clear bit
+func testBitPos(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:pos"
+	defer keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, key, []byte{0x00, 0xff, 0xf0}, 0).Err())
+
+	pos, err := bitCmd.BitPos(ctx, key, 1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(8), pos)
+
+	pos, err = bitCmd.BitPos(ctx, key, 1, 2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(16), pos)
+
+	pos, err = bitCmd.BitPos(ctx, key, 0, 1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(20), pos)
+
+	pos, err = bitCmd.BitPosSpan(ctx, key, 1, 7, 15, caches.BitCountIndexBit).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(8), pos)
+
+	pos, err = bitCmd.BitPosSpan(ctx, key, 1, 7, -3, caches.BitCountIndexBit).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(8), pos)
+
+	// Looking for a clear bit in a string of ones
+	require.NoError(t, strCmd.Set(ctx, key, []byte{0xff, 0xff}, 0).Err())
+	pos, err = bitCmd.BitPos(ctx, key, 0).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(16), pos, "the string is padded with zeros without an end")
+
+	pos, err = bitCmd.BitPos(ctx, key, 0, 0, -1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), pos, "no padding with an end")
+
+	missing := "test:bitmap:pos_missing"
+	pos, err = bitCmd.BitPos(ctx, missing, 0).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), pos)
+
+	pos, err = bitCmd.BitPos(ctx, missing, 1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), pos)
+}
+
+// testBitOp tests BITOP AND/OR/XOR/NOT, the source and destination keys being prefixed alike
+func testBitOp(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key1, key2, missing := "test:bitmap:op1", "test:bitmap:op2", "test:bitmap:op_missing"
+	dest := "test:bitmap:op_dest"
+	defer keyCmd.Del(ctx, key1, key2, dest)
+	require.NoError(t, strCmd.Set(ctx, key1, []byte{0xff, 0x0f}, 0).Err())
+	require.NoError(t, strCmd.Set(ctx, key2, []byte{0x3c}, 0).Err())
+
+	tests := []struct {
+		name string
+		op   func() caches.Result[int64]
+		want []byte
+	}{
+		{"AND", func() caches.Result[int64] { return bitCmd.BitOpAnd(ctx, dest, key1, key2) }, []byte{0x3c, 0x00}},
+		{"OR", func() caches.Result[int64] { return bitCmd.BitOpOr(ctx, dest, key1, key2) }, []byte{0xff, 0x0f}},
+		{"XOR", func() caches.Result[int64] { return bitCmd.BitOpXor(ctx, dest, key1, key2) }, []byte{0xc3, 0x0f}},
+		{"NOT", func() caches.Result[int64] { return bitCmd.BitOpNot(ctx, dest, key1) }, []byte{0x00, 0xf0}},
+		{"AND_Missing", func() caches.Result[int64] { return bitCmd.BitOpAnd(ctx, dest, key1, missing) }, []byte{0x00, 0x00}},
+		{"OR_Missing", func() caches.Result[int64] { return bitCmd.BitOpOr(ctx, dest, key2, missing) }, []byte{0x3c}},
+	}
+	for _, tc := range tests {
+		n, err := tc.op().Result()
+		require.NoError(t, err, tc.name)
+		require.Equal(t, int64(len(tc.want)), n, tc.name)
+
+		val, err := strCmd.Get(ctx, dest).Result()
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.want, val, tc.name)
+	}
+
+	// An empty result deletes the destination
+	n, err := bitCmd.BitOpOr(ctx, dest, missing).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	exists, err := keyCmd.Exists(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
+
+// testBitOpOverwrite tests BITOP replaces a destination of another type and clears its TTL
+func testBitOpOverwrite(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	hashCmd := provider.GetHashCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key, dest, hashKey := "test:bitmap:overwrite_src", "test:bitmap:overwrite_dest", "test:bitmap:overwrite_hash"
+	keyCmd.Del(ctx, dest, hashKey)
+	defer keyCmd.Del(ctx, key, dest, hashKey)
+	require.NoError(t, strCmd.Set(ctx, key, []byte{0x0f}, 0).Err())
+	require.NoError(t, hashCmd.HSet(ctx, dest, map[string]any{"field": "value"}).Err())
+	require.NoError(t, keyCmd.Expire(ctx, dest, time.Minute).Err())
+
+	n, err := bitCmd.BitOpNot(ctx, dest, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	val, err := strCmd.Get(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xf0}, val)
+
+	ttl, err := keyCmd.TTL(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(-1), ttl)
+
+	// A source of another type is rejected
+	require.NoError(t, hashCmd.HSet(ctx, hashKey, map[string]any{"field": "value"}).Err())
+	require.Error(t, bitCmd.BitOpAnd(ctx, dest, key, hashKey).Err())
+}
+
+// testBitField tests BITFIELD GET, SET and INCRBY
+func testBitField(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:field"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	// GET on a missing key reads zeros without creating it
+	vals, err := bitCmd.BitField(ctx, key, "GET", "u8", 0).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(0), vals)
+
+	exists, err := keyCmd.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+
+	// SET returns the previous value, INCRBY the new one, and # offsets are multiplied by the width
+	vals, err = bitCmd.BitField(ctx, key, "SET", "u8", 0, 200, "SET", "i8", "#1", -5, "INCRBY", "u8", 0, 10, "GET", "i8", 8).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(0, 0, 210, -5), vals)
+
+	val, err := strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte{210, 0xfb}, val)
+
+	// Fields can span bytes
+	vals, err = bitCmd.BitField(ctx, key, "GET", "u4", 4, "GET", "u12", 4).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(0x2, 0x2fb), vals)
+}
+
+// testBitFieldOverflow tests the WRAP, SAT and FAIL overflow modes on unsigned fields
+func testBitFieldOverflow(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:overflow"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	// The u2 counters wrap and saturate at 3
+	want := [][]*int64{
+		int64Ptrs(1, 1),
+		int64Ptrs(2, 2),
+		int64Ptrs(3, 3),
+		int64Ptrs(0, 3),
+	}
+	for i, w := range want {
+		vals, err := bitCmd.BitField(ctx, key, "INCRBY", "u2", 100, 1, "OVERFLOW", "SAT", "INCRBY", "u2", 102, 1).Result()
+		require.NoError(t, err)
+		require.Equal(t, w, vals, "round %d", i)
+	}
+
+	// FAIL leaves the field unchanged
+	vals, err := bitCmd.BitField(ctx, key, "OVERFLOW", "FAIL", "INCRBY", "u2", 102, 1, "GET", "u2", 102).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(nil, 3), vals)
+
+	vals, err = bitCmd.BitField(ctx, key, "OVERFLOW", "FAIL", "SET", "u8", 0, 256, "OVERFLOW", "WRAP", "SET", "u8", 8, 257, "GET", "u8", 8).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(nil, 0, 1), vals)
+
+	vals, err = bitCmd.BitField(ctx, key, "OVERFLOW", "SAT", "INCRBY", "u8", 8, -10).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(0), vals)
+}
+
+// testBitFieldSigned tests the overflow modes on signed fields
+func testBitFieldSigned(t *testing.T, provider BitmapCommandProvider) {
+	bitCmd := provider.GetBitmapCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:bitmap:signed"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	vals, err := bitCmd.BitField(ctx, key, "SET", "i8", 0, 127, "INCRBY", "i8", 0, 1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(0, -128), vals)
+
+	vals, err = bitCmd.BitField(ctx, key, "OVERFLOW", "SAT", "INCRBY", "i8", 0, -1, "INCRBY", "i8", 0, 300).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(-128, 127), vals)
+
+	vals, err = bitCmd.BitField(ctx, key, "OVERFLOW", "FAIL", "INCRBY", "i8", 0, 1, "SET", "i8", 0, -129, "GET", "i8", 0).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(nil, nil, 127), vals)
+
+	vals, err = bitCmd.BitField(ctx, key, "SET", "i64", 8, -1, "INCRBY", "i64", 8, 1, "GET", "i64", 8).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64Ptrs(0, 0, 0), vals)
+}
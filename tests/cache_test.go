@@ -0,0 +1,241 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// CacheProvider defines the interface for testing Cache implementations
+type CacheProvider interface {
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// RunCacheTests runs all Cache tests.
+// Each capability test is skipped when the provider does not advertise it.
+func RunCacheTests(t *testing.T, provider CacheProvider) {
+	t.Run("CapabilitiesOf", func(t *testing.T) {
+		testCapabilitiesOf(t, provider)
+	})
+	t.Run("Capability_KeepTTL", func(t *testing.T) {
+		testCapabilityKeepTTL(t, provider)
+	})
+	t.Run("Capability_SetGet", func(t *testing.T) {
+		testCapabilitySetGet(t, provider)
+	})
+	t.Run("Capability_ZRangeByLex", func(t *testing.T) {
+		testCapabilityZRangeByLex(t, provider)
+	})
+	t.Run("Capability_ZScoreExclusive", func(t *testing.T) {
+		testCapabilityZScoreExclusive(t, provider)
+	})
+	t.Run("Capability_ZStoreWeights", func(t *testing.T) {
+		testCapabilityZStoreWeights(t, provider)
+	})
+	t.Run("Capability_Families", func(t *testing.T) {
+		testCapabilityFamilies(t, provider)
+	})
+	t.Run("Capability_Streams", func(t *testing.T) {
+		testCapabilityStreams(t, provider)
+	})
+	t.Run("Capability_HashFieldTTL", func(t *testing.T) {
+		testCapabilityHashFieldTTL(t, provider)
+	})
+}
+
+// requireCapability skips the test when the provider does not support caps
+func requireCapability(t *testing.T, cache caches.Cache, caps caches.Capabilities) {
+	t.Helper()
+	if !cache.Capabilities().Has(caps) {
+		t.Skipf("provider does not support %s", caps)
+	}
+}
+
+// testCapabilitiesOf tests that CapabilitiesOf reports the provider capabilities
+func testCapabilitiesOf(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+
+	require.Equal(t, cache.Capabilities(), caches.CapabilitiesOf(cache))
+	require.Equal(t, caches.Capabilities(0), caches.CapabilitiesOf(struct{}{}))
+}
+
+// testCapabilityKeepTTL tests that KeepTTL preserves the existing expiration
+func testCapabilityKeepTTL(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapKeepTTL)
+
+	key := "test:cache:keepttl"
+	cache.Del(ctx, key)
+
+	require.NoError(t, cache.Set(ctx, key, "v1", time.Hour).Err())
+	require.NoError(t, cache.SetArgs(ctx, key, "v2", caches.SetArgs{KeepTTL: true}).Err())
+
+	ttl := cache.TTL(ctx, key)
+	require.NoError(t, ttl.Err())
+	require.Greater(t, ttl.Val(), time.Duration(0))
+
+	val := cache.Get(ctx, key)
+	require.NoError(t, val.Err())
+	require.Equal(t, []byte("v2"), val.Val())
+}
+
+// testCapabilitySetGet tests that SetArgs.Get returns the previous value
+func testCapabilitySetGet(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapSetGet)
+
+	key := "test:cache:setget"
+	cache.Del(ctx, key)
+
+	require.NoError(t, cache.Set(ctx, key, "old", 0).Err())
+
+	result := cache.SetArgs(ctx, key, "new", caches.SetArgs{Get: true})
+	require.NoError(t, result.Err())
+	require.Equal(t, "old", result.Val())
+}
+
+// testCapabilityZRangeByLex tests ZRangeArgs with lexicographical bounds
+func testCapabilityZRangeByLex(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapZRangeByLex)
+
+	key := "test:cache:bylex"
+	cache.Del(ctx, key)
+
+	cache.ZAdd(ctx, key,
+		caches.ZMember{Member: []byte("a"), Score: 0},
+		caches.ZMember{Member: []byte("b"), Score: 0},
+		caches.ZMember{Member: []byte("c"), Score: 0},
+		caches.ZMember{Member: []byte("d"), Score: 0},
+	)
+
+	result := cache.ZRangeArgs(ctx, key, caches.ZRangeArgs{Start: "[b", Stop: "(d", ByLex: true})
+	require.NoError(t, result.Err())
+	require.Equal(t, [][]byte{[]byte("b"), []byte("c")}, result.Val())
+
+	result = cache.ZRangeArgs(ctx, key, caches.ZRangeArgs{Start: "-", Stop: "+", ByLex: true, Rev: true})
+	require.NoError(t, result.Err())
+	require.Equal(t, [][]byte{[]byte("d"), []byte("c"), []byte("b"), []byte("a")}, result.Val())
+}
+
+// testCapabilityZScoreExclusive tests exclusive score bounds
+func testCapabilityZScoreExclusive(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapZScoreExclusive)
+
+	key := "test:cache:exclusive"
+	cache.Del(ctx, key)
+
+	cache.ZAdd(ctx, key,
+		caches.ZMember{Member: []byte("one"), Score: 1},
+		caches.ZMember{Member: []byte("two"), Score: 2},
+		caches.ZMember{Member: []byte("three"), Score: 3},
+	)
+
+	result := cache.ZRangeByScore(ctx, key, "(1", "3")
+	require.NoError(t, result.Err())
+	require.Equal(t, [][]byte{[]byte("two"), []byte("three")}, result.Val())
+
+	count := cache.ZCount(ctx, key, "1", "(3")
+	require.NoError(t, count.Err())
+	require.Equal(t, int64(2), count.Val())
+}
+
+// testCapabilityZStoreWeights tests that ZStore weights are applied
+func testCapabilityZStoreWeights(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapZStoreWeights)
+
+	key1 := "test:cache:weights1"
+	key2 := "test:cache:weights2"
+	cache.Del(ctx, key1, key2)
+
+	cache.ZAdd(ctx, key1, caches.ZMember{Member: []byte("a"), Score: 1})
+	cache.ZAdd(ctx, key2, caches.ZMember{Member: []byte("a"), Score: 2})
+
+	result := cache.ZUnionWithScores(ctx, caches.ZStore{
+		Keys:    []string{key1, key2},
+		Weights: []float64{2, 3},
+	})
+	require.NoError(t, result.Err())
+	require.Len(t, result.Val(), 1)
+	require.Equal(t, 8.0, result.Val()[0].Score)
+}
+
+// testCapabilityFamilies tests that the provider implements the command families it advertises
+func testCapabilityFamilies(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	caps := cache.Capabilities()
+
+	families := []struct {
+		cap         caches.Capabilities
+		implemented bool
+	}{
+		{caches.CapStreams, implements[caches.StreamCommand](cache)},
+		{caches.CapHyperLogLog, implements[caches.HyperLogLogCommand](cache)},
+		{caches.CapGeo, implements[caches.GeoCommand](cache)},
+		{caches.CapBitmap, implements[caches.BitmapCommand](cache)},
+		{caches.CapCompare, implements[caches.CompareCommand](cache)},
+		{caches.CapRateLimit, implements[caches.RateLimitCommand](cache)},
+		{caches.CapScript, implements[caches.ScriptCommand](cache)},
+		{caches.CapLua, implements[caches.ScriptCommand](cache)},
+		{caches.CapPipeline, implements[caches.PipelineCommand](cache)},
+		{caches.CapTx, implements[caches.TxCommand](cache)},
+		{caches.CapPubSub, implements[caches.PubSub](cache)},
+	}
+	for _, f := range families {
+		if caps.Has(f.cap) {
+			require.True(t, f.implemented, "%s is advertised but not implemented", f.cap)
+		}
+	}
+}
+
+// implements reports whether v implements T
+func implements[T any](v any) bool {
+	_, ok := v.(T)
+	return ok
+}
+
+// testCapabilityStreams tests that stream commands run when CapStreams is advertised
+func testCapabilityStreams(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapStreams)
+
+	key := "test:cache:streams"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	stream := cache.(caches.StreamCommand)
+	require.NoError(t, stream.XAdd(ctx, key, caches.XAddArgs{Values: map[string]any{"field": "value"}}).Err())
+
+	n, err := stream.XLen(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+}
+
+// testCapabilityHashFieldTTL tests that hash fields expire when CapHashFieldTTL is advertised
+func testCapabilityHashFieldTTL(t *testing.T, provider CacheProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	requireCapability(t, cache, caches.CapHashFieldTTL)
+
+	key := "test:cache:hashfieldttl"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	require.NoError(t, cache.HSet(ctx, key, map[string]any{"field": "value"}).Err())
+
+	codes, err := cache.HExpire(ctx, key, time.Minute, "field").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, codes)
+}
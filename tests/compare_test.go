@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// CompareCommandProvider provides the commands used by the CompareCommand tests
+type CompareCommandProvider interface {
+	GetCompareCommand() caches.CompareCommand
+	GetStringCommand() caches.StringCommand
+	GetKeyCommand() caches.KeyCommand
+	GetContext() context.Context
+}
+
+// RunCompareCommandTests runs all CompareCommand tests
+func RunCompareCommandTests(t *testing.T, provider CompareCommandProvider) {
+	t.Run("CompareAndDelete_Equal", func(t *testing.T) {
+		testCompareAndDeleteEqual(t, provider)
+	})
+	t.Run("CompareAndDelete_NotEqual", func(t *testing.T) {
+		testCompareAndDeleteNotEqual(t, provider)
+	})
+	t.Run("CompareAndDelete_NonExistentKey", func(t *testing.T) {
+		testCompareAndDeleteNonExistentKey(t, provider)
+	})
+	t.Run("CompareAndExpire_Equal", func(t *testing.T) {
+		testCompareAndExpireEqual(t, provider)
+	})
+	t.Run("CompareAndExpire_NotEqual", func(t *testing.T) {
+		testCompareAndExpireNotEqual(t, provider)
+	})
+	t.Run("CompareAndExpire_NonExistentKey", func(t *testing.T) {
+		testCompareAndExpireNonExistentKey(t, provider)
+	})
+	t.Run("AcquireWithToken", func(t *testing.T) {
+		testAcquireWithToken(t, provider)
+	})
+	t.Run("AcquireWithToken_InvalidToken", func(t *testing.T) {
+		testAcquireWithTokenInvalidToken(t, provider)
+	})
+}
+
+// testCompareAndDeleteEqual tests CompareAndDelete deletes a key holding the value
+func testCompareAndDeleteEqual(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:compare:del_equal"
+	require.NoError(t, strCmd.Set(ctx, key, "token", 0).Err())
+
+	result := cmpCmd.CompareAndDelete(ctx, key, "token")
+	require.NoError(t, result.Err())
+	require.True(t, result.Val())
+
+	exists := keyCmd.Exists(ctx, key)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(0), exists.Val())
+}
+
+// testCompareAndDeleteNotEqual tests CompareAndDelete keeps a key holding another value
+func testCompareAndDeleteNotEqual(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:compare:del_not_equal"
+	defer keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, key, "token", 0).Err())
+
+	result := cmpCmd.CompareAndDelete(ctx, key, "other")
+	require.NoError(t, result.Err())
+	require.False(t, result.Val())
+
+	val := strCmd.Get(ctx, key)
+	require.NoError(t, val.Err())
+	require.Equal(t, []byte("token"), val.Val())
+}
+
+// testCompareAndDeleteNonExistentKey tests CompareAndDelete on a non-existent key
+func testCompareAndDeleteNonExistentKey(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:compare:del_nonexistent"
+	keyCmd.Del(ctx, key)
+
+	result := cmpCmd.CompareAndDelete(ctx, key, "token")
+	require.NoError(t, result.Err())
+	require.False(t, result.Val())
+}
+
+// testCompareAndExpireEqual tests CompareAndExpire sets the timeout of a key holding the value
+func testCompareAndExpireEqual(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:compare:expire_equal"
+	defer keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, key, "token", time.Minute).Err())
+
+	result := cmpCmd.CompareAndExpire(ctx, key, "token", time.Hour)
+	require.NoError(t, result.Err())
+	require.True(t, result.Val())
+
+	ttl := keyCmd.PTTL(ctx, key)
+	require.NoError(t, ttl.Err())
+	require.Greater(t, ttl.Val(), 59*time.Minute)
+}
+
+// testCompareAndExpireNotEqual tests CompareAndExpire keeps the timeout of a key holding another value
+func testCompareAndExpireNotEqual(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:compare:expire_not_equal"
+	defer keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, key, "token", time.Minute).Err())
+
+	result := cmpCmd.CompareAndExpire(ctx, key, "other", time.Hour)
+	require.NoError(t, result.Err())
+	require.False(t, result.Val())
+
+	ttl := keyCmd.PTTL(ctx, key)
+	require.NoError(t, ttl.Err())
+	require.LessOrEqual(t, ttl.Val(), time.Minute)
+}
+
+// testCompareAndExpireNonExistentKey tests CompareAndExpire on a non-existent key
+func testCompareAndExpireNonExistentKey(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:compare:expire_nonexistent"
+	keyCmd.Del(ctx, key)
+
+	result := cmpCmd.CompareAndExpire(ctx, key, "token", time.Hour)
+	require.NoError(t, result.Err())
+	require.False(t, result.Val())
+
+	exists := keyCmd.Exists(ctx, key)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(0), exists.Val())
+}
+
+// testAcquireWithToken tests AcquireWithToken sets a missing key and increments the token, and leaves both unchanged otherwise
+func testAcquireWithToken(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key, tokenKey := "test:compare:acquire", "test:compare:acquire:fence"
+	keyCmd.Del(ctx, key, tokenKey)
+	defer keyCmd.Del(ctx, key, tokenKey)
+
+	token, err := cmpCmd.AcquireWithToken(ctx, key, "holder1", time.Minute, tokenKey).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), token)
+
+	val, err := strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("holder1"), val)
+
+	ttl, err := keyCmd.PTTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, 59*time.Second)
+	require.LessOrEqual(t, ttl, time.Minute)
+
+	err = cmpCmd.AcquireWithToken(ctx, key, "holder2", time.Minute, tokenKey).Err()
+	require.Equal(t, caches.Nil, err)
+
+	val, err = strCmd.Get(ctx, tokenKey).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+
+	require.NoError(t, keyCmd.Del(ctx, key).Err())
+
+	// Without expiration the key is kept
+	token, err = cmpCmd.AcquireWithToken(ctx, key, "holder2", 0, tokenKey).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), token)
+
+	ttl, err = keyCmd.PTTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(-1), ttl)
+}
+
+// testAcquireWithTokenInvalidToken tests AcquireWithToken does not set the key when the token is not an integer
+func testAcquireWithTokenInvalidToken(t *testing.T, provider CompareCommandProvider) {
+	cmpCmd := provider.GetCompareCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key, tokenKey := "test:compare:acquire_invalid", "test:compare:acquire_invalid:fence"
+	keyCmd.Del(ctx, key)
+	require.NoError(t, strCmd.Set(ctx, tokenKey, "not a number", 0).Err())
+	defer keyCmd.Del(ctx, key, tokenKey)
+
+	require.Error(t, cmpCmd.AcquireWithToken(ctx, key, "holder", time.Minute, tokenKey).Err())
+
+	exists, err := keyCmd.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
@@ -0,0 +1,249 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// FetcherProvider provides the cache used by the Fetcher tests
+type FetcherProvider interface {
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// RunFetcherTests runs all Fetcher tests
+func RunFetcherTests(t *testing.T, provider FetcherProvider) {
+	t.Run("Fetch_Miss", func(t *testing.T) {
+		testFetcherMiss(t, provider)
+	})
+	t.Run("Fetch_Fresh", func(t *testing.T) {
+		testFetcherFresh(t, provider)
+	})
+	t.Run("Fetch_EarlyRecompute", func(t *testing.T) {
+		testFetcherEarlyRecompute(t, provider)
+	})
+	t.Run("Fetch_Stale", func(t *testing.T) {
+		testFetcherStale(t, provider)
+	})
+	t.Run("Fetch_ForeignValue", func(t *testing.T) {
+		testFetcherForeignValue(t, provider)
+	})
+	t.Run("Fetch_Error", func(t *testing.T) {
+		testFetcherError(t, provider)
+	})
+	t.Run("Fetch_Panic", func(t *testing.T) {
+		testFetcherPanic(t, provider)
+	})
+	t.Run("Fetch_NarrowClient", func(t *testing.T) {
+		testFetcherNarrowClient(t, provider)
+	})
+}
+
+// countingCompute returns a compute function returning its call count, after sleeping for delay
+func countingCompute(calls *atomic.Int32, delay time.Duration) caches.LoadFunc {
+	return func(ctx context.Context) ([]byte, error) {
+		n := calls.Add(1)
+		time.Sleep(delay)
+		return []byte(strconv.Itoa(int(n))), nil
+	}
+}
+
+// testFetcherMiss tests a missing value is computed and cached for its TTL and the grace period
+func testFetcherMiss(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, &caches.FetcherOptions{Grace: time.Minute})
+
+	key := "test:fetcher:miss"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls atomic.Int32
+	val, err := fetcher.Fetch(ctx, key, time.Minute, countingCompute(&calls, 0)).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+
+	pttl, err := cache.PTTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, pttl, time.Minute)
+}
+
+// testFetcherFresh tests a value far from its expiration is not recomputed
+func testFetcherFresh(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, nil)
+
+	key := "test:fetcher:fresh"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls atomic.Int32
+	for i := 0; i < 10; i++ {
+		val, err := fetcher.Fetch(ctx, key, time.Hour, countingCompute(&calls, 0)).Result()
+		require.NoError(t, err)
+		require.Equal(t, []byte("1"), val)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+// testFetcherEarlyRecompute tests a value which is slow to compute is recomputed before it expires
+func testFetcherEarlyRecompute(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, &caches.FetcherOptions{Beta: 1e6})
+
+	key := "test:fetcher:early"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls atomic.Int32
+	compute := countingCompute(&calls, 20*time.Millisecond)
+
+	val, err := fetcher.Fetch(ctx, key, time.Minute, compute).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+
+	// The current value is served while it is recomputed
+	val, err = fetcher.Fetch(ctx, key, time.Minute, compute).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+
+	require.Eventually(t, func() bool {
+		val, err := fetcher.Fetch(ctx, key, time.Minute, compute).Result()
+		return err == nil && string(val) != "1"
+	}, time.Second, 10*time.Millisecond)
+}
+
+// testFetcherStale tests an expired value is served during the grace period while it is recomputed
+func testFetcherStale(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, &caches.FetcherOptions{Grace: time.Minute})
+
+	key := "test:fetcher:stale"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	compute := func(ctx context.Context) ([]byte, error) {
+		n := calls.Add(1)
+		if n > 1 {
+			<-release
+		}
+		return []byte(strconv.Itoa(int(n))), nil
+	}
+
+	_, err := fetcher.Fetch(ctx, key, 100*time.Millisecond, compute).Result()
+	require.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+
+	// The stale value is returned without waiting for the recomputation
+	val, err := fetcher.Fetch(ctx, key, 100*time.Millisecond, compute).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		val, err := fetcher.Fetch(ctx, key, time.Minute, compute).Result()
+		return err == nil && string(val) == "2"
+	}, time.Second, 10*time.Millisecond)
+}
+
+// testFetcherForeignValue tests values not written by a Fetcher are recomputed
+func testFetcherForeignValue(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, nil)
+
+	key := "test:fetcher:foreign"
+	defer cache.Del(ctx, key)
+	require.NoError(t, cache.Set(ctx, key, "plain", 0).Err())
+
+	var calls atomic.Int32
+	val, err := fetcher.Fetch(ctx, key, time.Minute, countingCompute(&calls, 0)).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+}
+
+// testFetcherError tests compute errors are returned for missing values
+func testFetcherError(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, nil)
+
+	key := "test:fetcher:error"
+	cache.Del(ctx, key)
+
+	errCompute := errors.New("compute failed")
+	err := fetcher.Fetch(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		return nil, errCompute
+	}).Err()
+	require.ErrorIs(t, err, errCompute)
+
+	exists, err := cache.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
+
+// testFetcherPanic tests a panicking compute is returned as an error, and the value is computed on the next fetch
+func testFetcherPanic(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(cache, nil)
+
+	key := "test:fetcher:panic"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	err := fetcher.Fetch(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		panic("compute exploded")
+	}).Err()
+	require.ErrorIs(t, err, caches.ErrLoadPanic)
+
+	val, err := fetcher.Fetch(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		return []byte("computed"), nil
+	}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("computed"), val)
+}
+
+// narrowFetcherClient only exposes the commands required by a Fetcher
+type narrowFetcherClient struct {
+	caches.StringCommand
+	keys caches.KeyCommand
+}
+
+func (c narrowFetcherClient) PTTL(ctx context.Context, key string) caches.Result[time.Duration] {
+	return c.keys.PTTL(ctx, key)
+}
+
+// testFetcherNarrowClient tests a Fetcher only needs the string commands and PTTL
+func testFetcherNarrowClient(t *testing.T, provider FetcherProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	fetcher := caches.NewFetcher(narrowFetcherClient{StringCommand: cache, keys: cache}, nil)
+
+	key := "test:fetcher:narrow"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls atomic.Int32
+	for range 2 {
+		val, err := fetcher.Fetch(ctx, key, time.Minute, countingCompute(&calls, 0)).Result()
+		require.NoError(t, err)
+		require.Equal(t, []byte("1"), val)
+	}
+	require.Equal(t, int32(1), calls.Load())
+}
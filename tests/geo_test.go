@@ -0,0 +1,378 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// GeoCommandProvider provides the commands used by the GeoCommand tests
+type GeoCommandProvider interface {
+	GetGeoCommand() caches.GeoCommand
+	GetSortedSetCommand() caches.SortedSetCommand
+	GetKeyCommand() caches.KeyCommand
+	GetContext() context.Context
+}
+
+// RunGeoCommandTests runs all GeoCommand tests
+func RunGeoCommandTests(t *testing.T, provider GeoCommandProvider) {
+	t.Run("GeoAdd", func(t *testing.T) {
+		testGeoAdd(t, provider)
+	})
+	t.Run("GeoPos", func(t *testing.T) {
+		testGeoPos(t, provider)
+	})
+	t.Run("GeoDist", func(t *testing.T) {
+		testGeoDist(t, provider)
+	})
+	t.Run("GeoHash", func(t *testing.T) {
+		testGeoHash(t, provider)
+	})
+	t.Run("GeoSearch_Radius", func(t *testing.T) {
+		testGeoSearchRadius(t, provider)
+	})
+	t.Run("GeoSearch_Box", func(t *testing.T) {
+		testGeoSearchBox(t, provider)
+	})
+	t.Run("GeoSearch_Member", func(t *testing.T) {
+		testGeoSearchMember(t, provider)
+	})
+	t.Run("GeoSearchLocation", func(t *testing.T) {
+		testGeoSearchLocation(t, provider)
+	})
+	t.Run("GeoSearchStore", func(t *testing.T) {
+		testGeoSearchStore(t, provider)
+	})
+}
+
+// addSicily adds the cities of the Redis documentation examples to the index stored at key
+func addSicily(t *testing.T, provider GeoCommandProvider, key string) {
+	n, err := provider.GetGeoCommand().GeoAdd(provider.GetContext(), key,
+		&caches.GeoLocation{Name: "Palermo", Longitude: 13.361389, Latitude: 38.115556},
+		&caches.GeoLocation{Name: "Catania", Longitude: 15.087269, Latitude: 37.502669},
+		&caches.GeoLocation{Name: "edge1", Longitude: 12.758489, Latitude: 38.788135},
+		&caches.GeoLocation{Name: "edge2", Longitude: 17.241510, Latitude: 38.788135},
+	).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), n)
+}
+
+// testGeoAdd tests GeoAdd stores geohash scores and validates the coordinates
+func testGeoAdd(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	zsetCmd := provider.GetSortedSetCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:add"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	// Geo indexes are sorted sets scored by 52 bit geohashes
+	score, err := zsetCmd.ZScore(ctx, key, "Palermo").Result()
+	require.NoError(t, err)
+	require.Equal(t, float64(3479099956230698), score)
+
+	// Existing members are moved, not counted
+	n, err := geoCmd.GeoAdd(ctx, key,
+		&caches.GeoLocation{Name: "Palermo", Longitude: 15.087269, Latitude: 37.502669},
+		&caches.GeoLocation{Name: "Syracuse", Longitude: 15.293352, Latitude: 37.075474},
+	).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	score, err = zsetCmd.ZScore(ctx, key, "Palermo").Result()
+	require.NoError(t, err)
+	require.Equal(t, float64(3479447370796909), score)
+
+	// Positions out of the Mercator projection are rejected, nothing being added
+	err = geoCmd.GeoAdd(ctx, key,
+		&caches.GeoLocation{Name: "Ragusa", Longitude: 14.730, Latitude: 36.925},
+		&caches.GeoLocation{Name: "North Pole", Longitude: 0, Latitude: 90},
+	).Err()
+	require.Error(t, err)
+
+	card, err := zsetCmd.ZCard(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), card)
+}
+
+// testGeoPos tests GeoPos returns the decoded positions
+func testGeoPos(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:pos"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	pos, err := geoCmd.GeoPos(ctx, key, "Palermo", "missing", "Catania").Result()
+	require.NoError(t, err)
+	require.Len(t, pos, 3)
+	require.NotNil(t, pos[0])
+	require.InDelta(t, 13.361389, pos[0].Longitude, 1e-5)
+	require.InDelta(t, 38.115556, pos[0].Latitude, 1e-5)
+	require.Nil(t, pos[1])
+	require.NotNil(t, pos[2])
+	require.InDelta(t, 15.087269, pos[2].Longitude, 1e-5)
+	require.InDelta(t, 37.502669, pos[2].Latitude, 1e-5)
+
+	pos, err = geoCmd.GeoPos(ctx, "test:geo:pos_missing", "Palermo").Result()
+	require.NoError(t, err)
+	require.Equal(t, []*caches.GeoPos{nil}, pos)
+}
+
+// testGeoDist tests GeoDist in the supported units
+func testGeoDist(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:dist"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	dist, err := geoCmd.GeoDist(ctx, key, "Palermo", "Catania", "m").Result()
+	require.NoError(t, err)
+	require.Equal(t, 166274.1516, dist)
+
+	dist, err = geoCmd.GeoDist(ctx, key, "Palermo", "Catania", "").Result()
+	require.NoError(t, err)
+	require.Equal(t, 166.2742, dist)
+
+	dist, err = geoCmd.GeoDist(ctx, key, "Palermo", "Catania", "mi").Result()
+	require.NoError(t, err)
+	require.Equal(t, 103.3182, dist)
+
+	dist, err = geoCmd.GeoDist(ctx, key, "Palermo", "Palermo", "km").Result()
+	require.NoError(t, err)
+	require.Equal(t, float64(0), dist)
+
+	err = geoCmd.GeoDist(ctx, key, "Palermo", "missing", "km").Err()
+	require.ErrorIs(t, err, caches.Nil)
+
+	err = geoCmd.GeoDist(ctx, key, "Palermo", "Catania", "parsec").Err()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, caches.Nil)
+}
+
+// testGeoHash tests GeoHash returns the standard geohashes
+func testGeoHash(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:hash"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	hashes, err := geoCmd.GeoHash(ctx, key, "Palermo", "Catania", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"sqc8b49rny0", "sqdtr74hyu0", ""}, hashes)
+}
+
+// testGeoSearchRadius tests GeoSearch by radius around a position
+func testGeoSearchRadius(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:radius"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	q := &caches.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, RadiusUnit: "km", Sort: "ASC"}
+	members, err := geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania", "Palermo"}, members)
+
+	q.Sort = "DESC"
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Palermo", "Catania"}, members)
+
+	// The radius defaults to kilometers
+	q = &caches.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 400, Sort: "ASC"}
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania", "Palermo", "edge2", "edge1"}, members)
+
+	// COUNT returns the closest members
+	q.Count = 2
+	q.Sort = ""
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania", "Palermo"}, members)
+
+	// COUNT ANY returns any members
+	q.CountAny = true
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+
+	q = &caches.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 10, RadiusUnit: "m"}
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	members, err = geoCmd.GeoSearch(ctx, "test:geo:radius_missing", q).Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	q.RadiusUnit = "parsec"
+	require.Error(t, geoCmd.GeoSearch(ctx, key, q).Err())
+}
+
+// testGeoSearchBox tests GeoSearch by box around a position
+func testGeoSearchBox(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:box"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	q := &caches.GeoSearchQuery{Longitude: 15, Latitude: 37, BoxWidth: 400, BoxHeight: 400, BoxUnit: "km", Sort: "ASC"}
+	members, err := geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania", "Palermo", "edge2", "edge1"}, members)
+
+	// The box is narrower than the circle of the same width
+	q.BoxHeight = 260
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania", "Palermo"}, members)
+
+	q = &caches.GeoSearchQuery{Longitude: 15, Latitude: 37, BoxWidth: 50, BoxHeight: 150, Sort: "ASC"}
+	members, err = geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania"}, members)
+}
+
+// testGeoSearchMember tests GeoSearch around a member
+func testGeoSearchMember(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:member"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	q := &caches.GeoSearchQuery{Member: "Palermo", Radius: 200, Sort: "ASC"}
+	members, err := geoCmd.GeoSearch(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Palermo", "edge1", "Catania"}, members)
+
+	q.Member = "missing"
+	require.Error(t, geoCmd.GeoSearch(ctx, key, q).Err())
+}
+
+// testGeoSearchLocation tests GeoSearchLocation returns the requested fields
+func testGeoSearchLocation(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:geo:location"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	addSicily(t, provider, key)
+
+	q := &caches.GeoSearchLocationQuery{
+		GeoSearchQuery: caches.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200, Sort: "ASC"},
+		WithCoord:      true,
+		WithDist:       true,
+		WithHash:       true,
+	}
+	locs, err := geoCmd.GeoSearchLocation(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Len(t, locs, 2)
+
+	require.Equal(t, "Catania", locs[0].Name)
+	require.Equal(t, 56.4413, locs[0].Dist)
+	require.Equal(t, int64(3479447370796909), locs[0].GeoHash)
+	require.InDelta(t, 15.087269, locs[0].Longitude, 1e-5)
+	require.InDelta(t, 37.502669, locs[0].Latitude, 1e-5)
+
+	require.Equal(t, "Palermo", locs[1].Name)
+	require.Equal(t, 190.4424, locs[1].Dist)
+	require.Equal(t, int64(3479099956230698), locs[1].GeoHash)
+
+	// Only the requested fields are set
+	q.WithCoord, q.WithHash = false, false
+	q.RadiusUnit = "m"
+	q.Radius = 100000
+	locs, err = geoCmd.GeoSearchLocation(ctx, key, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.GeoLocation{{Name: "Catania", Dist: 56441.2579}}, locs)
+}
+
+// testGeoSearchStore tests GeoSearchStore stores geohashes or distances
+func testGeoSearchStore(t *testing.T, provider GeoCommandProvider) {
+	geoCmd := provider.GetGeoCommand()
+	zsetCmd := provider.GetSortedSetCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key, store := "test:geo:store", "test:geo:store_dest"
+	keyCmd.Del(ctx, key, store)
+	defer keyCmd.Del(ctx, key, store)
+
+	addSicily(t, provider, key)
+
+	q := &caches.GeoSearchStoreQuery{
+		GeoSearchQuery: caches.GeoSearchQuery{Longitude: 15, Latitude: 37, Radius: 200},
+	}
+	n, err := geoCmd.GeoSearchStore(ctx, key, store, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	// The stored members keep their geohash scores and can be searched
+	members, err := geoCmd.GeoSearch(ctx, store, &caches.GeoSearchQuery{Member: "Catania", Radius: 1, Sort: "ASC"}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Catania"}, members)
+
+	// Distances are stored unrounded in the unit of the shape
+	q.StoreDist = true
+	n, err = geoCmd.GeoSearchStore(ctx, key, store, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	vals, err := zsetCmd.ZRangeWithScores(ctx, store, 0, -1).Result()
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+	require.Equal(t, []byte("Catania"), vals[0].Member)
+	require.InDelta(t, 56.4413, vals[0].Score, 1e-4)
+	require.Equal(t, []byte("Palermo"), vals[1].Member)
+	require.InDelta(t, 190.4424, vals[1].Score, 1e-4)
+
+	// An empty result deletes the destination
+	q.Radius = 1
+	n, err = geoCmd.GeoSearchStore(ctx, key, store, q).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	exists, err := keyCmd.Exists(ctx, store).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
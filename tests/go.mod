@@ -7,6 +7,7 @@ require (
 	github.com/nalgeon/redka v0.6.0
 	github.com/redis/go-redis/v9 v9.16.0
 	github.com/rockcookies/go-caches v0.0.0
+	github.com/rockcookies/go-caches/providers/memory v0.0.0
 	github.com/rockcookies/go-caches/providers/redis v0.0.0
 	github.com/rockcookies/go-caches/providers/redka v0.0.0
 	github.com/stretchr/testify v1.11.1
@@ -22,6 +23,7 @@ require (
 
 replace (
 	github.com/rockcookies/go-caches => ../
+	github.com/rockcookies/go-caches/providers/memory => ../providers/memory
 	github.com/rockcookies/go-caches/providers/redis => ../providers/redis
 	github.com/rockcookies/go-caches/providers/redka => ../providers/redka
 )
@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rockcookies/go-caches"
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 // HashCommandProvider defines the interface for testing HashCommand implementations
 type HashCommandProvider interface {
 	GetHashCommand() caches.HashCommand
+	GetKeyCommand() caches.KeyCommand
 	GetContext() context.Context
 }
 
@@ -97,6 +99,42 @@ func RunHashCommandTests(t *testing.T, provider HashCommandProvider) {
 	t.Run("HScan_NonExistent", func(t *testing.T) {
 		testHScanNonExistent(t, provider)
 	})
+	t.Run("HExpire_and_HTTL", func(t *testing.T) {
+		testHExpireAndHTTL(t, provider)
+	})
+	t.Run("HExpire_Conditions", func(t *testing.T) {
+		testHExpireConditions(t, provider)
+	})
+	t.Run("HPExpire_Conditions", func(t *testing.T) {
+		testHPExpireConditions(t, provider)
+	})
+	t.Run("HPExpireAt", func(t *testing.T) {
+		testHPExpireAt(t, provider)
+	})
+	t.Run("HExpire_Expired", func(t *testing.T) {
+		testHExpireExpired(t, provider)
+	})
+	t.Run("HExpire_Past", func(t *testing.T) {
+		testHExpirePast(t, provider)
+	})
+	t.Run("HExpire_Fields", func(t *testing.T) {
+		testHExpireFields(t, provider)
+	})
+	t.Run("HExpire_DeletesKey", func(t *testing.T) {
+		testHExpireDeletesKey(t, provider)
+	})
+	t.Run("HPersist", func(t *testing.T) {
+		testHPersist(t, provider)
+	})
+	t.Run("HSet_ClearsTTL", func(t *testing.T) {
+		testHSetClearsTTL(t, provider)
+	})
+	t.Run("HGetEx", func(t *testing.T) {
+		testHGetEx(t, provider)
+	})
+	t.Run("HSetEx", func(t *testing.T) {
+		testHSetEx(t, provider)
+	})
 }
 
 // testHSetAndHGet tests basic HSet and HGet operations
@@ -707,3 +745,465 @@ func testHScanNonExistent(t *testing.T, provider HashCommandProvider) {
 	require.Empty(t, scanResult.Fields)
 	require.Equal(t, uint64(0), scanResult.Cursor)
 }
+
+// requireFieldTTL checks the remaining time to live of a field is within (ttl - 1s, ttl]
+func requireFieldTTL(t *testing.T, ttl, actual time.Duration) {
+	t.Helper()
+	require.Greater(t, actual, ttl-time.Second)
+	require.LessOrEqual(t, actual, ttl)
+}
+
+// testHExpireAndHTTL tests HExpire sets the TTL of fields and HTTL/HPTTL return it
+func testHExpireAndHTTL(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:expire"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2"}).Err())
+
+	codes, err := cmd.HExpire(ctx, key, 100*time.Second, "a", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, -2}, codes)
+
+	ttls, err := cmd.HTTL(ctx, key, "a", "b", "missing").Result()
+	require.NoError(t, err)
+	require.Len(t, ttls, 3)
+	requireFieldTTL(t, 100*time.Second, ttls[0])
+	require.Equal(t, time.Duration(-1), ttls[1])
+	require.Equal(t, time.Duration(-2), ttls[2])
+
+	codes, err = cmd.HPExpire(ctx, key, 50*time.Second, "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, codes)
+
+	ttls, err = cmd.HPTTL(ctx, key, "b").Result()
+	require.NoError(t, err)
+	require.Len(t, ttls, 1)
+	requireFieldTTL(t, 50*time.Second, ttls[0])
+
+	codes, err = cmd.HExpireAt(ctx, key, time.Now().Add(200*time.Second), "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, codes)
+
+	ttls, err = cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, 200*time.Second, ttls[0])
+
+	// Fields of a missing key do not exist
+	codes, err = cmd.HExpire(ctx, "test:hash:expire_missing", time.Minute, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{-2}, codes)
+
+	ttls, err = cmd.HTTL(ctx, "test:hash:expire_missing", "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-2}, ttls)
+
+	// The fields keep their values
+	val, err := cmd.HGet(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1"), val)
+}
+
+// testHExpireConditions tests the NX, XX, GT and LT variants of HExpire
+func testHExpireConditions(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:expire_conditions"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"volatile": "1", "persistent": "2"}).Err())
+	require.NoError(t, cmd.HExpire(ctx, key, 100*time.Second, "volatile").Err())
+
+	codes, err := cmd.HExpireNX(ctx, key, 200*time.Second, "volatile", "persistent", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1, -2}, codes)
+
+	require.NoError(t, cmd.HPersist(ctx, key, "persistent").Err())
+
+	codes, err = cmd.HExpireXX(ctx, key, 300*time.Second, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 0}, codes)
+
+	// Fields without expiration have an infinite TTL
+	codes, err = cmd.HExpireGT(ctx, key, 200*time.Second, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 0}, codes)
+
+	codes, err = cmd.HExpireGT(ctx, key, 400*time.Second, "volatile").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, codes)
+
+	codes, err = cmd.HExpireLT(ctx, key, 500*time.Second, "volatile").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{0}, codes)
+
+	codes, err = cmd.HExpireLT(ctx, key, 100*time.Second, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1}, codes)
+
+	ttls, err := cmd.HTTL(ctx, key, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, 100*time.Second, ttls[0])
+	requireFieldTTL(t, 100*time.Second, ttls[1])
+}
+
+// testHPExpireConditions tests the NX, XX, GT and LT variants of HPExpire
+func testHPExpireConditions(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:pexpire_conditions"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"volatile": "1", "persistent": "2"}).Err())
+	require.NoError(t, cmd.HPExpire(ctx, key, 100*time.Second, "volatile").Err())
+
+	codes, err := cmd.HPExpireNX(ctx, key, 200*time.Second, "volatile", "persistent", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1, -2}, codes)
+
+	require.NoError(t, cmd.HPersist(ctx, key, "persistent").Err())
+
+	codes, err = cmd.HPExpireXX(ctx, key, 300*time.Second, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 0}, codes)
+
+	codes, err = cmd.HPExpireGT(ctx, key, 200*time.Second, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 0}, codes)
+
+	codes, err = cmd.HPExpireGT(ctx, key, 400*time.Second, "volatile").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, codes)
+
+	codes, err = cmd.HPExpireLT(ctx, key, 500*time.Second, "volatile").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{0}, codes)
+
+	codes, err = cmd.HPExpireLT(ctx, key, 100*time.Second, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1}, codes)
+
+	ttls, err := cmd.HPTTL(ctx, key, "volatile", "persistent").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, 100*time.Second, ttls[0])
+	requireFieldTTL(t, 100*time.Second, ttls[1])
+}
+
+// testHPExpireAt tests HPExpireAt sets a millisecond timestamp, and a past timestamp deletes the field
+func testHPExpireAt(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:pexpire_at"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2"}).Err())
+
+	codes, err := cmd.HPExpireAt(ctx, key, time.Now().Add(1500*time.Millisecond), "a", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, -2}, codes)
+
+	ttls, err := cmd.HPTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, 1500*time.Millisecond, ttls[0])
+
+	codes, err = cmd.HPExpireAt(ctx, key, time.Now().Add(-time.Second), "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, codes)
+
+	exists, err := cmd.HExists(ctx, key, "b").Result()
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+// testHExpireExpired tests expired fields are no longer returned, and the hash is removed with its last field
+func testHExpireExpired(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:expire_expired"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2", "c": "3"}).Err())
+
+	codes, err := cmd.HPExpire(ctx, key, 100*time.Millisecond, "a", "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1}, codes)
+
+	time.Sleep(200 * time.Millisecond)
+
+	require.Equal(t, caches.Nil, cmd.HGet(ctx, key, "a").Err())
+
+	exists, err := cmd.HExists(ctx, key, "b").Result()
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	n, err := cmd.HLen(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	fields, err := cmd.HKeys(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, fields)
+
+	vals, err := cmd.HVals(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("3")}, vals)
+
+	all, err := cmd.HGetAll(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"c": []byte("3")}, all)
+
+	got, err := cmd.HMGet(ctx, key, "a", "c").Result()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"c": []byte("3")}, got)
+
+	scan, err := cmd.HScan(ctx, key, 0, "", 10).Result()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"c": []byte("3")}, scan.Fields)
+
+	ttls, err := cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-2}, ttls)
+
+	// An expired field is created again without TTL
+	set, err := cmd.HSetNX(ctx, key, "a", "4").Result()
+	require.NoError(t, err)
+	require.True(t, set)
+
+	ttls, err = cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1}, ttls)
+
+	// The hash is empty once all its fields expired
+	require.NoError(t, cmd.HPExpire(ctx, key, 100*time.Millisecond, "a", "c").Err())
+	time.Sleep(200 * time.Millisecond)
+
+	n, err = cmd.HLen(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	all, err = cmd.HGetAll(ctx, key).Result()
+	require.NoError(t, err)
+	require.Empty(t, all)
+}
+
+// testHExpirePast tests a zero timeout or a past time deletes the fields
+func testHExpirePast(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:expire_past"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2", "c": "3"}).Err())
+
+	codes, err := cmd.HExpire(ctx, key, 0, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, codes)
+
+	codes, err = cmd.HExpireAt(ctx, key, time.Now().Add(-time.Hour), "b", "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, -2}, codes)
+
+	fields, err := cmd.HKeys(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, fields)
+}
+
+// testHExpireFields tests that the expiration of fields does not change the fields of the hash
+func testHExpireFields(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:expire_fields"
+	provider.GetKeyCommand().Del(ctx, key)
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2"}).Err())
+	require.NoError(t, cmd.HExpire(ctx, key, time.Hour, "a").Err())
+
+	n, err := cmd.HLen(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	fields, err := cmd.HKeys(ctx, key).Result()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"a", "b"}, fields)
+
+	all, err := cmd.HGetAll(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"a": []byte("1"), "b": []byte("2")}, all)
+
+	scan, err := cmd.HScan(ctx, key, 0, "", 10).Result()
+	require.NoError(t, err)
+	require.Equal(t, all, scan.Fields)
+
+	// Deleting the fields deletes the hash
+	deleted, err := cmd.HDel(ctx, key, "a", "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	exists, err := provider.GetKeyCommand().Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
+
+// testHExpireDeletesKey tests that a hash no longer exists once all its fields expired
+func testHExpireDeletesKey(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	keys := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:expire_deletes_key"
+	keys.Del(ctx, key)
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2"}).Err())
+	require.NoError(t, cmd.HPExpire(ctx, key, 100*time.Millisecond, "a", "b").Err())
+
+	time.Sleep(200 * time.Millisecond)
+
+	exists, err := keys.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+
+	typ, err := keys.Type(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, "none", typ)
+
+	// The hash is created again without the expired fields
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"c": "3"}).Err())
+
+	fields, err := cmd.HKeys(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, fields)
+
+	ttls, err := cmd.HTTL(ctx, key, "c").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1}, ttls)
+	keys.Del(ctx, key)
+}
+
+// testHPersist tests HPersist removes the TTL of fields
+func testHPersist(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:persist"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2"}).Err())
+	require.NoError(t, cmd.HExpire(ctx, key, time.Minute, "a").Err())
+
+	codes, err := cmd.HPersist(ctx, key, "a", "b", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, -1, -2}, codes)
+
+	ttls, err := cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1}, ttls)
+}
+
+// testHSetClearsTTL tests HSet removes the TTL of the fields it sets, unlike HIncrBy
+func testHSetClearsTTL(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:set_clears_ttl"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "counter": "1"}).Err())
+	require.NoError(t, cmd.HExpire(ctx, key, time.Minute, "a", "counter").Err())
+
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "2"}).Err())
+	require.NoError(t, cmd.HIncrBy(ctx, key, "counter", 1).Err())
+
+	ttls, err := cmd.HTTL(ctx, key, "a", "counter").Result()
+	require.NoError(t, err)
+	require.Len(t, ttls, 2)
+	require.Equal(t, time.Duration(-1), ttls[0])
+	requireFieldTTL(t, time.Minute, ttls[1])
+
+	// Deleted fields lose their TTL
+	require.NoError(t, cmd.HDel(ctx, key, "counter").Err())
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"counter": "1"}).Err())
+
+	ttls, err = cmd.HTTL(ctx, key, "counter").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1}, ttls)
+}
+
+// testHGetEx tests HGetEx returns the values of fields and updates their TTL
+func testHGetEx(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:getex"
+	require.NoError(t, cmd.HSet(ctx, key, map[string]any{"a": "1", "b": "2"}).Err())
+
+	vals, err := cmd.HGetEx(ctx, key, time.Minute, "a", "missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("1"), nil}, vals)
+
+	ttls, err := cmd.HTTL(ctx, key, "a", "b").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, time.Minute, ttls[0])
+	require.Equal(t, time.Duration(-1), ttls[1])
+
+	// KeepTTL only reads the fields
+	vals, err = cmd.HGetEx(ctx, key, caches.KeepTTL, "a", "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("1"), []byte("2")}, vals)
+
+	ttls, err = cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, time.Minute, ttls[0])
+
+	// 0 persists the fields
+	require.NoError(t, cmd.HGetEx(ctx, key, 0, "a").Err())
+
+	ttls, err = cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1}, ttls)
+
+	vals, err = cmd.HGetEx(ctx, "test:hash:getex_missing", time.Minute, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{nil}, vals)
+}
+
+// testHSetEx tests HSetEx sets fields with their TTL under the FNX and FXX conditions
+func testHSetEx(t *testing.T, provider HashCommandProvider) {
+	cmd := provider.GetHashCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hash:setex"
+
+	set, err := cmd.HSetEx(ctx, key, "", time.Minute, map[string]any{"a": "1", "b": "2"}).Result()
+	require.NoError(t, err)
+	require.True(t, set)
+
+	ttls, err := cmd.HTTL(ctx, key, "a", "b").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, time.Minute, ttls[0])
+	requireFieldTTL(t, time.Minute, ttls[1])
+
+	// FNX fails when any field exists, setting none of them
+	set, err = cmd.HSetEx(ctx, key, "FNX", time.Minute, map[string]any{"b": "3", "c": "3"}).Result()
+	require.NoError(t, err)
+	require.False(t, set)
+
+	exists, err := cmd.HExists(ctx, key, "c").Result()
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// FXX fails when any field is missing
+	set, err = cmd.HSetEx(ctx, key, "FXX", time.Minute, map[string]any{"a": "3", "c": "3"}).Result()
+	require.NoError(t, err)
+	require.False(t, set)
+
+	set, err = cmd.HSetEx(ctx, key, "FXX", caches.KeepTTL, map[string]any{"a": "4"}).Result()
+	require.NoError(t, err)
+	require.True(t, set)
+
+	val, err := cmd.HGet(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("4"), val)
+
+	ttls, err = cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	requireFieldTTL(t, time.Minute, ttls[0])
+
+	// Without expiration, the TTL of the fields is removed
+	set, err = cmd.HSetEx(ctx, key, "", 0, map[string]any{"a": "5"}).Result()
+	require.NoError(t, err)
+	require.True(t, set)
+
+	ttls, err = cmd.HTTL(ctx, key, "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1}, ttls)
+}
@@ -0,0 +1,211 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// HyperLogLogCommandProvider provides the commands used by the HyperLogLogCommand tests
+type HyperLogLogCommandProvider interface {
+	GetHyperLogLogCommand() caches.HyperLogLogCommand
+	GetStringCommand() caches.StringCommand
+	GetKeyCommand() caches.KeyCommand
+	GetContext() context.Context
+}
+
+// RunHyperLogLogCommandTests runs all HyperLogLogCommand tests
+func RunHyperLogLogCommandTests(t *testing.T, provider HyperLogLogCommandProvider) {
+	t.Run("PFAdd", func(t *testing.T) {
+		testPFAdd(t, provider)
+	})
+	t.Run("PFCount_Small", func(t *testing.T) {
+		testPFCountSmall(t, provider)
+	})
+	t.Run("PFCount_Accuracy", func(t *testing.T) {
+		testPFCountAccuracy(t, provider)
+	})
+	t.Run("PFCount_MultipleKeys", func(t *testing.T) {
+		testPFCountMultipleKeys(t, provider)
+	})
+	t.Run("PFMerge", func(t *testing.T) {
+		testPFMerge(t, provider)
+	})
+	t.Run("WrongType", func(t *testing.T) {
+		testHyperLogLogWrongType(t, provider)
+	})
+}
+
+// hllStdError is the standard error of the HyperLogLog estimates, tolerated three times
+const hllStdError = 0.0081
+
+// hllElements returns the elements prefix:from to prefix:to-1
+func hllElements(prefix string, from, to int) []any {
+	els := make([]any, 0, to-from)
+	for i := from; i < to; i++ {
+		els = append(els, fmt.Sprintf("%s:%d", prefix, i))
+	}
+	return els
+}
+
+// testPFAdd tests PFAdd reports whether the HyperLogLog changed
+func testPFAdd(t *testing.T, provider HyperLogLogCommandProvider) {
+	hllCmd := provider.GetHyperLogLogCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key, empty := "test:hll:add", "test:hll:add_empty"
+	keyCmd.Del(ctx, key, empty)
+	defer keyCmd.Del(ctx, key, empty)
+
+	n, err := hllCmd.PFAdd(ctx, key, "a", "b", "c").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = hllCmd.PFAdd(ctx, key, "a", "b").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	n, err = hllCmd.PFAdd(ctx, key, "d").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// Without elements, only a missing key changes
+	n, err = hllCmd.PFAdd(ctx, empty).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = hllCmd.PFAdd(ctx, empty).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	count, err := hllCmd.PFCount(ctx, empty).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+// testPFCountSmall tests small cardinalities are counted exactly
+func testPFCountSmall(t *testing.T, provider HyperLogLogCommandProvider) {
+	hllCmd := provider.GetHyperLogLogCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hll:small"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	require.NoError(t, hllCmd.PFAdd(ctx, key, "a", "b", "c", "d", "e", "f", "g").Err())
+	require.NoError(t, hllCmd.PFAdd(ctx, key, "a", "g").Err())
+
+	count, err := hllCmd.PFCount(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), count)
+
+	count, err = hllCmd.PFCount(ctx, "test:hll:small_missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+// testPFCountAccuracy tests large cardinalities are estimated within the standard error
+func testPFCountAccuracy(t *testing.T, provider HyperLogLogCommandProvider) {
+	hllCmd := provider.GetHyperLogLogCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:hll:accuracy"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	for from := 0; from < 20000; from += 1000 {
+		require.NoError(t, hllCmd.PFAdd(ctx, key, hllElements("visitor", from, from+1000)...).Err())
+	}
+
+	count, err := hllCmd.PFCount(ctx, key).Result()
+	require.NoError(t, err)
+	require.InEpsilon(t, 20000, count, 3*hllStdError)
+}
+
+// testPFCountMultipleKeys tests PFCount counts the union of several keys
+func testPFCountMultipleKeys(t *testing.T, provider HyperLogLogCommandProvider) {
+	hllCmd := provider.GetHyperLogLogCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key1, key2 := "test:hll:union1", "test:hll:union2"
+	keyCmd.Del(ctx, key1, key2)
+	defer keyCmd.Del(ctx, key1, key2)
+
+	require.NoError(t, hllCmd.PFAdd(ctx, key1, hllElements("user", 0, 5000)...).Err())
+	require.NoError(t, hllCmd.PFAdd(ctx, key2, hllElements("user", 2500, 7500)...).Err())
+
+	count, err := hllCmd.PFCount(ctx, key1, key2, "test:hll:union_missing").Result()
+	require.NoError(t, err)
+	require.InEpsilon(t, 7500, count, 3*hllStdError)
+}
+
+// testPFMerge tests PFMerge merges the sources into the destination, keeping its elements
+func testPFMerge(t *testing.T, provider HyperLogLogCommandProvider) {
+	hllCmd := provider.GetHyperLogLogCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key1, key2, dest := "test:hll:merge1", "test:hll:merge2", "test:hll:merge_dest"
+	keyCmd.Del(ctx, key1, key2, dest)
+	defer keyCmd.Del(ctx, key1, key2, dest)
+
+	require.NoError(t, hllCmd.PFAdd(ctx, key1, "a", "b", "c").Err())
+	require.NoError(t, hllCmd.PFAdd(ctx, key2, "c", "d").Err())
+	require.NoError(t, hllCmd.PFAdd(ctx, dest, "e").Err())
+
+	require.NoError(t, hllCmd.PFMerge(ctx, dest, key1, key2, "test:hll:merge_missing").Err())
+
+	count, err := hllCmd.PFCount(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+
+	// The sources are unchanged
+	count, err = hllCmd.PFCount(ctx, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// HyperLogLogs are stored as strings in the Redis encoding
+	val, err := strCmd.Get(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, "HYLL", string(val[:4]))
+
+	// Merging into a missing key creates it
+	dest2 := "test:hll:merge_dest2"
+	defer keyCmd.Del(ctx, dest2)
+	require.NoError(t, hllCmd.PFMerge(ctx, dest2, key1).Err())
+
+	count, err = hllCmd.PFCount(ctx, dest2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+// testHyperLogLogWrongType tests strings which are not HyperLogLogs are rejected
+func testHyperLogLogWrongType(t *testing.T, provider HyperLogLogCommandProvider) {
+	hllCmd := provider.GetHyperLogLogCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key, valid := "test:hll:wrong", "test:hll:wrong_valid"
+	defer keyCmd.Del(ctx, key, valid)
+	require.NoError(t, strCmd.Set(ctx, key, "not a hyperloglog", 0).Err())
+	require.NoError(t, hllCmd.PFAdd(ctx, valid, "a").Err())
+
+	require.Error(t, hllCmd.PFAdd(ctx, key, "a").Err())
+	require.Error(t, hllCmd.PFCount(ctx, key).Err())
+	require.Error(t, hllCmd.PFCount(ctx, valid, key).Err())
+	require.Error(t, hllCmd.PFMerge(ctx, valid, key).Err())
+
+	// Nothing was overwritten
+	val, err := strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("not a hyperloglog"), val)
+}
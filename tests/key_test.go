@@ -132,6 +132,15 @@ func RunKeyCommandTests(t *testing.T, provider KeyCommandProvider) {
 	t.Run("Scan_EmptyDB", func(t *testing.T) {
 		testScanEmptyDB(t, provider)
 	})
+	t.Run("DBSizePrefix", func(t *testing.T) {
+		testDBSizePrefix(t, provider)
+	})
+	t.Run("FlushPrefix", func(t *testing.T) {
+		testFlushPrefix(t, provider)
+	})
+	t.Run("FlushPrefix_SpecialCharacters", func(t *testing.T) {
+		testFlushPrefixSpecialCharacters(t, provider)
+	})
 }
 
 // testDelSingleKey tests Del on a single key
@@ -899,3 +908,93 @@ func testScanEmptyDB(t *testing.T, provider KeyCommandProvider) {
 	// Cursor might still be non-zero if there are other keys in DB
 	require.Empty(t, scanResult.Keys)
 }
+
+// testDBSizePrefix tests DBSizePrefix counts the keys under a prefix only
+func testDBSizePrefix(t *testing.T, provider KeyCommandProvider) {
+	keyCmd := provider.GetKeyCommand()
+	strCmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	keys := []string{
+		"test:key:size_prefix:1",
+		"test:key:size_prefix:2",
+		"test:key:size_prefix:3",
+		"test:key:size_other",
+	}
+	for _, key := range keys {
+		require.NoError(t, strCmd.Set(ctx, key, "value", 0).Err())
+	}
+	defer keyCmd.Del(ctx, keys...)
+
+	result := keyCmd.DBSizePrefix(ctx, "test:key:size_prefix:")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(3), result.Val())
+
+	// The provider namespace includes all of them
+	total := keyCmd.DBSizePrefix(ctx, "")
+	require.NoError(t, total.Err())
+	require.GreaterOrEqual(t, total.Val(), int64(4))
+
+	size := keyCmd.DBSize(ctx)
+	require.NoError(t, size.Err())
+	require.Equal(t, total.Val(), size.Val())
+}
+
+// testFlushPrefix tests FlushPrefix deletes the keys under a prefix only
+func testFlushPrefix(t *testing.T, provider KeyCommandProvider) {
+	keyCmd := provider.GetKeyCommand()
+	strCmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	keys := []string{
+		"test:key:flush_prefix:1",
+		"test:key:flush_prefix:2",
+		"test:key:flush_other",
+	}
+	for _, key := range keys {
+		require.NoError(t, strCmd.Set(ctx, key, "value", 0).Err())
+	}
+	defer keyCmd.Del(ctx, keys...)
+
+	result := keyCmd.FlushPrefix(ctx, "test:key:flush_prefix:")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(2), result.Val())
+
+	exists := keyCmd.Exists(ctx, keys...)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(1), exists.Val())
+
+	// Nothing left to delete
+	result = keyCmd.FlushPrefix(ctx, "test:key:flush_prefix:")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(0), result.Val())
+}
+
+// testFlushPrefixSpecialCharacters tests glob characters in the prefix are matched literally
+func testFlushPrefixSpecialCharacters(t *testing.T, provider KeyCommandProvider) {
+	keyCmd := provider.GetKeyCommand()
+	strCmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	keys := []string{
+		"test:key:flush*[a]?:1",
+		"test:key:flushx[a]?:1",
+		"test:key:flush*a!:1",
+	}
+	for _, key := range keys {
+		require.NoError(t, strCmd.Set(ctx, key, "value", 0).Err())
+	}
+	defer keyCmd.Del(ctx, keys...)
+
+	size := keyCmd.DBSizePrefix(ctx, "test:key:flush*[a]?")
+	require.NoError(t, size.Err())
+	require.Equal(t, int64(1), size.Val())
+
+	result := keyCmd.FlushPrefix(ctx, "test:key:flush*[a]?")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(1), result.Val())
+
+	exists := keyCmd.Exists(ctx, keys...)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(2), exists.Val())
+}
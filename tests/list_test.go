@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rockcookies/go-caches"
 	"github.com/stretchr/testify/require"
@@ -88,6 +89,69 @@ func RunListCommandTests(t *testing.T, provider ListCommandProvider) {
 	t.Run("RPopLPush_SameKey", func(t *testing.T) {
 		testRPopLPushSameKey(t, provider)
 	})
+	t.Run("BLPop", func(t *testing.T) {
+		testBLPop(t, provider)
+	})
+	t.Run("BLPop_Timeout", func(t *testing.T) {
+		testBLPopTimeout(t, provider)
+	})
+	t.Run("BLPop_WakeOnPush", func(t *testing.T) {
+		testBLPopWakeOnPush(t, provider)
+	})
+	t.Run("BLPop_ContextCanceled", func(t *testing.T) {
+		testBLPopContextCanceled(t, provider)
+	})
+	t.Run("BRPop", func(t *testing.T) {
+		testBRPop(t, provider)
+	})
+	t.Run("BLMove", func(t *testing.T) {
+		testBLMove(t, provider)
+	})
+	t.Run("BLMove_WakeOnPush", func(t *testing.T) {
+		testBLMoveWakeOnPush(t, provider)
+	})
+	t.Run("BRPopLPush", func(t *testing.T) {
+		testBRPopLPush(t, provider)
+	})
+	t.Run("BRPopLPush_Timeout", func(t *testing.T) {
+		testBRPopLPushTimeout(t, provider)
+	})
+	t.Run("LMove", func(t *testing.T) {
+		testLMove(t, provider)
+	})
+	t.Run("LMove_SameKey", func(t *testing.T) {
+		testLMoveSameKey(t, provider)
+	})
+	t.Run("LMove_NonExistent", func(t *testing.T) {
+		testLMoveNonExistent(t, provider)
+	})
+	t.Run("LPos", func(t *testing.T) {
+		testLPos(t, provider)
+	})
+	t.Run("LPos_Rank", func(t *testing.T) {
+		testLPosRank(t, provider)
+	})
+	t.Run("LPos_MaxLen", func(t *testing.T) {
+		testLPosMaxLen(t, provider)
+	})
+	t.Run("LPos_NotFound", func(t *testing.T) {
+		testLPosNotFound(t, provider)
+	})
+	t.Run("LPosCount", func(t *testing.T) {
+		testLPosCount(t, provider)
+	})
+	t.Run("LPushX_and_RPushX", func(t *testing.T) {
+		testLPushXAndRPushX(t, provider)
+	})
+	t.Run("LPushX_NonExistent", func(t *testing.T) {
+		testLPushXNonExistent(t, provider)
+	})
+	t.Run("LMPop", func(t *testing.T) {
+		testLMPop(t, provider)
+	})
+	t.Run("LMPop_Empty", func(t *testing.T) {
+		testLMPopEmpty(t, provider)
+	})
 }
 
 // testLPushAndLRange tests LPush and LRange operations
@@ -582,3 +646,428 @@ func testRPopLPushSameKey(t *testing.T, provider ListCommandProvider) {
 	require.Equal(t, []byte("one"), rangeResult.Val()[1])
 	require.Equal(t, []byte("two"), rangeResult.Val()[2])
 }
+
+// clearLists empties the given lists, as ListCommand has no Del
+func clearLists(ctx context.Context, cmd caches.ListCommand, keys ...string) {
+	for _, key := range keys {
+		cmd.LTrim(ctx, key, 1, 0)
+	}
+}
+
+// testBLPop tests BLPop returns the first element of the first non-empty list
+func testBLPop(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	empty := "test:list:blpop_empty"
+	key := "test:list:blpop"
+	clearLists(ctx, cmd, empty, key)
+
+	cmd.RPush(ctx, key, "one", "two")
+
+	result := cmd.BLPop(ctx, time.Second, empty, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, key, result.Val().Key)
+	require.Equal(t, []byte("one"), result.Val().Element)
+}
+
+// testBLPopTimeout tests BLPop returns Nil when the timeout expires
+func testBLPopTimeout(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:blpop_timeout"
+	clearLists(ctx, cmd, key)
+
+	result := cmd.BLPop(ctx, 100*time.Millisecond, key)
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testBLPopWakeOnPush tests BLPop is woken up by a push
+func testBLPopWakeOnPush(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:blpop_wake"
+	clearLists(ctx, cmd, key)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cmd.RPush(ctx, key, "pushed")
+	}()
+
+	result := cmd.BLPop(ctx, 5*time.Second, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, key, result.Val().Key)
+	require.Equal(t, []byte("pushed"), result.Val().Element)
+}
+
+// testBLPopContextCanceled tests BLPop returns when ctx is done
+func testBLPopContextCanceled(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+
+	key := "test:list:blpop_canceled"
+	clearLists(provider.GetContext(), cmd, key)
+
+	ctx, cancel := context.WithTimeout(provider.GetContext(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result := cmd.BLPop(ctx, 0, key)
+	require.Error(t, result.Err())
+	require.NotErrorIs(t, result.Err(), caches.Nil)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+// testBRPop tests BRPop returns the last element of the first non-empty list
+func testBRPop(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	empty := "test:list:brpop_empty"
+	key := "test:list:brpop"
+	clearLists(ctx, cmd, empty, key)
+
+	cmd.RPush(ctx, key, "one", "two")
+
+	result := cmd.BRPop(ctx, time.Second, empty, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, key, result.Val().Key)
+	require.Equal(t, []byte("two"), result.Val().Element)
+}
+
+// testBLMove tests BLMove with explicit directions
+func testBLMove(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	source := "test:list:blmove_src"
+	dest := "test:list:blmove_dest"
+	clearLists(ctx, cmd, source, dest)
+
+	cmd.RPush(ctx, source, "one", "two", "three")
+	cmd.RPush(ctx, dest, "a")
+
+	result := cmd.BLMove(ctx, source, dest, caches.ListLeft, caches.ListRight, time.Second)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("one"), result.Val())
+
+	destResult := cmd.LRange(ctx, dest, 0, -1)
+	require.NoError(t, destResult.Err())
+	require.Equal(t, [][]byte{[]byte("a"), []byte("one")}, destResult.Val())
+}
+
+// testBLMoveWakeOnPush tests BLMove is woken up by a push
+func testBLMoveWakeOnPush(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	source := "test:list:blmove_wake_src"
+	dest := "test:list:blmove_wake_dest"
+	clearLists(ctx, cmd, source, dest)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cmd.LPush(ctx, source, "job")
+	}()
+
+	result := cmd.BLMove(ctx, source, dest, caches.ListRight, caches.ListLeft, 5*time.Second)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("job"), result.Val())
+
+	length := cmd.LLen(ctx, dest)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(1), length.Val())
+}
+
+// testBRPopLPush tests BRPopLPush moves the tail of source to the head of destination
+func testBRPopLPush(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	source := "test:list:brpoplpush_src"
+	dest := "test:list:brpoplpush_dest"
+	clearLists(ctx, cmd, source, dest)
+
+	cmd.RPush(ctx, source, "one", "two")
+	cmd.RPush(ctx, dest, "a")
+
+	result := cmd.BRPopLPush(ctx, source, dest, time.Second)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("two"), result.Val())
+
+	destResult := cmd.LRange(ctx, dest, 0, -1)
+	require.NoError(t, destResult.Err())
+	require.Equal(t, [][]byte{[]byte("two"), []byte("a")}, destResult.Val())
+}
+
+// testBRPopLPushTimeout tests BRPopLPush returns Nil when the timeout expires
+func testBRPopLPushTimeout(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	source := "test:list:brpoplpush_timeout_src"
+	dest := "test:list:brpoplpush_timeout_dest"
+	clearLists(ctx, cmd, source)
+
+	result := cmd.BRPopLPush(ctx, source, dest, 100*time.Millisecond)
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testLMove tests LMove with every combination of directions
+func testLMove(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	source := "test:list:lmove_src"
+	dest := "test:list:lmove_dest"
+	clearLists(ctx, cmd, source, dest)
+
+	cmd.RPush(ctx, source, "one", "two", "three", "four")
+
+	result := cmd.LMove(ctx, source, dest, caches.ListLeft, caches.ListLeft)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("one"), result.Val())
+
+	result = cmd.LMove(ctx, source, dest, caches.ListRight, caches.ListRight)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("four"), result.Val())
+
+	result = cmd.LMove(ctx, source, dest, caches.ListLeft, caches.ListRight)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("two"), result.Val())
+
+	result = cmd.LMove(ctx, source, dest, caches.ListRight, caches.ListLeft)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("three"), result.Val())
+
+	destResult := cmd.LRange(ctx, dest, 0, -1)
+	require.NoError(t, destResult.Err())
+	require.Equal(t, [][]byte{[]byte("three"), []byte("one"), []byte("four"), []byte("two")}, destResult.Val())
+
+	length := cmd.LLen(ctx, source)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(0), length.Val())
+}
+
+// testLMoveSameKey tests LMove on the same key (rotation)
+func testLMoveSameKey(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:lmove_same"
+	clearLists(ctx, cmd, key)
+
+	cmd.RPush(ctx, key, "one", "two", "three")
+
+	result := cmd.LMove(ctx, key, key, caches.ListLeft, caches.ListRight)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("one"), result.Val())
+
+	rangeResult := cmd.LRange(ctx, key, 0, -1)
+	require.NoError(t, rangeResult.Err())
+	require.Equal(t, [][]byte{[]byte("two"), []byte("three"), []byte("one")}, rangeResult.Val())
+}
+
+// testLMoveNonExistent tests LMove on a non-existent source
+func testLMoveNonExistent(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	source := "test:list:lmove_missing_src"
+	dest := "test:list:lmove_missing_dest"
+	clearLists(ctx, cmd, source, dest)
+
+	result := cmd.LMove(ctx, source, dest, caches.ListLeft, caches.ListLeft)
+	require.ErrorIs(t, result.Err(), caches.Nil)
+
+	length := cmd.LLen(ctx, dest)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(0), length.Val())
+}
+
+// testLPos tests LPos returns the index of the first match
+func testLPos(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:lpos"
+	clearLists(ctx, cmd, key)
+
+	cmd.RPush(ctx, key, "a", "b", "c", "b", "d", "b")
+
+	result := cmd.LPos(ctx, key, "b", caches.LPosArgs{})
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(1), result.Val())
+}
+
+// testLPosRank tests LPos with positive and negative ranks
+func testLPosRank(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:lpos_rank"
+	clearLists(ctx, cmd, key)
+
+	cmd.RPush(ctx, key, "a", "b", "c", "b", "d", "b")
+
+	result := cmd.LPos(ctx, key, "b", caches.LPosArgs{Rank: 2})
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(3), result.Val())
+
+	result = cmd.LPos(ctx, key, "b", caches.LPosArgs{Rank: -1})
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(5), result.Val())
+
+	result = cmd.LPos(ctx, key, "b", caches.LPosArgs{Rank: -3})
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(1), result.Val())
+
+	result = cmd.LPos(ctx, key, "b", caches.LPosArgs{Rank: 4})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testLPosMaxLen tests LPos only compares MaxLen elements
+func testLPosMaxLen(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:lpos_maxlen"
+	clearLists(ctx, cmd, key)
+
+	cmd.RPush(ctx, key, "a", "b", "c", "d")
+
+	result := cmd.LPos(ctx, key, "c", caches.LPosArgs{MaxLen: 2})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+
+	result = cmd.LPos(ctx, key, "c", caches.LPosArgs{MaxLen: 3})
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(2), result.Val())
+
+	result = cmd.LPos(ctx, key, "c", caches.LPosArgs{Rank: -1, MaxLen: 2})
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(2), result.Val())
+}
+
+// testLPosNotFound tests LPos returns Nil when nothing matches
+func testLPosNotFound(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:lpos_notfound"
+	clearLists(ctx, cmd, key)
+
+	result := cmd.LPos(ctx, key, "a", caches.LPosArgs{})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+
+	cmd.RPush(ctx, key, "a", "b")
+
+	result = cmd.LPos(ctx, key, "z", caches.LPosArgs{})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testLPosCount tests LPosCount returns the indexes of several matches
+func testLPosCount(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:lpos_count"
+	clearLists(ctx, cmd, key)
+
+	cmd.RPush(ctx, key, "a", "b", "c", "b", "d", "b")
+
+	result := cmd.LPosCount(ctx, key, "b", 2, caches.LPosArgs{})
+	require.NoError(t, result.Err())
+	require.Equal(t, []int64{1, 3}, result.Val())
+
+	result = cmd.LPosCount(ctx, key, "b", 0, caches.LPosArgs{})
+	require.NoError(t, result.Err())
+	require.Equal(t, []int64{1, 3, 5}, result.Val())
+
+	result = cmd.LPosCount(ctx, key, "b", 0, caches.LPosArgs{Rank: -2})
+	require.NoError(t, result.Err())
+	require.Equal(t, []int64{3, 1}, result.Val())
+
+	result = cmd.LPosCount(ctx, key, "z", 0, caches.LPosArgs{})
+	require.NoError(t, result.Err())
+	require.Empty(t, result.Val())
+}
+
+// testLPushXAndRPushX tests LPushX and RPushX on an existing list
+func testLPushXAndRPushX(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:pushx"
+	clearLists(ctx, cmd, key)
+
+	cmd.RPush(ctx, key, "middle")
+
+	result := cmd.LPushX(ctx, key, "b", "a")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(3), result.Val())
+
+	result = cmd.RPushX(ctx, key, "y", "z")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(5), result.Val())
+
+	rangeResult := cmd.LRange(ctx, key, 0, -1)
+	require.NoError(t, rangeResult.Err())
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("middle"), []byte("y"), []byte("z")}, rangeResult.Val())
+}
+
+// testLPushXNonExistent tests LPushX and RPushX do not create the list
+func testLPushXNonExistent(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key := "test:list:pushx_missing"
+	clearLists(ctx, cmd, key)
+
+	result := cmd.LPushX(ctx, key, "a")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(0), result.Val())
+
+	result = cmd.RPushX(ctx, key, "a")
+	require.NoError(t, result.Err())
+	require.Equal(t, int64(0), result.Val())
+
+	length := cmd.LLen(ctx, key)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(0), length.Val())
+}
+
+// testLMPop tests LMPop pops from the first non-empty list
+func testLMPop(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	empty := "test:list:lmpop_empty"
+	key := "test:list:lmpop"
+	clearLists(ctx, cmd, empty, key)
+
+	cmd.RPush(ctx, key, "one", "two", "three")
+
+	result := cmd.LMPop(ctx, caches.ListLeft, 2, empty, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, key, result.Val().Key)
+	require.Equal(t, [][]byte{[]byte("one"), []byte("two")}, result.Val().Elements)
+
+	result = cmd.LMPop(ctx, caches.ListRight, 5, empty, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, key, result.Val().Key)
+	require.Equal(t, [][]byte{[]byte("three")}, result.Val().Elements)
+}
+
+// testLMPopEmpty tests LMPop returns Nil when all lists are empty
+func testLMPopEmpty(t *testing.T, provider ListCommandProvider) {
+	cmd := provider.GetListCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:list:lmpop_empty1"
+	key2 := "test:list:lmpop_empty2"
+	clearLists(ctx, cmd, key1, key2)
+
+	result := cmd.LMPop(ctx, caches.ListLeft, 1, key1, key2)
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
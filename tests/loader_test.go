@@ -0,0 +1,282 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// LoaderProvider provides the cache used by the Loader tests
+type LoaderProvider interface {
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// RunLoaderTests runs all Loader tests
+func RunLoaderTests(t *testing.T, provider LoaderProvider) {
+	t.Run("GetOrLoad_Miss", func(t *testing.T) {
+		testLoaderMiss(t, provider)
+	})
+	t.Run("GetOrLoad_Hit", func(t *testing.T) {
+		testLoaderHit(t, provider)
+	})
+	t.Run("GetOrLoad_ConcurrentMisses", func(t *testing.T) {
+		testLoaderConcurrentMisses(t, provider)
+	})
+	t.Run("GetOrLoad_Negative", func(t *testing.T) {
+		testLoaderNegative(t, provider)
+	})
+	t.Run("GetOrLoad_NegativeDisabled", func(t *testing.T) {
+		testLoaderNegativeDisabled(t, provider)
+	})
+	t.Run("GetOrLoad_Error", func(t *testing.T) {
+		testLoaderError(t, provider)
+	})
+	t.Run("GetOrLoad_Panic", func(t *testing.T) {
+		testLoaderPanic(t, provider)
+	})
+	t.Run("GetOrLoad_ContextCanceled", func(t *testing.T) {
+		testLoaderContextCanceled(t, provider)
+	})
+}
+
+// testLoaderMiss tests a missing key is loaded and cached with its TTL
+func testLoaderMiss(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, nil)
+
+	key := "test:loader:miss"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	val, err := loader.GetOrLoad(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		return []byte("loaded"), nil
+	}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("loaded"), val)
+
+	cached, err := cache.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("loaded"), cached)
+
+	ttl, err := cache.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, time.Duration(0))
+}
+
+// testLoaderHit tests a cached value is returned without loading
+func testLoaderHit(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, nil)
+
+	key := "test:loader:hit"
+	defer cache.Del(ctx, key)
+	require.NoError(t, cache.Set(ctx, key, "cached", 0).Err())
+
+	val, err := loader.GetOrLoad(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		t.Error("load called on a cached key")
+		return nil, nil
+	}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("cached"), val)
+}
+
+// testLoaderConcurrentMisses tests concurrent misses for a key run a single load
+func testLoaderConcurrentMisses(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, nil)
+
+	key := "test:loader:concurrent"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) ([]byte, error) {
+		calls.Add(1)
+		<-release
+		return []byte("loaded"), nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([][]byte, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = loader.GetOrLoad(ctx, key, time.Minute, load).Result()
+		}(i)
+	}
+
+	require.Eventually(t, func() bool {
+		return calls.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+	// Let the other callers reach the load in progress
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), calls.Load())
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, []byte("loaded"), results[i])
+	}
+}
+
+// testLoaderNegative tests values reported missing are cached for the negative TTL
+func testLoaderNegative(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, &caches.LoaderOptions{NegativeTTL: time.Minute})
+
+	key := "test:loader:negative"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	var calls int
+	load := func(ctx context.Context) ([]byte, error) {
+		calls++
+		return nil, caches.Nil
+	}
+
+	err := loader.GetOrLoad(ctx, key, time.Minute, load).Err()
+	require.ErrorIs(t, err, caches.Nil)
+
+	err = loader.GetOrLoad(ctx, key, time.Minute, load).Err()
+	require.ErrorIs(t, err, caches.Nil)
+	require.Equal(t, 1, calls)
+
+	ttl, err := cache.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, time.Duration(0))
+}
+
+// testLoaderNegativeDisabled tests values reported missing are not cached without a negative TTL
+func testLoaderNegativeDisabled(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, nil)
+
+	key := "test:loader:negative_disabled"
+	cache.Del(ctx, key)
+
+	var calls int
+	load := func(ctx context.Context) ([]byte, error) {
+		calls++
+		return nil, caches.Nil
+	}
+
+	require.ErrorIs(t, loader.GetOrLoad(ctx, key, time.Minute, load).Err(), caches.Nil)
+	require.ErrorIs(t, loader.GetOrLoad(ctx, key, time.Minute, load).Err(), caches.Nil)
+	require.Equal(t, 2, calls)
+
+	exists, err := cache.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
+
+// testLoaderError tests load errors are returned and not cached
+func testLoaderError(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, &caches.LoaderOptions{NegativeTTL: time.Minute})
+
+	key := "test:loader:error"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	errLoad := errors.New("load failed")
+	err := loader.GetOrLoad(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		return nil, errLoad
+	}).Err()
+	require.ErrorIs(t, err, errLoad)
+
+	val, err := loader.GetOrLoad(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+		return []byte("recovered"), nil
+	}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("recovered"), val)
+}
+
+// testLoaderPanic tests a panicking load is returned as an error to all the waiting callers
+func testLoaderPanic(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, nil)
+
+	key := "test:loader:panic"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	release := make(chan struct{})
+	load := func(ctx context.Context) ([]byte, error) {
+		<-release
+		panic("load exploded")
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = loader.GetOrLoad(ctx, key, time.Minute, load).Err()
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		require.ErrorIs(t, err, caches.ErrLoadPanic)
+		require.ErrorContains(t, err, "load exploded")
+	}
+
+	exists, err := cache.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exists)
+}
+
+// testLoaderContextCanceled tests a canceled caller stops waiting while the load completes
+func testLoaderContextCanceled(t *testing.T, provider LoaderProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	loader := caches.NewLoader(cache, nil)
+
+	key := "test:loader:canceled"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	release := make(chan struct{})
+	loaded := make(chan struct{})
+	load := func(ctx context.Context) ([]byte, error) {
+		defer close(loaded)
+		<-release
+		return []byte("loaded"), ctx.Err()
+	}
+
+	cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	err := loader.GetOrLoad(cancelCtx, key, time.Minute, load).Err()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	<-loaded
+
+	require.Eventually(t, func() bool {
+		val, err := cache.Get(ctx, key).Result()
+		return err == nil && string(val) == "loaded"
+	}, time.Second, 10*time.Millisecond)
+}
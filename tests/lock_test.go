@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches/lock"
+	"github.com/stretchr/testify/require"
+)
+
+// LockProvider provides the client used by the lock tests
+type LockProvider interface {
+	GetLockClient() lock.Client
+	GetContext() context.Context
+}
+
+// RunLockTests runs all lock package tests
+func RunLockTests(t *testing.T, provider LockProvider) {
+	t.Run("Acquire", func(t *testing.T) {
+		testLockAcquire(t, provider)
+	})
+	t.Run("Acquire_Held", func(t *testing.T) {
+		testLockAcquireHeld(t, provider)
+	})
+	t.Run("Acquire_Expired", func(t *testing.T) {
+		testLockAcquireExpired(t, provider)
+	})
+	t.Run("Acquire_Concurrent", func(t *testing.T) {
+		testLockAcquireConcurrent(t, provider)
+	})
+	t.Run("Token_Increases", func(t *testing.T) {
+		testLockTokenIncreases(t, provider)
+	})
+	t.Run("Refresh", func(t *testing.T) {
+		testLockRefresh(t, provider)
+	})
+	t.Run("Release_NotHeld", func(t *testing.T) {
+		testLockReleaseNotHeld(t, provider)
+	})
+}
+
+// newTestLocker returns a Locker with the prefix of the lock tests
+func newTestLocker(provider LockProvider) *lock.Locker {
+	return lock.New(provider.GetLockClient(), &lock.Options{Prefix: "test:lock:"})
+}
+
+// testLockAcquire tests a lock can be acquired again once released
+func testLockAcquire(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	l, err := locker.Acquire(ctx, "acquire", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, "{test:lock:acquire}", l.Key())
+	require.Positive(t, l.Token())
+	require.NoError(t, l.Release(ctx))
+
+	l, err = locker.Acquire(ctx, "acquire", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, l.Release(ctx))
+}
+
+// testLockAcquireHeld tests a held lock cannot be acquired
+func testLockAcquireHeld(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	l, err := locker.Acquire(ctx, "held", time.Minute)
+	require.NoError(t, err)
+	defer l.Release(ctx)
+
+	_, err = locker.Acquire(ctx, "held", time.Minute)
+	require.ErrorIs(t, err, lock.ErrNotAcquired)
+}
+
+// testLockAcquireExpired tests an expired holder cannot release or refresh the lock of the next holder
+func testLockAcquireExpired(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	first, err := locker.Acquire(ctx, "expired", 50*time.Millisecond)
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	second, err := locker.Acquire(ctx, "expired", time.Minute)
+	require.NoError(t, err)
+	defer second.Release(ctx)
+	require.Greater(t, second.Token(), first.Token())
+
+	require.ErrorIs(t, first.Refresh(ctx, time.Minute), lock.ErrNotHeld)
+	require.ErrorIs(t, first.Release(ctx), lock.ErrNotHeld)
+
+	// The lock of the second holder is still held
+	_, err = locker.Acquire(ctx, "expired", time.Minute)
+	require.ErrorIs(t, err, lock.ErrNotAcquired)
+}
+
+// testLockAcquireConcurrent tests a single concurrent caller acquires the lock
+func testLockAcquireConcurrent(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	var acquired atomic.Int32
+	var mu sync.Mutex
+	var held *lock.Lock
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, err := locker.Acquire(ctx, "concurrent", time.Minute)
+			if err == nil {
+				acquired.Add(1)
+				mu.Lock()
+				held = l
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), acquired.Load())
+	require.NoError(t, held.Release(ctx))
+}
+
+// testLockTokenIncreases tests the fencing token increases with each holder
+func testLockTokenIncreases(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	var last int64
+	for i := 0; i < 3; i++ {
+		l, err := locker.Acquire(ctx, "token", time.Minute)
+		require.NoError(t, err)
+		require.Greater(t, l.Token(), last)
+		last = l.Token()
+		require.NoError(t, l.Release(ctx))
+	}
+}
+
+// testLockRefresh tests a refreshed lock outlives its initial TTL
+func testLockRefresh(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	l, err := locker.Acquire(ctx, "refresh", 100*time.Millisecond)
+	require.NoError(t, err)
+	defer l.Release(ctx)
+
+	require.NoError(t, l.Refresh(ctx, time.Minute))
+	time.Sleep(200 * time.Millisecond)
+
+	_, err = locker.Acquire(ctx, "refresh", time.Minute)
+	require.ErrorIs(t, err, lock.ErrNotAcquired)
+	require.NoError(t, l.Refresh(ctx, time.Minute))
+}
+
+// testLockReleaseNotHeld tests a released lock cannot be released again
+func testLockReleaseNotHeld(t *testing.T, provider LockProvider) {
+	locker := newTestLocker(provider)
+	ctx := provider.GetContext()
+
+	l, err := locker.Acquire(ctx, "release", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, l.Release(ctx))
+	require.ErrorIs(t, l.Release(ctx), lock.ErrNotHeld)
+}
@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/lock"
+	"github.com/rockcookies/go-caches/providers/memory"
+	"github.com/rockcookies/go-caches/ratelimit"
+	"github.com/stretchr/testify/suite"
+)
+
+// MemoryTestSuite is the base test suite for Memory provider
+type MemoryTestSuite struct {
+	suite.Suite
+	provider *memory.Provider
+	ctx      context.Context
+}
+
+// SetupSuite runs once before all tests
+func (s *MemoryTestSuite) SetupSuite() {
+	// Create cache instance with key prefix to avoid conflicts
+	s.provider = memory.NewWithOptions(&memory.Options{
+		Prefix: "test:memory:",
+	})
+
+	s.ctx = context.Background()
+}
+
+// GetCache implements CacheProvider interface
+func (s *MemoryTestSuite) GetCache() caches.Cache {
+	return s.provider
+}
+
+// GetStringCommand implements StringCommandProvider interface
+func (s *MemoryTestSuite) GetStringCommand() caches.StringCommand {
+	return s.provider
+}
+
+// GetKeyCommand implements KeyCommandProvider interface
+func (s *MemoryTestSuite) GetKeyCommand() caches.KeyCommand {
+	return s.provider
+}
+
+// GetHashCommand implements HashCommandProvider interface
+func (s *MemoryTestSuite) GetHashCommand() caches.HashCommand {
+	return s.provider
+}
+
+// GetListCommand implements ListCommandProvider interface
+func (s *MemoryTestSuite) GetListCommand() caches.ListCommand {
+	return s.provider
+}
+
+// GetSetCommand implements SetCommandProvider interface
+func (s *MemoryTestSuite) GetSetCommand() caches.SetCommand {
+	return s.provider
+}
+
+// GetSortedSetCommand implements SortedSetCommandProvider interface
+func (s *MemoryTestSuite) GetSortedSetCommand() caches.SortedSetCommand {
+	return s.provider
+}
+
+// GetPipelineCommand implements PipelineCommandProvider interface
+func (s *MemoryTestSuite) GetPipelineCommand() caches.PipelineCommand {
+	return s.provider
+}
+
+// GetTxCommand implements TxCommandProvider interface
+func (s *MemoryTestSuite) GetTxCommand() caches.TxCommand {
+	return s.provider
+}
+
+// GetPubSub implements PubSubProvider interface
+func (s *MemoryTestSuite) GetPubSub() caches.PubSub {
+	return s.provider
+}
+
+// GetCompareCommand implements CompareCommandProvider interface
+func (s *MemoryTestSuite) GetCompareCommand() caches.CompareCommand {
+	return s.provider
+}
+
+// GetLockClient implements LockProvider interface
+func (s *MemoryTestSuite) GetLockClient() lock.Client {
+	return s.provider
+}
+
+// GetRateLimitClient implements RateLimitProvider interface
+func (s *MemoryTestSuite) GetRateLimitClient() ratelimit.Client {
+	return s.provider
+}
+
+// GetScriptCommand implements ScriptProvider interface
+func (s *MemoryTestSuite) GetScriptCommand() caches.ScriptCommand {
+	return s.provider
+}
+
+// RegisterScript implements ScriptProvider interface
+func (s *MemoryTestSuite) RegisterScript(script string, fn caches.ScriptFunc) {
+	s.provider.RegisterScript(script, fn)
+}
+
+// GetBitmapCommand implements BitmapCommandProvider interface
+func (s *MemoryTestSuite) GetBitmapCommand() caches.BitmapCommand {
+	return s.provider
+}
+
+// GetHyperLogLogCommand implements HyperLogLogCommandProvider interface
+func (s *MemoryTestSuite) GetHyperLogLogCommand() caches.HyperLogLogCommand {
+	return s.provider
+}
+
+// GetGeoCommand implements GeoCommandProvider interface
+func (s *MemoryTestSuite) GetGeoCommand() caches.GeoCommand {
+	return s.provider
+}
+
+// GetContext implements StringCommandProvider interface
+func (s *MemoryTestSuite) GetContext() context.Context {
+	return s.ctx
+}
+
+// TestCache runs all Cache tests
+func (s *MemoryTestSuite) TestCache() {
+	RunCacheTests(s.T(), s)
+}
+
+// TestStringCommand runs all StringCommand tests
+func (s *MemoryTestSuite) TestStringCommand() {
+	RunStringCommandTests(s.T(), s)
+}
+
+// TestKeyCommand runs all KeyCommand tests
+func (s *MemoryTestSuite) TestKeyCommand() {
+	RunKeyCommandTests(s.T(), s)
+}
+
+// TestHashCommand runs all HashCommand tests
+func (s *MemoryTestSuite) TestHashCommand() {
+	RunHashCommandTests(s.T(), s)
+}
+
+// TestListCommand runs all ListCommand tests
+func (s *MemoryTestSuite) TestListCommand() {
+	RunListCommandTests(s.T(), s)
+}
+
+// TestSetCommand runs all SetCommand tests
+func (s *MemoryTestSuite) TestSetCommand() {
+	RunSetCommandTests(s.T(), s)
+}
+
+// TestSortedSetCommand runs all SortedSetCommand tests
+func (s *MemoryTestSuite) TestSortedSetCommand() {
+	RunSortedSetCommandTests(s.T(), s)
+}
+
+// TestPipelineCommand runs all PipelineCommand tests
+func (s *MemoryTestSuite) TestPipelineCommand() {
+	RunPipelineCommandTests(s.T(), s)
+}
+
+// TestTxCommand runs all TxCommand tests
+func (s *MemoryTestSuite) TestTxCommand() {
+	RunTxCommandTests(s.T(), s)
+}
+
+// TestPubSub runs all PubSub tests
+func (s *MemoryTestSuite) TestPubSub() {
+	RunPubSubTests(s.T(), s)
+}
+
+// TestTyped runs all typed package tests
+func (s *MemoryTestSuite) TestTyped() {
+	RunTypedTests(s.T(), s)
+}
+
+// TestLoader runs all Loader tests
+func (s *MemoryTestSuite) TestLoader() {
+	RunLoaderTests(s.T(), s)
+}
+
+// TestFetcher runs all Fetcher tests
+func (s *MemoryTestSuite) TestFetcher() {
+	RunFetcherTests(s.T(), s)
+}
+
+// TestCompareCommand runs all CompareCommand tests
+func (s *MemoryTestSuite) TestCompareCommand() {
+	RunCompareCommandTests(s.T(), s)
+}
+
+// TestLock runs all lock package tests
+func (s *MemoryTestSuite) TestLock() {
+	RunLockTests(s.T(), s)
+}
+
+// TestRateLimit runs all ratelimit package tests
+func (s *MemoryTestSuite) TestRateLimit() {
+	RunRateLimitTests(s.T(), s)
+}
+
+// TestScript runs all ScriptCommand tests
+func (s *MemoryTestSuite) TestScript() {
+	RunScriptTests(s.T(), s)
+}
+
+// TestBitmapCommand runs all BitmapCommand tests
+func (s *MemoryTestSuite) TestBitmapCommand() {
+	RunBitmapCommandTests(s.T(), s)
+}
+
+// TestHyperLogLogCommand runs all HyperLogLogCommand tests
+func (s *MemoryTestSuite) TestHyperLogLogCommand() {
+	RunHyperLogLogCommandTests(s.T(), s)
+}
+
+// TestGeoCommand runs all GeoCommand tests
+func (s *MemoryTestSuite) TestGeoCommand() {
+	RunGeoCommandTests(s.T(), s)
+}
+
+// TestMemory runs all Memory provider tests
+func TestMemory(t *testing.T) {
+	suite.Run(t, new(MemoryTestSuite))
+}
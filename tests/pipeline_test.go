@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// PipelineCommandProvider defines the interface for testing PipelineCommand implementations
+type PipelineCommandProvider interface {
+	GetPipelineCommand() caches.PipelineCommand
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// RunPipelineCommandTests runs all PipelineCommand tests
+func RunPipelineCommandTests(t *testing.T, provider PipelineCommandProvider) {
+	t.Run("Pipeline_DeferredResults", func(t *testing.T) {
+		testPipelineDeferredResults(t, provider)
+	})
+	t.Run("Pipeline_Nil", func(t *testing.T) {
+		testPipelineNil(t, provider)
+	})
+	t.Run("Pipeline_MixedCommands", func(t *testing.T) {
+		testPipelineMixedCommands(t, provider)
+	})
+	t.Run("Pipeline_Discard", func(t *testing.T) {
+		testPipelineDiscard(t, provider)
+	})
+	t.Run("Pipelined", func(t *testing.T) {
+		testPipelined(t, provider)
+	})
+	t.Run("Pipelined_Error", func(t *testing.T) {
+		testPipelinedError(t, provider)
+	})
+}
+
+// testPipelineDeferredResults tests that results resolve after Exec
+func testPipelineDeferredResults(t *testing.T, provider PipelineCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:pipeline:deferred"
+	cache.Del(ctx, key)
+
+	pipe := provider.GetPipelineCommand().Pipeline()
+	set := pipe.Set(ctx, key, "value", 0)
+	get := pipe.Get(ctx, key)
+	incr := pipe.Incr(ctx, key+":counter")
+	require.Equal(t, 3, pipe.Len())
+	require.Empty(t, get.Val())
+
+	require.NoError(t, pipe.Exec(ctx))
+	require.Equal(t, 0, pipe.Len())
+
+	require.NoError(t, set.Err())
+	require.Equal(t, "OK", set.Val())
+	require.NoError(t, get.Err())
+	require.Equal(t, []byte("value"), get.Val())
+	require.NoError(t, incr.Err())
+	require.Equal(t, int64(1), incr.Val())
+
+	cache.Del(ctx, key+":counter")
+}
+
+// testPipelineNil tests that caches.Nil is reported per command and by Exec
+func testPipelineNil(t *testing.T, provider PipelineCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:pipeline:nil"
+	cache.Del(ctx, key, key+":missing")
+
+	pipe := provider.GetPipelineCommand().Pipeline()
+	set := pipe.Set(ctx, key, "value", 0)
+	missing := pipe.Get(ctx, key+":missing")
+	get := pipe.Get(ctx, key)
+
+	err := pipe.Exec(ctx)
+	require.ErrorIs(t, err, caches.Nil)
+
+	require.NoError(t, set.Err())
+	require.ErrorIs(t, missing.Err(), caches.Nil)
+	require.NoError(t, get.Err())
+	require.Equal(t, []byte("value"), get.Val())
+}
+
+// testPipelineMixedCommands tests queueing commands from several command groups
+func testPipelineMixedCommands(t *testing.T, provider PipelineCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	hashKey := "test:pipeline:hash"
+	zsetKey := "test:pipeline:zset"
+	listKey := "test:pipeline:list"
+	cache.Del(ctx, hashKey, zsetKey, listKey)
+
+	pipe := provider.GetPipelineCommand().Pipeline()
+	hset := pipe.HSet(ctx, hashKey, map[string]any{"field1": "value1", "field2": "value2"})
+	zadd := pipe.ZAdd(ctx, zsetKey,
+		caches.ZMember{Member: []byte("one"), Score: 1},
+		caches.ZMember{Member: []byte("two"), Score: 2},
+	)
+	rpush := pipe.RPush(ctx, listKey, "a", "b", "c")
+	hgetall := pipe.HGetAll(ctx, hashKey)
+	zrange := pipe.ZRange(ctx, zsetKey, 0, -1)
+	exists := pipe.Exists(ctx, hashKey, zsetKey, listKey)
+
+	require.NoError(t, pipe.Exec(ctx))
+
+	require.Equal(t, int64(2), hset.Val())
+	require.Equal(t, int64(2), zadd.Val())
+	require.Equal(t, int64(3), rpush.Val())
+	require.Equal(t, map[string][]byte{"field1": []byte("value1"), "field2": []byte("value2")}, hgetall.Val())
+	require.Equal(t, [][]byte{[]byte("one"), []byte("two")}, zrange.Val())
+	require.Equal(t, int64(3), exists.Val())
+
+	length := cache.LLen(ctx, listKey)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(3), length.Val())
+}
+
+// testPipelineDiscard tests that discarded commands are not executed
+func testPipelineDiscard(t *testing.T, provider PipelineCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:pipeline:discard"
+	cache.Del(ctx, key)
+
+	pipe := provider.GetPipelineCommand().Pipeline()
+	pipe.Set(ctx, key, "value", 0)
+	require.Equal(t, 1, pipe.Len())
+
+	pipe.Discard()
+	require.Equal(t, 0, pipe.Len())
+	require.NoError(t, pipe.Exec(ctx))
+
+	exists := cache.Exists(ctx, key)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(0), exists.Val())
+}
+
+// testPipelined tests that Pipelined executes the queued commands
+func testPipelined(t *testing.T, provider PipelineCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:pipeline:pipelined"
+	cache.Del(ctx, key)
+
+	var sadd caches.Result[int64]
+	err := provider.GetPipelineCommand().Pipelined(ctx, func(pipe caches.Pipeliner) error {
+		sadd = pipe.SAdd(ctx, key, "a", "b")
+		pipe.SAdd(ctx, key, "c")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), sadd.Val())
+
+	card := cache.SCard(ctx, key)
+	require.NoError(t, card.Err())
+	require.Equal(t, int64(3), card.Val())
+}
+
+// testPipelinedError tests that nothing is executed when fn fails
+func testPipelinedError(t *testing.T, provider PipelineCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:pipeline:error"
+	cache.Del(ctx, key)
+
+	errAbort := errors.New("abort")
+	err := provider.GetPipelineCommand().Pipelined(ctx, func(pipe caches.Pipeliner) error {
+		pipe.Set(ctx, key, "value", 0)
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	exists := cache.Exists(ctx, key)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(0), exists.Val())
+}
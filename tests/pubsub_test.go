@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// PubSubProvider defines the interface for testing PubSub implementations
+type PubSubProvider interface {
+	GetPubSub() caches.PubSub
+	GetContext() context.Context
+}
+
+// RunPubSubTests runs all PubSub tests
+func RunPubSubTests(t *testing.T, provider PubSubProvider) {
+	t.Run("Subscribe", func(t *testing.T) {
+		testSubscribe(t, provider)
+	})
+	t.Run("PSubscribe", func(t *testing.T) {
+		testPSubscribe(t, provider)
+	})
+	t.Run("Subscription_Subscribe", func(t *testing.T) {
+		testSubscriptionSubscribe(t, provider)
+	})
+	t.Run("Unsubscribe", func(t *testing.T) {
+		testUnsubscribe(t, provider)
+	})
+	t.Run("Subscription_Close", func(t *testing.T) {
+		testSubscriptionClose(t, provider)
+	})
+	t.Run("Publish_MultipleSubscribers", func(t *testing.T) {
+		testPublishMultipleSubscribers(t, provider)
+	})
+	t.Run("Publish_SlowSubscriber", func(t *testing.T) {
+		testPublishSlowSubscriber(t, provider)
+	})
+}
+
+// receiveMessage waits for the next message of sub
+func receiveMessage(t *testing.T, sub caches.Subscription) *caches.Message {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.Channel():
+		require.True(t, ok, "subscription channel closed")
+		return msg
+	case <-time.After(time.Second):
+		require.FailNow(t, "no message received")
+		return nil
+	}
+}
+
+// testSubscribe tests receiving a message published to a channel
+func testSubscribe(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	channel := "test:pubsub:subscribe"
+	sub := pubsub.Subscribe(ctx, channel)
+	defer sub.Close()
+
+	count := pubsub.Publish(ctx, channel, "hello")
+	require.NoError(t, count.Err())
+	require.Equal(t, int64(1), count.Val())
+
+	msg := receiveMessage(t, sub)
+	require.Equal(t, channel, msg.Channel)
+	require.Empty(t, msg.Pattern)
+	require.Equal(t, []byte("hello"), msg.Payload)
+}
+
+// testPSubscribe tests receiving messages published to channels matching a pattern
+func testPSubscribe(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	pattern := "test:pubsub:psubscribe:*"
+	sub := pubsub.PSubscribe(ctx, pattern)
+	defer sub.Close()
+
+	count := pubsub.Publish(ctx, "test:pubsub:psubscribe:news", 42)
+	require.NoError(t, count.Err())
+	require.Equal(t, int64(1), count.Val())
+
+	count = pubsub.Publish(ctx, "test:pubsub:other", "ignored")
+	require.NoError(t, count.Err())
+	require.Equal(t, int64(0), count.Val())
+
+	msg := receiveMessage(t, sub)
+	require.Equal(t, "test:pubsub:psubscribe:news", msg.Channel)
+	require.Equal(t, pattern, msg.Pattern)
+	require.Equal(t, []byte("42"), msg.Payload)
+}
+
+// testSubscriptionSubscribe tests adding channels to an existing subscription
+func testSubscriptionSubscribe(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	channel1 := "test:pubsub:add1"
+	channel2 := "test:pubsub:add2"
+	sub := pubsub.Subscribe(ctx, channel1)
+	defer sub.Close()
+
+	require.NoError(t, sub.Subscribe(ctx, channel2))
+
+	// Wait until the first channel delivers, so that the second subscription is active
+	require.NoError(t, pubsub.Publish(ctx, channel1, "first").Err())
+	msg := receiveMessage(t, sub)
+	require.Equal(t, channel1, msg.Channel)
+
+	require.NoError(t, pubsub.Publish(ctx, channel2, "second").Err())
+	msg = receiveMessage(t, sub)
+	require.Equal(t, channel2, msg.Channel)
+	require.Equal(t, []byte("second"), msg.Payload)
+}
+
+// testUnsubscribe tests that unsubscribed channels no longer receive messages
+func testUnsubscribe(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	channel := "test:pubsub:unsubscribe"
+	sub := pubsub.Subscribe(ctx, channel)
+	defer sub.Close()
+
+	require.NoError(t, sub.Unsubscribe(ctx, channel))
+
+	require.Eventually(t, func() bool {
+		count := pubsub.Publish(ctx, channel, "hello")
+		return count.Err() == nil && count.Val() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// testSubscriptionClose tests that closing a subscription closes its channel
+func testSubscriptionClose(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	sub := pubsub.Subscribe(ctx, "test:pubsub:close")
+	require.NoError(t, sub.Close())
+
+	select {
+	case _, ok := <-sub.Channel():
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		require.FailNow(t, "subscription channel not closed")
+	}
+}
+
+// testPublishMultipleSubscribers tests that every subscriber receives the message
+func testPublishMultipleSubscribers(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	channel := "test:pubsub:multiple"
+	sub1 := pubsub.Subscribe(ctx, channel)
+	defer sub1.Close()
+	sub2 := pubsub.Subscribe(ctx, channel)
+	defer sub2.Close()
+
+	count := pubsub.Publish(ctx, channel, []byte("hello"))
+	require.NoError(t, count.Err())
+	require.Equal(t, int64(2), count.Val())
+
+	require.Equal(t, []byte("hello"), receiveMessage(t, sub1).Payload)
+	require.Equal(t, []byte("hello"), receiveMessage(t, sub2).Payload)
+}
+
+// testPublishSlowSubscriber tests a subscriber that stopped reading does not block Publish past its context
+func testPublishSlowSubscriber(t *testing.T, provider PubSubProvider) {
+	pubsub := provider.GetPubSub()
+	ctx := provider.GetContext()
+
+	channel := "test:pubsub:slow"
+	sub := pubsub.Subscribe(ctx, channel)
+	defer sub.Close()
+
+	// Fill the buffer of the subscription, without reading it
+	for i := 0; i < 100; i++ {
+		require.NoError(t, pubsub.Publish(ctx, channel, "filler").Err())
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pubsub.Publish(timeoutCtx, channel, "blocked")
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish blocked past its context")
+	}
+}
@@ -0,0 +1,341 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+// RateLimitProvider provides the clients used by the ratelimit tests
+type RateLimitProvider interface {
+	GetRateLimitClient() ratelimit.Client
+	GetKeyCommand() caches.KeyCommand
+	GetContext() context.Context
+}
+
+// RunRateLimitTests runs all ratelimit package tests
+func RunRateLimitTests(t *testing.T, provider RateLimitProvider) {
+	t.Run("FixedWindow", func(t *testing.T) {
+		testRateLimitFixedWindow(t, provider)
+	})
+	t.Run("SlidingLog", func(t *testing.T) {
+		testRateLimitSlidingLog(t, provider)
+	})
+	t.Run("SlidingLog_Slides", func(t *testing.T) {
+		testRateLimitSlidingLogSlides(t, provider)
+	})
+	t.Run("SlidingLog_Expires", func(t *testing.T) {
+		testRateLimitSlidingLogExpires(t, provider)
+	})
+	t.Run("GCRA", func(t *testing.T) {
+		testRateLimitGCRA(t, provider)
+	})
+	t.Run("GCRA_Refills", func(t *testing.T) {
+		testRateLimitGCRARefills(t, provider)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		testRateLimitExpired(t, provider)
+	})
+	t.Run("AllowN_ExceedsLimit", func(t *testing.T) {
+		testRateLimitAllowNExceedsLimit(t, provider)
+	})
+	t.Run("AllowN_Zero", func(t *testing.T) {
+		testRateLimitAllowNZero(t, provider)
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		testRateLimitConcurrent(t, provider)
+	})
+	t.Run("InvalidLimit", func(t *testing.T) {
+		testRateLimitInvalidLimit(t, provider)
+	})
+}
+
+var rateLimitAlgorithms = []struct {
+	name      string
+	algorithm ratelimit.Algorithm
+}{
+	{"FixedWindow", ratelimit.FixedWindow},
+	{"SlidingLog", ratelimit.SlidingLog},
+	{"GCRA", ratelimit.GCRA},
+}
+
+// newTestLimiter returns a Limiter with the prefix of the ratelimit tests, deleting key once the test is over
+func newTestLimiter(t *testing.T, provider RateLimitProvider, algorithm ratelimit.Algorithm, key string) *ratelimit.Limiter {
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	keyCmd.Del(ctx, "test:ratelimit:"+key)
+	t.Cleanup(func() {
+		keyCmd.Del(ctx, "test:ratelimit:"+key)
+	})
+	return ratelimit.New(provider.GetRateLimitClient(), &ratelimit.Options{
+		Prefix:    "test:ratelimit:",
+		Algorithm: algorithm,
+	})
+}
+
+// testRateLimitFixedWindow tests FixedWindow allows Rate requests then denies the next ones
+func testRateLimitFixedWindow(t *testing.T, provider RateLimitProvider) {
+	limiter := newTestLimiter(t, provider, ratelimit.FixedWindow, "fixed")
+	ctx := provider.GetContext()
+	limit := ratelimit.Limit{Rate: 3, Period: time.Minute}
+
+	for i := int64(2); i >= 0; i-- {
+		res, err := limiter.Allow(ctx, "fixed", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, i, res.Remaining)
+		require.Zero(t, res.RetryAfter)
+	}
+
+	res, err := limiter.Allow(ctx, "fixed", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, int64(0), res.Remaining)
+	require.Greater(t, res.RetryAfter, 59*time.Second)
+	require.LessOrEqual(t, res.RetryAfter, time.Minute)
+}
+
+// testRateLimitSlidingLog tests SlidingLog allows Rate requests then denies the next ones
+func testRateLimitSlidingLog(t *testing.T, provider RateLimitProvider) {
+	limiter := newTestLimiter(t, provider, ratelimit.SlidingLog, "sliding")
+	ctx := provider.GetContext()
+	limit := ratelimit.Limit{Rate: 3, Period: time.Minute}
+
+	for i := int64(2); i >= 0; i-- {
+		res, err := limiter.Allow(ctx, "sliding", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, i, res.Remaining)
+	}
+
+	res, err := limiter.Allow(ctx, "sliding", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, int64(0), res.Remaining)
+	require.Greater(t, res.RetryAfter, 59*time.Second)
+	require.LessOrEqual(t, res.RetryAfter, time.Minute)
+}
+
+// testRateLimitSlidingLogSlides tests SlidingLog allows a request once the oldest one left the window
+func testRateLimitSlidingLogSlides(t *testing.T, provider RateLimitProvider) {
+	limiter := newTestLimiter(t, provider, ratelimit.SlidingLog, "sliding_slides")
+	ctx := provider.GetContext()
+	limit := ratelimit.Limit{Rate: 2, Period: 300 * time.Millisecond}
+
+	res, err := limiter.Allow(ctx, "sliding_slides", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	time.Sleep(150 * time.Millisecond)
+
+	res, err = limiter.Allow(ctx, "sliding_slides", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	// The request fits once the first request left the window, before the second one does
+	res, err = limiter.Allow(ctx, "sliding_slides", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Greater(t, res.RetryAfter, time.Duration(0))
+	require.LessOrEqual(t, res.RetryAfter, 150*time.Millisecond)
+
+	time.Sleep(res.RetryAfter + 20*time.Millisecond)
+
+	res, err = limiter.Allow(ctx, "sliding_slides", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, int64(0), res.Remaining)
+}
+
+// testRateLimitSlidingLogExpires tests the SlidingLog key expires with the window of its last request
+func testRateLimitSlidingLogExpires(t *testing.T, provider RateLimitProvider) {
+	limiter := newTestLimiter(t, provider, ratelimit.SlidingLog, "sliding_expires")
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+	limit := ratelimit.Limit{Rate: 3, Period: time.Minute}
+
+	res, err := limiter.Allow(ctx, "sliding_expires", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	ttl, err := keyCmd.PTTL(ctx, "test:ratelimit:sliding_expires").Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, 59*time.Second)
+	require.LessOrEqual(t, ttl, time.Minute)
+}
+
+// testRateLimitGCRA tests GCRA allows a burst of requests then denies the next ones
+func testRateLimitGCRA(t *testing.T, provider RateLimitProvider) {
+	limiter := newTestLimiter(t, provider, ratelimit.GCRA, "gcra")
+	ctx := provider.GetContext()
+	limit := ratelimit.Limit{Rate: 60, Period: time.Minute, Burst: 3}
+
+	for i := int64(2); i >= 0; i-- {
+		res, err := limiter.Allow(ctx, "gcra", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, i, res.Remaining)
+	}
+
+	// One request is allowed per second once the burst is spent
+	res, err := limiter.Allow(ctx, "gcra", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, int64(0), res.Remaining)
+	require.Greater(t, res.RetryAfter, 900*time.Millisecond)
+	require.LessOrEqual(t, res.RetryAfter, time.Second)
+}
+
+// testRateLimitGCRARefills tests GCRA allows a request again after the emission interval
+func testRateLimitGCRARefills(t *testing.T, provider RateLimitProvider) {
+	limiter := newTestLimiter(t, provider, ratelimit.GCRA, "gcra_refills")
+	ctx := provider.GetContext()
+	limit := ratelimit.Limit{Rate: 10, Period: time.Second, Burst: 1}
+
+	res, err := limiter.Allow(ctx, "gcra_refills", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, int64(0), res.Remaining)
+
+	res, err = limiter.Allow(ctx, "gcra_refills", limit)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Greater(t, res.RetryAfter, time.Duration(0))
+	require.LessOrEqual(t, res.RetryAfter, 100*time.Millisecond)
+
+	time.Sleep(res.RetryAfter + 20*time.Millisecond)
+
+	res, err = limiter.Allow(ctx, "gcra_refills", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+}
+
+// testRateLimitExpired tests requests are allowed again once the state of the limit expired
+func testRateLimitExpired(t *testing.T, provider RateLimitProvider) {
+	for _, tc := range rateLimitAlgorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			key := "expired:" + tc.name
+			limiter := newTestLimiter(t, provider, tc.algorithm, key)
+			ctx := provider.GetContext()
+			limit := ratelimit.Limit{Rate: 2, Period: 100 * time.Millisecond}
+
+			res, err := limiter.AllowN(ctx, key, limit, 2)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+
+			res, err = limiter.Allow(ctx, key, limit)
+			require.NoError(t, err)
+			require.False(t, res.Allowed)
+
+			time.Sleep(150 * time.Millisecond)
+
+			res, err = limiter.Allow(ctx, key, limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, int64(1), res.Remaining)
+
+			res, err = limiter.Allow(ctx, key, limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, int64(0), res.Remaining)
+		})
+	}
+}
+
+// testRateLimitAllowNExceedsLimit tests requests exceeding the limit are never allowed, nor counted
+func testRateLimitAllowNExceedsLimit(t *testing.T, provider RateLimitProvider) {
+	for _, tc := range rateLimitAlgorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			key := "exceeds:" + tc.name
+			limiter := newTestLimiter(t, provider, tc.algorithm, key)
+			ctx := provider.GetContext()
+			limit := ratelimit.Limit{Rate: 3, Period: time.Minute}
+
+			res, err := limiter.AllowN(ctx, key, limit, 4)
+			require.NoError(t, err)
+			require.False(t, res.Allowed)
+			require.Equal(t, int64(3), res.Remaining)
+			require.Equal(t, time.Duration(-1), res.RetryAfter)
+
+			res, err = limiter.AllowN(ctx, key, limit, 3)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, int64(0), res.Remaining)
+		})
+	}
+}
+
+// testRateLimitAllowNZero tests n = 0 returns the state of the limit without counting a request
+func testRateLimitAllowNZero(t *testing.T, provider RateLimitProvider) {
+	for _, tc := range rateLimitAlgorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			key := "zero:" + tc.name
+			limiter := newTestLimiter(t, provider, tc.algorithm, key)
+			ctx := provider.GetContext()
+			limit := ratelimit.Limit{Rate: 3, Period: time.Minute}
+
+			res, err := limiter.AllowN(ctx, key, limit, 0)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, int64(3), res.Remaining)
+
+			_, err = limiter.Allow(ctx, key, limit)
+			require.NoError(t, err)
+
+			res, err = limiter.AllowN(ctx, key, limit, 0)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, int64(2), res.Remaining)
+		})
+	}
+}
+
+// testRateLimitConcurrent tests concurrent requests share the same limit
+func testRateLimitConcurrent(t *testing.T, provider RateLimitProvider) {
+	for _, tc := range rateLimitAlgorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			key := "concurrent:" + tc.name
+			limiter := newTestLimiter(t, provider, tc.algorithm, key)
+			ctx := provider.GetContext()
+			limit := ratelimit.Limit{Rate: 5, Period: time.Minute}
+
+			var allowed atomic.Int32
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					res, err := limiter.Allow(ctx, key, limit)
+					if err == nil && res.Allowed {
+						allowed.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			require.Equal(t, int32(5), allowed.Load())
+		})
+	}
+}
+
+// testRateLimitInvalidLimit tests invalid limits are rejected
+func testRateLimitInvalidLimit(t *testing.T, provider RateLimitProvider) {
+	limiter := ratelimit.New(provider.GetRateLimitClient(), nil)
+	ctx := provider.GetContext()
+
+	_, err := limiter.Allow(ctx, "invalid", ratelimit.Limit{Period: time.Second})
+	require.ErrorIs(t, err, ratelimit.ErrInvalidLimit)
+
+	_, err = limiter.Allow(ctx, "invalid", ratelimit.Limit{Rate: 1})
+	require.ErrorIs(t, err, ratelimit.ErrInvalidLimit)
+
+	_, err = limiter.AllowN(ctx, "invalid", ratelimit.PerSecond(1), -1)
+	require.ErrorIs(t, err, ratelimit.ErrInvalidLimit)
+}
@@ -6,7 +6,9 @@ import (
 
 	rds "github.com/redis/go-redis/v9"
 	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/lock"
 	"github.com/rockcookies/go-caches/providers/redis"
+	"github.com/rockcookies/go-caches/ratelimit"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -29,6 +31,9 @@ func (s *RedisTestSuite) SetupSuite() {
 	s.client = rds.NewClient(&rds.Options{
 		Addr: "localhost:6379",
 		DB:   0,
+
+		// Let blocking commands return when ctx is done
+		ContextTimeoutEnabled: true,
 	})
 
 	// Ping to verify connection
@@ -60,6 +65,11 @@ func (s *RedisTestSuite) TearDownSuite() {
 	}
 }
 
+// GetCache implements CacheProvider interface
+func (s *RedisTestSuite) GetCache() caches.Cache {
+	return s.provder
+}
+
 // GetStringCommand implements StringCommandProvider interface
 func (s *RedisTestSuite) GetStringCommand() caches.StringCommand {
 	return s.provder
@@ -90,11 +100,75 @@ func (s *RedisTestSuite) GetSortedSetCommand() caches.SortedSetCommand {
 	return s.provder
 }
 
+// GetPipelineCommand implements PipelineCommandProvider interface
+func (s *RedisTestSuite) GetPipelineCommand() caches.PipelineCommand {
+	return s.provder
+}
+
+// GetTxCommand implements TxCommandProvider interface
+func (s *RedisTestSuite) GetTxCommand() caches.TxCommand {
+	return s.provder
+}
+
+// GetPubSub implements PubSubProvider interface
+func (s *RedisTestSuite) GetPubSub() caches.PubSub {
+	return s.provder
+}
+
+// GetStreamCommand implements StreamCommandProvider interface
+func (s *RedisTestSuite) GetStreamCommand() caches.StreamCommand {
+	return s.provder
+}
+
+// GetCompareCommand implements CompareCommandProvider interface
+func (s *RedisTestSuite) GetCompareCommand() caches.CompareCommand {
+	return s.provder
+}
+
+// GetLockClient implements LockProvider interface
+func (s *RedisTestSuite) GetLockClient() lock.Client {
+	return s.provder
+}
+
+// GetRateLimitClient implements RateLimitProvider interface
+func (s *RedisTestSuite) GetRateLimitClient() ratelimit.Client {
+	return s.provder
+}
+
+// GetScriptCommand implements ScriptProvider interface
+func (s *RedisTestSuite) GetScriptCommand() caches.ScriptCommand {
+	return s.provder
+}
+
+// RegisterScript implements ScriptProvider interface
+// Redis runs the Lua source of the scripts
+func (s *RedisTestSuite) RegisterScript(script string, fn caches.ScriptFunc) {}
+
+// GetBitmapCommand implements BitmapCommandProvider interface
+func (s *RedisTestSuite) GetBitmapCommand() caches.BitmapCommand {
+	return s.provder
+}
+
+// GetHyperLogLogCommand implements HyperLogLogCommandProvider interface
+func (s *RedisTestSuite) GetHyperLogLogCommand() caches.HyperLogLogCommand {
+	return s.provder
+}
+
+// GetGeoCommand implements GeoCommandProvider interface
+func (s *RedisTestSuite) GetGeoCommand() caches.GeoCommand {
+	return s.provder
+}
+
 // GetContext implements StringCommandProvider interface
 func (s *RedisTestSuite) GetContext() context.Context {
 	return s.ctx
 }
 
+// TestCache runs all Cache tests
+func (s *RedisTestSuite) TestCache() {
+	RunCacheTests(s.T(), s)
+}
+
 // TestStringCommand runs all StringCommand tests
 func (s *RedisTestSuite) TestStringCommand() {
 	RunStringCommandTests(s.T(), s)
@@ -125,6 +199,76 @@ func (s *RedisTestSuite) TestSortedSetCommand() {
 	RunSortedSetCommandTests(s.T(), s)
 }
 
+// TestPipelineCommand runs all PipelineCommand tests
+func (s *RedisTestSuite) TestPipelineCommand() {
+	RunPipelineCommandTests(s.T(), s)
+}
+
+// TestTxCommand runs all TxCommand tests
+func (s *RedisTestSuite) TestTxCommand() {
+	RunTxCommandTests(s.T(), s)
+}
+
+// TestPubSub runs all PubSub tests
+func (s *RedisTestSuite) TestPubSub() {
+	RunPubSubTests(s.T(), s)
+}
+
+// TestStreamCommand runs all StreamCommand tests
+func (s *RedisTestSuite) TestStreamCommand() {
+	RunStreamCommandTests(s.T(), s)
+}
+
+// TestTyped runs all typed package tests
+func (s *RedisTestSuite) TestTyped() {
+	RunTypedTests(s.T(), s)
+}
+
+// TestLoader runs all Loader tests
+func (s *RedisTestSuite) TestLoader() {
+	RunLoaderTests(s.T(), s)
+}
+
+// TestFetcher runs all Fetcher tests
+func (s *RedisTestSuite) TestFetcher() {
+	RunFetcherTests(s.T(), s)
+}
+
+// TestCompareCommand runs all CompareCommand tests
+func (s *RedisTestSuite) TestCompareCommand() {
+	RunCompareCommandTests(s.T(), s)
+}
+
+// TestLock runs all lock package tests
+func (s *RedisTestSuite) TestLock() {
+	RunLockTests(s.T(), s)
+}
+
+// TestRateLimit runs all ratelimit package tests
+func (s *RedisTestSuite) TestRateLimit() {
+	RunRateLimitTests(s.T(), s)
+}
+
+// TestScript runs all ScriptCommand tests
+func (s *RedisTestSuite) TestScript() {
+	RunScriptTests(s.T(), s)
+}
+
+// TestBitmapCommand runs all BitmapCommand tests
+func (s *RedisTestSuite) TestBitmapCommand() {
+	RunBitmapCommandTests(s.T(), s)
+}
+
+// TestHyperLogLogCommand runs all HyperLogLogCommand tests
+func (s *RedisTestSuite) TestHyperLogLogCommand() {
+	RunHyperLogLogCommandTests(s.T(), s)
+}
+
+// TestGeoCommand runs all GeoCommand tests
+func (s *RedisTestSuite) TestGeoCommand() {
+	RunGeoCommandTests(s.T(), s)
+}
+
 // TestRedis runs all Redis provider tests
 func TestRedis(t *testing.T) {
 	suite.Run(t, new(RedisTestSuite))
@@ -2,11 +2,18 @@ package tests
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	rdk "github.com/nalgeon/redka"
 	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/lock"
 	"github.com/rockcookies/go-caches/providers/redka"
+	"github.com/rockcookies/go-caches/ratelimit"
 	"github.com/stretchr/testify/suite"
 
 	// Import SQLite driver
@@ -17,20 +24,29 @@ import (
 type RedkaTestSuite struct {
 	suite.Suite
 	db       *rdk.DB
+	rw       *sql.DB
 	provider *redka.Provider
 	ctx      context.Context
 }
 
 // SetupSuite runs once before all tests
 func (s *RedkaTestSuite) SetupSuite() {
-	// Create in-memory Redka database
-	db, err := rdk.Open(":memory:", nil)
+	// Create in-memory Redka database, opening the SQL handles
+	// so that the provider can create its stream tables
+	rw, err := sql.Open("sqlite3", "file:/redka.db?vfs=memdb&_txlock=immediate")
+	s.Require().NoError(err, "Failed to open SQL database")
+	ro, err := sql.Open("sqlite3", "file:/redka.db?vfs=memdb")
+	s.Require().NoError(err, "Failed to open SQL database")
+
+	db, err := rdk.OpenDB(rw, ro, nil)
 	s.Require().NoError(err, "Failed to open Redka database")
 	s.db = db
+	s.rw = rw
 
 	// Create cache instance with key prefix to avoid conflicts
 	s.provider = redka.NewWithOptions(db, &redka.Options{
 		Prefix: "test:redka:",
+		DB:     rw,
 	})
 
 	s.ctx = context.Background()
@@ -43,6 +59,11 @@ func (s *RedkaTestSuite) TearDownSuite() {
 	}
 }
 
+// GetCache implements CacheProvider interface
+func (s *RedkaTestSuite) GetCache() caches.Cache {
+	return s.provider
+}
+
 // GetStringCommand implements StringCommandProvider interface
 func (s *RedkaTestSuite) GetStringCommand() caches.StringCommand {
 	return s.provider
@@ -73,11 +94,76 @@ func (s *RedkaTestSuite) GetSortedSetCommand() caches.SortedSetCommand {
 	return s.provider
 }
 
+// GetPipelineCommand implements PipelineCommandProvider interface
+func (s *RedkaTestSuite) GetPipelineCommand() caches.PipelineCommand {
+	return s.provider
+}
+
+// GetTxCommand implements TxCommandProvider interface
+func (s *RedkaTestSuite) GetTxCommand() caches.TxCommand {
+	return s.provider
+}
+
+// GetPubSub implements PubSubProvider interface
+func (s *RedkaTestSuite) GetPubSub() caches.PubSub {
+	return s.provider
+}
+
+// GetStreamCommand implements StreamCommandProvider interface
+func (s *RedkaTestSuite) GetStreamCommand() caches.StreamCommand {
+	return s.provider
+}
+
+// GetCompareCommand implements CompareCommandProvider interface
+func (s *RedkaTestSuite) GetCompareCommand() caches.CompareCommand {
+	return s.provider
+}
+
+// GetLockClient implements LockProvider interface
+func (s *RedkaTestSuite) GetLockClient() lock.Client {
+	return s.provider
+}
+
+// GetRateLimitClient implements RateLimitProvider interface
+func (s *RedkaTestSuite) GetRateLimitClient() ratelimit.Client {
+	return s.provider
+}
+
+// GetScriptCommand implements ScriptProvider interface
+func (s *RedkaTestSuite) GetScriptCommand() caches.ScriptCommand {
+	return s.provider
+}
+
+// RegisterScript implements ScriptProvider interface
+func (s *RedkaTestSuite) RegisterScript(script string, fn caches.ScriptFunc) {
+	s.provider.RegisterScript(script, fn)
+}
+
+// GetBitmapCommand implements BitmapCommandProvider interface
+func (s *RedkaTestSuite) GetBitmapCommand() caches.BitmapCommand {
+	return s.provider
+}
+
+// GetHyperLogLogCommand implements HyperLogLogCommandProvider interface
+func (s *RedkaTestSuite) GetHyperLogLogCommand() caches.HyperLogLogCommand {
+	return s.provider
+}
+
+// GetGeoCommand implements GeoCommandProvider interface
+func (s *RedkaTestSuite) GetGeoCommand() caches.GeoCommand {
+	return s.provider
+}
+
 // GetContext implements StringCommandProvider interface
 func (s *RedkaTestSuite) GetContext() context.Context {
 	return s.ctx
 }
 
+// TestCache runs all Cache tests
+func (s *RedkaTestSuite) TestCache() {
+	RunCacheTests(s.T(), s)
+}
+
 // TestStringCommand runs all StringCommand tests
 func (s *RedkaTestSuite) TestStringCommand() {
 	RunStringCommandTests(s.T(), s)
@@ -108,6 +194,283 @@ func (s *RedkaTestSuite) TestSortedSetCommand() {
 	RunSortedSetCommandTests(s.T(), s)
 }
 
+// TestPipelineCommand runs all PipelineCommand tests
+func (s *RedkaTestSuite) TestPipelineCommand() {
+	RunPipelineCommandTests(s.T(), s)
+}
+
+// TestTxCommand runs all TxCommand tests
+func (s *RedkaTestSuite) TestTxCommand() {
+	RunTxCommandTests(s.T(), s)
+}
+
+// TestPubSub runs all PubSub tests
+func (s *RedkaTestSuite) TestPubSub() {
+	RunPubSubTests(s.T(), s)
+}
+
+// TestStreamCommand runs all StreamCommand tests
+func (s *RedkaTestSuite) TestStreamCommand() {
+	RunStreamCommandTests(s.T(), s)
+}
+
+// TestTyped runs all typed package tests
+func (s *RedkaTestSuite) TestTyped() {
+	RunTypedTests(s.T(), s)
+}
+
+// TestLoader runs all Loader tests
+func (s *RedkaTestSuite) TestLoader() {
+	RunLoaderTests(s.T(), s)
+}
+
+// TestFetcher runs all Fetcher tests
+func (s *RedkaTestSuite) TestFetcher() {
+	RunFetcherTests(s.T(), s)
+}
+
+// TestSweeper checks that the sweeper removes expired keys of its provider only
+func (s *RedkaTestSuite) TestSweeper() {
+	var mu sync.Mutex
+	var expired []string
+
+	provider := redka.NewWithOptions(s.db, &redka.Options{
+		Prefix:        "test:redka:sweep:",
+		DB:            s.rw,
+		SweepInterval: 10 * time.Millisecond,
+		OnExpire: func(keys []string) {
+			mu.Lock()
+			defer mu.Unlock()
+			expired = append(expired, keys...)
+		},
+	})
+	defer provider.Close()
+
+	s.Require().NoError(provider.Set(s.ctx, "short", "value", 50*time.Millisecond).Err())
+	s.Require().NoError(provider.Set(s.ctx, "long", "value", time.Hour).Err())
+	s.Require().NoError(provider.Set(s.ctx, "persistent", "value", 0).Err())
+	s.Require().NoError(s.provider.Set(s.ctx, "unswept", "value", 50*time.Millisecond).Err())
+	defer provider.Del(s.ctx, "long", "persistent")
+	s.discardConn()
+
+	s.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(expired) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	s.Equal([]string{"short"}, expired)
+	mu.Unlock()
+
+	// The expired key of the other provider is left to Redka
+	var count int
+	err := s.rw.QueryRow(`select count(*) from rkey where key = ?`, "test:redka:unswept").Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+
+	// The values of the removed keys are removed too
+	err = s.rw.QueryRow(`select count(*) from rstring where kid not in (select id from rkey)`).Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(0, count)
+
+	s.NoError(provider.Close())
+	s.NoError(provider.Close())
+}
+
+// TestCapabilitiesWithoutDB checks that streams and hash field expiration are not advertised without Options.DB
+func (s *RedkaTestSuite) TestCapabilitiesWithoutDB() {
+	s.True(s.provider.Capabilities().Has(caches.CapStreams | caches.CapHashFieldTTL))
+
+	provider := redka.NewWithOptions(s.db, &redka.Options{Prefix: "test:redka:nodb:"})
+	s.False(provider.Capabilities().Has(caches.CapStreams))
+	s.False(provider.Capabilities().Has(caches.CapHashFieldTTL))
+	s.Error(provider.XLen(s.ctx, "stream").Err())
+
+	key := "hash"
+	s.Require().NoError(provider.HSet(s.ctx, key, map[string]any{"a": "1"}).Err())
+	defer provider.Del(s.ctx, key)
+
+	s.Error(provider.HExpire(s.ctx, key, time.Hour, "a").Err())
+	s.Error(provider.HGetEx(s.ctx, key, time.Hour, "a").Err())
+	s.Error(provider.HSetEx(s.ctx, key, "", time.Hour, map[string]any{"a": "2"}).Err())
+
+	// Without expiration they still apply
+	vals, err := provider.HGetEx(s.ctx, key, caches.KeepTTL, "a").Result()
+	s.Require().NoError(err)
+	s.Equal([][]byte{[]byte("1")}, vals)
+	s.NoError(provider.HSetEx(s.ctx, key, "", 0, map[string]any{"a": "2"}).Err())
+}
+
+// TestFlushAllPrefix checks that FlushAll only deletes the keys under the provider prefix
+func (s *RedkaTestSuite) TestFlushAllPrefix() {
+	provider := redka.NewWithOptions(s.db, &redka.Options{Prefix: "test:redka:flush:"})
+
+	s.Require().NoError(provider.Set(s.ctx, "key1", "value", 0).Err())
+	s.Require().NoError(provider.Set(s.ctx, "key2", "value", 0).Err())
+	s.Require().NoError(s.provider.Set(s.ctx, "unflushed", "value", 0).Err())
+	defer s.provider.Del(s.ctx, "unflushed")
+
+	size, err := provider.DBSize(s.ctx).Result()
+	s.Require().NoError(err)
+	s.Equal(int64(2), size)
+
+	s.Require().NoError(provider.FlushAll(s.ctx).Err())
+
+	size, err = provider.DBSize(s.ctx).Result()
+	s.Require().NoError(err)
+	s.Equal(int64(0), size)
+
+	exists, err := s.provider.Exists(s.ctx, "unflushed").Result()
+	s.Require().NoError(err)
+	s.Equal(int64(1), exists)
+}
+
+// discardConn makes database/sql replace the read-write connection, which opens without foreign keys
+func (s *RedkaTestSuite) discardConn() {
+	// Canceling a write transaction discards its connection
+	ctx, cancel := context.WithCancel(s.ctx)
+	err := s.db.UpdateContext(ctx, func(tx *rdk.Tx) error {
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		return tx.Str().Set("test:redka:discard", "value")
+	})
+	s.Require().Error(err)
+}
+
+// TestForeignKeys checks that deleting a key deletes its values on a new connection
+func (s *RedkaTestSuite) TestForeignKeys() {
+	s.discardConn()
+
+	s.Require().NoError(s.provider.HSet(s.ctx, "fk:hash", map[string]any{"field": "value"}).Err())
+	s.Require().NoError(s.provider.Del(s.ctx, "fk:hash").Err())
+
+	var count int
+	err := s.rw.QueryRow(`select count(*) from rhash where kid not in (select id from rkey)`).Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(0, count)
+}
+
+// TestHashExpiresDeleted checks that the expiration times of hash fields are deleted with the hash
+func (s *RedkaTestSuite) TestHashExpiresDeleted() {
+	key := "hexpire:deleted"
+	s.Require().NoError(s.provider.HSet(s.ctx, key, map[string]any{"a": "1"}).Err())
+	s.Require().NoError(s.provider.HExpire(s.ctx, key, time.Hour, "a").Err())
+
+	var kid int
+	err := s.rw.QueryRow(`select id from rkey where key = ?`, "test:redka:"+key).Scan(&kid)
+	s.Require().NoError(err)
+
+	countExpires := func() (count int) {
+		err := s.rw.QueryRow(`select count(*) from rkey where key = ?`,
+			"__caches:hexpire:"+strconv.Itoa(kid)).Scan(&count)
+		s.Require().NoError(err)
+		return
+	}
+	s.Equal(1, countExpires())
+
+	// The key holding them is not listed without a prefix
+	keys, err := redka.New(s.db).Keys(s.ctx, "*").Result()
+	s.Require().NoError(err)
+	s.Contains(keys, "test:redka:"+key)
+	for _, k := range keys {
+		s.NotContains(k, "__caches:")
+	}
+
+	s.Require().NoError(s.provider.Del(s.ctx, key).Err())
+	s.Equal(0, countExpires())
+}
+
+// TestHashExpiresFlushed checks that flushing a prefix deletes the expiration times of its hashes,
+// which are neither counted nor flushed as keys of their own
+func (s *RedkaTestSuite) TestHashExpiresFlushed() {
+	provider := redka.NewWithOptions(s.db, &redka.Options{Prefix: "test:redka:hflush:", DB: s.rw})
+	for _, key := range []string{"h1", "h2"} {
+		s.Require().NoError(provider.HSet(s.ctx, key, map[string]any{"a": "1"}).Err())
+		s.Require().NoError(provider.HExpire(s.ctx, key, time.Hour, "a").Err())
+	}
+
+	countExpires := func() (count int) {
+		err := s.rw.QueryRow(`select count(*) from rkey where key in (
+			select '__caches:hexpire:' || id from rkey where key like 'test:redka:hflush:%'
+		)`).Scan(&count)
+		s.Require().NoError(err)
+		return
+	}
+	s.Equal(2, countExpires())
+
+	// An unprefixed provider does not count them either
+	unprefixed := redka.NewWithOptions(s.db, &redka.Options{DB: s.rw})
+	size, err := unprefixed.DBSize(s.ctx).Result()
+	s.Require().NoError(err)
+	sizePrefix, err := unprefixed.DBSizePrefix(s.ctx, "").Result()
+	s.Require().NoError(err)
+	s.Equal(size, sizePrefix)
+
+	s.Require().NoError(provider.FlushAll(s.ctx).Err())
+	s.Equal(0, countExpires())
+
+	var left int
+	err = s.rw.QueryRow(`select count(*) from rkey where key like '__caches:hexpire:%'
+		and cast(substr(key, 18) as integer) not in (select id from rkey)`).Scan(&left)
+	s.Require().NoError(err)
+	s.Equal(0, left)
+}
+
+// TestRegisterScriptRollback checks that a script returning an error rolls back its commands
+func (s *RedkaTestSuite) TestRegisterScriptRollback() {
+	script := caches.NewScript(`redis.call("SET", KEYS[1], "partial"); return redis.error_reply("failed")`)
+	s.provider.RegisterScript(script.Source(), func(ctx context.Context, c caches.Cache, keys []string, args []any) (any, error) {
+		if err := c.Set(ctx, keys[0], "partial", 0).Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("failed")
+	})
+
+	key := "test:script:rollback"
+	err := script.Run(s.ctx, s.provider, []string{key}).Err()
+	s.Require().EqualError(err, "failed")
+
+	exists, err := s.provider.Exists(s.ctx, key).Result()
+	s.Require().NoError(err)
+	s.Equal(int64(0), exists)
+}
+
+// TestCompareCommand runs all CompareCommand tests
+func (s *RedkaTestSuite) TestCompareCommand() {
+	RunCompareCommandTests(s.T(), s)
+}
+
+// TestLock runs all lock package tests
+func (s *RedkaTestSuite) TestLock() {
+	RunLockTests(s.T(), s)
+}
+
+// TestRateLimit runs all ratelimit package tests
+func (s *RedkaTestSuite) TestRateLimit() {
+	RunRateLimitTests(s.T(), s)
+}
+
+// TestScript runs all ScriptCommand tests
+func (s *RedkaTestSuite) TestScript() {
+	RunScriptTests(s.T(), s)
+}
+
+// TestBitmapCommand runs all BitmapCommand tests
+func (s *RedkaTestSuite) TestBitmapCommand() {
+	RunBitmapCommandTests(s.T(), s)
+}
+
+// TestHyperLogLogCommand runs all HyperLogLogCommand tests
+func (s *RedkaTestSuite) TestHyperLogLogCommand() {
+	RunHyperLogLogCommandTests(s.T(), s)
+}
+
+// TestGeoCommand runs all GeoCommand tests
+func (s *RedkaTestSuite) TestGeoCommand() {
+	RunGeoCommandTests(s.T(), s)
+}
+
 // TestRedka runs all Redka provider tests
 func TestRedka(t *testing.T) {
 	suite.Run(t, new(RedkaTestSuite))
@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// ScriptProvider provides the commands used by the ScriptCommand tests
+type ScriptProvider interface {
+	GetScriptCommand() caches.ScriptCommand
+	GetStringCommand() caches.StringCommand
+	GetKeyCommand() caches.KeyCommand
+	GetContext() context.Context
+
+	// RegisterScript registers the Go implementation of a script for providers without Lua
+	RegisterScript(script string, fn caches.ScriptFunc)
+}
+
+// RunScriptTests runs all ScriptCommand tests
+func RunScriptTests(t *testing.T, provider ScriptProvider) {
+	registerTestScripts(provider)
+
+	t.Run("Eval", func(t *testing.T) {
+		testScriptEval(t, provider)
+	})
+	t.Run("Eval_Nil", func(t *testing.T) {
+		testScriptEvalNil(t, provider)
+	})
+	t.Run("Eval_Multi", func(t *testing.T) {
+		testScriptEvalMulti(t, provider)
+	})
+	t.Run("EvalSha_NoScript", func(t *testing.T) {
+		testScriptEvalShaNoScript(t, provider)
+	})
+	t.Run("ScriptLoad", func(t *testing.T) {
+		testScriptLoad(t, provider)
+	})
+	t.Run("Run", func(t *testing.T) {
+		testScriptRun(t, provider)
+	})
+}
+
+var (
+	// testIncrScript increments KEYS[1] by ARGV[1]
+	testIncrScript = caches.NewScript(`return redis.call("INCRBY", KEYS[1], ARGV[1])`)
+
+	// testNilScript returns nil
+	testNilScript = caches.NewScript(`return nil`)
+
+	// testSwapScript swaps the values of KEYS[1] and KEYS[2], returning them
+	testSwapScript = caches.NewScript(`
+local a = redis.call("GET", KEYS[1])
+local b = redis.call("GET", KEYS[2])
+redis.call("SET", KEYS[1], b)
+redis.call("SET", KEYS[2], a)
+return {b, a}`)
+)
+
+// registerTestScripts registers the Go implementations of the test scripts
+func registerTestScripts(provider ScriptProvider) {
+	provider.RegisterScript(testIncrScript.Source(), func(ctx context.Context, c caches.Cache, keys []string, args []any) (any, error) {
+		incr, ok := args[0].(int)
+		if !ok {
+			return nil, fmt.Errorf("unexpected increment %v", args[0])
+		}
+		return c.IncrBy(ctx, keys[0], int64(incr)).Result()
+	})
+
+	provider.RegisterScript(testNilScript.Source(), func(ctx context.Context, c caches.Cache, keys []string, args []any) (any, error) {
+		return nil, nil
+	})
+
+	provider.RegisterScript(testSwapScript.Source(), func(ctx context.Context, c caches.Cache, keys []string, args []any) (any, error) {
+		a, err := c.Get(ctx, keys[0]).Result()
+		if err != nil {
+			return nil, err
+		}
+		b, err := c.Get(ctx, keys[1]).Result()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Set(ctx, keys[0], b, 0).Err(); err != nil {
+			return nil, err
+		}
+		if err := c.Set(ctx, keys[1], a, 0).Err(); err != nil {
+			return nil, err
+		}
+		return []any{string(b), string(a)}, nil
+	})
+}
+
+// testScriptEval tests Eval runs a script with the provider prefix applied to its keys
+func testScriptEval(t *testing.T, provider ScriptProvider) {
+	scriptCmd := provider.GetScriptCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:script:eval"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	val, err := scriptCmd.Eval(ctx, testIncrScript.Source(), []string{key}, 5).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), val)
+
+	val, err = scriptCmd.Eval(ctx, testIncrScript.Source(), []string{key}, 2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), val)
+
+	// The script wrote the prefixed key read by the other commands
+	stored, err := strCmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("7"), stored)
+}
+
+// testScriptEvalNil tests a script returning nil is reported as caches.Nil
+func testScriptEvalNil(t *testing.T, provider ScriptProvider) {
+	scriptCmd := provider.GetScriptCommand()
+	ctx := provider.GetContext()
+
+	err := scriptCmd.Eval(ctx, testNilScript.Source(), nil).Err()
+	require.ErrorIs(t, err, caches.Nil)
+}
+
+// testScriptEvalMulti tests a script running several commands on several keys
+func testScriptEvalMulti(t *testing.T, provider ScriptProvider) {
+	scriptCmd := provider.GetScriptCommand()
+	strCmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key1, key2 := "test:script:swap1", "test:script:swap2"
+	defer keyCmd.Del(ctx, key1, key2)
+	require.NoError(t, strCmd.Set(ctx, key1, "a", 0).Err())
+	require.NoError(t, strCmd.Set(ctx, key2, "b", 0).Err())
+
+	val, err := scriptCmd.Eval(ctx, testSwapScript.Source(), []string{key1, key2}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []any{"b", "a"}, val)
+
+	vals, err := strCmd.MGet(ctx, key1, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{key1: []byte("b"), key2: []byte("a")}, vals)
+}
+
+// testScriptEvalShaNoScript tests EvalSha with an unknown digest
+func testScriptEvalShaNoScript(t *testing.T, provider ScriptProvider) {
+	scriptCmd := provider.GetScriptCommand()
+	ctx := provider.GetContext()
+
+	unknown := caches.ScriptHash("return 'unknown'")
+	err := scriptCmd.EvalSha(ctx, unknown, nil).Err()
+	require.True(t, errors.Is(err, caches.ErrNoScript), "unexpected error %v", err)
+
+	exists, err := scriptCmd.ScriptExists(ctx, unknown).Result()
+	require.NoError(t, err)
+	require.Equal(t, []bool{false}, exists)
+}
+
+// testScriptLoad tests a loaded script can be run by its digest
+func testScriptLoad(t *testing.T, provider ScriptProvider) {
+	scriptCmd := provider.GetScriptCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:script:load"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	sha1, err := testIncrScript.Load(ctx, scriptCmd).Result()
+	require.NoError(t, err)
+	require.Equal(t, testIncrScript.Hash(), sha1)
+
+	exists, err := testIncrScript.Exists(ctx, scriptCmd).Result()
+	require.NoError(t, err)
+	require.Equal(t, []bool{true}, exists)
+
+	val, err := scriptCmd.EvalSha(ctx, sha1, []string{key}, 3).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), val)
+}
+
+// testScriptRun tests Script.Run with the same call site on all providers
+func testScriptRun(t *testing.T, provider ScriptProvider) {
+	scriptCmd := provider.GetScriptCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:script:run"
+	keyCmd.Del(ctx, key)
+	defer keyCmd.Del(ctx, key)
+
+	for i := int64(1); i <= 3; i++ {
+		val, err := testIncrScript.Run(ctx, scriptCmd, []string{key}, 1).Result()
+		require.NoError(t, err)
+		require.Equal(t, i, val)
+	}
+}
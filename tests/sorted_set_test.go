@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/rockcookies/go-caches"
 	"github.com/stretchr/testify/require"
@@ -118,6 +119,60 @@ func RunSortedSetCommandTests(t *testing.T, provider SortedSetCommandProvider) {
 	t.Run("ZScan_NonExistent", func(t *testing.T) {
 		testZScanNonExistent(t, provider)
 	})
+	t.Run("ZPopMin_and_ZPopMax", func(t *testing.T) {
+		testZPopMinAndZPopMax(t, provider)
+	})
+	t.Run("BZPopMin", func(t *testing.T) {
+		testBZPopMin(t, provider)
+	})
+	t.Run("BZPopMax", func(t *testing.T) {
+		testBZPopMax(t, provider)
+	})
+	t.Run("BZPopMin_Timeout", func(t *testing.T) {
+		testBZPopMinTimeout(t, provider)
+	})
+	t.Run("BZPopMin_WakeOnAdd", func(t *testing.T) {
+		testBZPopMinWakeOnAdd(t, provider)
+	})
+	t.Run("ZMPop", func(t *testing.T) {
+		testZMPop(t, provider)
+	})
+	t.Run("ZRandMember", func(t *testing.T) {
+		testZRandMember(t, provider)
+	})
+	t.Run("ZRangeByLex", func(t *testing.T) {
+		testZRangeByLex(t, provider)
+	})
+	t.Run("ZRevRangeByLex", func(t *testing.T) {
+		testZRevRangeByLex(t, provider)
+	})
+	t.Run("ZRangeArgs_ByLex", func(t *testing.T) {
+		testZRangeArgsByLex(t, provider)
+	})
+	t.Run("ZLexCount", func(t *testing.T) {
+		testZLexCount(t, provider)
+	})
+	t.Run("ZRemRangeByLex", func(t *testing.T) {
+		testZRemRangeByLex(t, provider)
+	})
+	t.Run("ZRangeByLex_InvalidBounds", func(t *testing.T) {
+		testZRangeByLexInvalidBounds(t, provider)
+	})
+	t.Run("ZDiff", func(t *testing.T) {
+		testZDiff(t, provider)
+	})
+	t.Run("ZDiffStore", func(t *testing.T) {
+		testZDiffStore(t, provider)
+	})
+	t.Run("ZInterCard", func(t *testing.T) {
+		testZInterCard(t, provider)
+	})
+	t.Run("ZRangeStore", func(t *testing.T) {
+		testZRangeStore(t, provider)
+	})
+	t.Run("ZMScore", func(t *testing.T) {
+		testZMScore(t, provider)
+	})
 }
 
 // testZAddAndZRange tests ZAdd and ZRange operations
@@ -953,3 +1008,530 @@ func testZScanNonExistent(t *testing.T, provider SortedSetCommandProvider) {
 	require.Empty(t, scanResult.Members)
 	require.Equal(t, uint64(0), scanResult.Cursor)
 }
+
+// clearZSets removes all the members of the sorted sets stored at keys
+func clearZSets(ctx context.Context, cmd caches.SortedSetCommand, keys ...string) {
+	for _, key := range keys {
+		cmd.ZRemRangeByScore(ctx, key, "-inf", "+inf")
+	}
+}
+
+// popTestMembers are the members added by the pop tests
+var popTestMembers = []caches.ZMember{
+	{Member: []byte("a"), Score: 1},
+	{Member: []byte("b"), Score: 2},
+	{Member: []byte("c"), Score: 3},
+	{Member: []byte("d"), Score: 4},
+}
+
+// testZPopMinAndZPopMax tests ZPopMin and ZPopMax remove the members with the lowest and highest scores
+func testZPopMinAndZPopMax(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zpop"
+	clearZSets(ctx, cmd, key)
+	require.NoError(t, cmd.ZAdd(ctx, key, popTestMembers...).Err())
+
+	members, err := cmd.ZPopMin(ctx, key, 2).Result()
+	require.NoError(t, err)
+	require.Equal(t, popTestMembers[:2], members)
+
+	members, err = cmd.ZPopMax(ctx, key, 1).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.ZMember{popTestMembers[3]}, members)
+
+	// The count is capped by the size of the sorted set
+	members, err = cmd.ZPopMax(ctx, key, 10).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.ZMember{popTestMembers[2]}, members)
+
+	card, err := cmd.ZCard(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), card)
+
+	members, err = cmd.ZPopMin(ctx, key, 1).Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+}
+
+// testBZPopMin tests BZPopMin pops the lowest score of the first non-empty sorted set
+func testBZPopMin(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	empty := "test:zset:bzpopmin_empty"
+	key := "test:zset:bzpopmin"
+	clearZSets(ctx, cmd, empty, key)
+	require.NoError(t, cmd.ZAdd(ctx, key, popTestMembers...).Err())
+
+	result, err := cmd.BZPopMin(ctx, time.Second, empty, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, key, result.Key)
+	require.Equal(t, popTestMembers[0], result.Member)
+}
+
+// testBZPopMax tests BZPopMax pops the highest score of the first non-empty sorted set
+func testBZPopMax(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	empty := "test:zset:bzpopmax_empty"
+	key := "test:zset:bzpopmax"
+	clearZSets(ctx, cmd, empty, key)
+	require.NoError(t, cmd.ZAdd(ctx, key, popTestMembers...).Err())
+
+	result, err := cmd.BZPopMax(ctx, time.Second, empty, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, key, result.Key)
+	require.Equal(t, popTestMembers[3], result.Member)
+}
+
+// testBZPopMinTimeout tests BZPopMin returns Nil when the timeout expires
+func testBZPopMinTimeout(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:bzpopmin_timeout"
+	clearZSets(ctx, cmd, key)
+
+	result := cmd.BZPopMin(ctx, 100*time.Millisecond, key)
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testBZPopMinWakeOnAdd tests BZPopMin is woken up when a member is added
+func testBZPopMinWakeOnAdd(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:bzpopmin_wake"
+	clearZSets(ctx, cmd, key)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cmd.ZAdd(ctx, key, caches.ZMember{Member: []byte("job"), Score: 42})
+	}()
+
+	result, err := cmd.BZPopMin(ctx, 5*time.Second, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, key, result.Key)
+	require.Equal(t, caches.ZMember{Member: []byte("job"), Score: 42}, result.Member)
+}
+
+// testZMPop tests ZMPop pops members from the first non-empty sorted set
+func testZMPop(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	empty := "test:zset:zmpop_empty"
+	key := "test:zset:zmpop"
+	clearZSets(ctx, cmd, empty, key)
+	require.NoError(t, cmd.ZAdd(ctx, key, popTestMembers...).Err())
+
+	result, err := cmd.ZMPop(ctx, "MIN", 2, empty, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, key, result.Key)
+	require.Equal(t, popTestMembers[:2], result.Members)
+
+	result, err = cmd.ZMPop(ctx, "MAX", 10, empty, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, key, result.Key)
+	require.Equal(t, []caches.ZMember{popTestMembers[3], popTestMembers[2]}, result.Members)
+
+	require.ErrorIs(t, cmd.ZMPop(ctx, "MIN", 1, empty, key).Err(), caches.Nil)
+
+	require.Error(t, cmd.ZMPop(ctx, "MIDDLE", 1, key).Err())
+	require.Error(t, cmd.ZMPop(ctx, "MIN", 0, key).Err())
+}
+
+// testZRandMember tests ZRandMember with positive and negative counts
+func testZRandMember(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zrandmember"
+	clearZSets(ctx, cmd, key)
+	require.NoError(t, cmd.ZAdd(ctx, key, popTestMembers...).Err())
+
+	names := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+
+	// Positive counts return distinct members
+	members, err := cmd.ZRandMember(ctx, key, 2).Result()
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	require.NotEqual(t, members[0], members[1])
+	require.Subset(t, names, members)
+
+	members, err = cmd.ZRandMember(ctx, key, 10).Result()
+	require.NoError(t, err)
+	require.ElementsMatch(t, names, members)
+
+	// Negative counts may repeat members
+	members, err = cmd.ZRandMember(ctx, key, -6).Result()
+	require.NoError(t, err)
+	require.Len(t, members, 6)
+	require.Subset(t, names, members)
+
+	withScores, err := cmd.ZRandMemberWithScores(ctx, key, 10).Result()
+	require.NoError(t, err)
+	require.ElementsMatch(t, popTestMembers, withScores)
+
+	members, err = cmd.ZRandMember(ctx, "test:zset:zrandmember_missing", 2).Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+}
+
+// addLexTestMembers stores equal-score members at key, as an autocomplete index does
+func addLexTestMembers(t *testing.T, ctx context.Context, cmd caches.SortedSetCommand, key string) {
+	t.Helper()
+	clearZSets(ctx, cmd, key)
+
+	var members []caches.ZMember
+	for _, name := range []string{"cherry", "apple", "banana", "apricot", "blueberry", "avocado"} {
+		members = append(members, caches.ZMember{Member: []byte(name), Score: 0})
+	}
+	require.NoError(t, cmd.ZAdd(ctx, key, members...).Err())
+}
+
+// toStrings converts members returned by lex ranges for readable assertions
+func toStrings(members [][]byte) []string {
+	result := make([]string, len(members))
+	for i, m := range members {
+		result[i] = string(m)
+	}
+	return result
+}
+
+// testZRangeByLex tests ZRangeByLex with inclusive, exclusive and infinite bounds
+func testZRangeByLex(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zrangebylex"
+	addLexTestMembers(t, ctx, cmd, key)
+
+	members, err := cmd.ZRangeByLex(ctx, key, "-", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"apple", "apricot", "avocado", "banana", "blueberry", "cherry"}, toStrings(members))
+
+	// Prefix search, as used for autocompletion
+	members, err = cmd.ZRangeByLex(ctx, key, "[ap", "(aq").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"apple", "apricot"}, toStrings(members))
+
+	members, err = cmd.ZRangeByLex(ctx, key, "(apple", "[banana").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"apricot", "avocado", "banana"}, toStrings(members))
+
+	members, err = cmd.ZRangeByLex(ctx, key, "[b", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"banana", "blueberry", "cherry"}, toStrings(members))
+
+	members, err = cmd.ZRangeByLex(ctx, key, "+", "-").Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+
+	members, err = cmd.ZRangeByLex(ctx, "test:zset:zrangebylex_missing", "-", "+").Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+}
+
+// testZRevRangeByLex tests ZRevRangeByLex takes max before min and returns members in reverse order
+func testZRevRangeByLex(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zrevrangebylex"
+	addLexTestMembers(t, ctx, cmd, key)
+
+	members, err := cmd.ZRevRangeByLex(ctx, key, "+", "-").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"cherry", "blueberry", "banana", "avocado", "apricot", "apple"}, toStrings(members))
+
+	members, err = cmd.ZRevRangeByLex(ctx, key, "(b", "[apricot").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"avocado", "apricot"}, toStrings(members))
+}
+
+// testZRangeArgsByLex tests ZRangeArgs with ByLex, Rev and a limit
+func testZRangeArgsByLex(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zrangeargs_bylex"
+	addLexTestMembers(t, ctx, cmd, key)
+
+	members, err := cmd.ZRangeArgs(ctx, key, caches.ZRangeArgs{Start: "[a", Stop: "(b", ByLex: true, Offset: 1, Count: 2}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"apricot", "avocado"}, toStrings(members))
+
+	members, err = cmd.ZRangeArgs(ctx, key, caches.ZRangeArgs{Start: "[a", Stop: "(b", ByLex: true, Rev: true}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"avocado", "apricot", "apple"}, toStrings(members))
+
+	withScores, err := cmd.ZRangeArgsWithScores(ctx, key, caches.ZRangeArgs{Start: "[c", Stop: "+", ByLex: true}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.ZMember{{Member: []byte("cherry"), Score: 0}}, withScores)
+}
+
+// testZLexCount tests ZLexCount counts the members between lexicographical bounds
+func testZLexCount(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zlexcount"
+	addLexTestMembers(t, ctx, cmd, key)
+
+	n, err := cmd.ZLexCount(ctx, key, "-", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(6), n)
+
+	n, err = cmd.ZLexCount(ctx, key, "[a", "(b").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	n, err = cmd.ZLexCount(ctx, key, "(banana", "(cherry").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = cmd.ZLexCount(ctx, "test:zset:zlexcount_missing", "-", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+}
+
+// testZRemRangeByLex tests ZRemRangeByLex removes the members between lexicographical bounds
+func testZRemRangeByLex(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zremrangebylex"
+	addLexTestMembers(t, ctx, cmd, key)
+
+	n, err := cmd.ZRemRangeByLex(ctx, key, "[a", "(b").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	members, err := cmd.ZRangeByLex(ctx, key, "-", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"banana", "blueberry", "cherry"}, toStrings(members))
+
+	n, err = cmd.ZRemRangeByLex(ctx, key, "[x", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	// Removing the last members deletes the key
+	n, err = cmd.ZRemRangeByLex(ctx, key, "-", "+").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	card, err := cmd.ZCard(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), card)
+}
+
+// testZRangeByLexInvalidBounds tests that bounds without a `[` or `(` prefix are rejected
+func testZRangeByLexInvalidBounds(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zrangebylex_invalid"
+	addLexTestMembers(t, ctx, cmd, key)
+
+	require.Error(t, cmd.ZRangeByLex(ctx, key, "a", "+").Err())
+	require.Error(t, cmd.ZRevRangeByLex(ctx, key, "+", "").Err())
+	require.Error(t, cmd.ZLexCount(ctx, key, "-", "b").Err())
+	require.Error(t, cmd.ZRemRangeByLex(ctx, key, "-x", "+").Err())
+	require.Error(t, cmd.ZRangeArgs(ctx, key, caches.ZRangeArgs{Start: "a", Stop: "z", ByLex: true}).Err())
+
+	// Nothing is removed when the bounds are invalid
+	n, err := cmd.ZCard(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(6), n)
+}
+
+// addSetAlgebraTestMembers stores the sorted sets used by the ZDiff and ZInterCard tests
+func addSetAlgebraTestMembers(t *testing.T, ctx context.Context, cmd caches.SortedSetCommand, key1, key2, key3 string) {
+	t.Helper()
+	clearZSets(ctx, cmd, key1, key2, key3)
+
+	require.NoError(t, cmd.ZAdd(ctx, key1, popTestMembers...).Err())
+	require.NoError(t, cmd.ZAdd(ctx, key2,
+		caches.ZMember{Member: []byte("b"), Score: 20},
+		caches.ZMember{Member: []byte("c"), Score: 30},
+		caches.ZMember{Member: []byte("e"), Score: 50},
+	).Err())
+	require.NoError(t, cmd.ZAdd(ctx, key3,
+		caches.ZMember{Member: []byte("c"), Score: 300},
+		caches.ZMember{Member: []byte("d"), Score: 400},
+	).Err())
+}
+
+// testZDiff tests ZDiff and ZDiffWithScores keep the members and scores of the first sorted set only
+func testZDiff(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:zset:zdiff1"
+	key2 := "test:zset:zdiff2"
+	key3 := "test:zset:zdiff3"
+	addSetAlgebraTestMembers(t, ctx, cmd, key1, key2, key3)
+
+	members, err := cmd.ZDiff(ctx, key1, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "d"}, toStrings(members))
+
+	withScores, err := cmd.ZDiffWithScores(ctx, key1, key2, key3).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.ZMember{{Member: []byte("a"), Score: 1}}, withScores)
+
+	// Missing keys are empty sorted sets
+	members, err = cmd.ZDiff(ctx, key1, "test:zset:zdiff_missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d"}, toStrings(members))
+
+	members, err = cmd.ZDiff(ctx, "test:zset:zdiff_missing", key1).Result()
+	require.NoError(t, err)
+	require.Empty(t, members)
+}
+
+// testZDiffStore tests ZDiffStore overwrites the destination and deletes it when the difference is empty
+func testZDiffStore(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:zset:zdiffstore1"
+	key2 := "test:zset:zdiffstore2"
+	key3 := "test:zset:zdiffstore3"
+	dest := "test:zset:zdiffstore_dest"
+	addSetAlgebraTestMembers(t, ctx, cmd, key1, key2, key3)
+	clearZSets(ctx, cmd, dest)
+	require.NoError(t, cmd.ZAdd(ctx, dest, caches.ZMember{Member: []byte("old"), Score: 9}).Err())
+
+	n, err := cmd.ZDiffStore(ctx, dest, key1, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	stored, err := cmd.ZRangeWithScores(ctx, dest, 0, -1).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.ZMember{
+		{Member: []byte("a"), Score: 1},
+		{Member: []byte("d"), Score: 4},
+	}, stored)
+
+	n, err = cmd.ZDiffStore(ctx, dest, key1, key1).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	card, err := cmd.ZCard(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), card)
+}
+
+// testZInterCard tests ZInterCard with and without a limit
+func testZInterCard(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:zset:zintercard1"
+	key2 := "test:zset:zintercard2"
+	key3 := "test:zset:zintercard3"
+	addSetAlgebraTestMembers(t, ctx, cmd, key1, key2, key3)
+
+	n, err := cmd.ZInterCard(ctx, 0, key1, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	n, err = cmd.ZInterCard(ctx, 1, key1, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = cmd.ZInterCard(ctx, 10, key1, key2).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	n, err = cmd.ZInterCard(ctx, 0, key1, key2, key3).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	n, err = cmd.ZInterCard(ctx, 0, key1, "test:zset:zintercard_missing").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	require.Error(t, cmd.ZInterCard(ctx, -1, key1, key2).Err())
+}
+
+// testZRangeStore tests ZRangeStore stores the members selected by rank, score and lex ranges
+func testZRangeStore(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zrangestore"
+	dest := "test:zset:zrangestore_dest"
+	clearZSets(ctx, cmd, key, dest)
+	require.NoError(t, cmd.ZAdd(ctx, key, popTestMembers...).Err())
+
+	n, err := cmd.ZRangeStore(ctx, dest, key, caches.ZRangeArgs{Start: 1, Stop: 2}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	stored, err := cmd.ZRangeWithScores(ctx, dest, 0, -1).Result()
+	require.NoError(t, err)
+	require.Equal(t, []caches.ZMember{
+		{Member: []byte("b"), Score: 2},
+		{Member: []byte("c"), Score: 3},
+	}, stored)
+
+	// The destination is overwritten
+	n, err = cmd.ZRangeStore(ctx, dest, key, caches.ZRangeArgs{Start: "3", Stop: "+inf", ByScore: true}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	names, err := cmd.ZRange(ctx, dest, 0, -1).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "d"}, toStrings(names))
+
+	lexKey := "test:zset:zrangestore_lex"
+	addLexTestMembers(t, ctx, cmd, lexKey)
+	n, err = cmd.ZRangeStore(ctx, dest, lexKey, caches.ZRangeArgs{Start: "[a", Stop: "(b", ByLex: true, Rev: true, Offset: 0, Count: 2}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	names, err = cmd.ZRange(ctx, dest, 0, -1).Result()
+	require.NoError(t, err)
+	require.Equal(t, []string{"apricot", "avocado"}, toStrings(names))
+
+	// An empty range deletes the destination
+	n, err = cmd.ZRangeStore(ctx, dest, "test:zset:zrangestore_missing", caches.ZRangeArgs{Start: 0, Stop: -1}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	card, err := cmd.ZCard(ctx, dest).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), card)
+}
+
+// testZMScore tests ZMScore returns nil for the missing members
+func testZMScore(t *testing.T, provider SortedSetCommandProvider) {
+	cmd := provider.GetSortedSetCommand()
+	ctx := provider.GetContext()
+
+	key := "test:zset:zmscore"
+	clearZSets(ctx, cmd, key)
+	require.NoError(t, cmd.ZAdd(ctx, key,
+		caches.ZMember{Member: []byte("a"), Score: 1.5},
+		caches.ZMember{Member: []byte("b"), Score: -2},
+	).Err())
+
+	scores, err := cmd.ZMScore(ctx, key, "a", "missing", "b").Result()
+	require.NoError(t, err)
+	require.Len(t, scores, 3)
+	require.NotNil(t, scores[0])
+	require.Equal(t, 1.5, *scores[0])
+	require.Nil(t, scores[1])
+	require.NotNil(t, scores[2])
+	require.Equal(t, float64(-2), *scores[2])
+
+	scores, err = cmd.ZMScore(ctx, "test:zset:zmscore_missing", "a").Result()
+	require.NoError(t, err)
+	require.Equal(t, []*float64{nil}, scores)
+}
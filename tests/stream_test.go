@@ -0,0 +1,593 @@
+package tests
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// StreamCommandProvider defines the interface for testing StreamCommand implementations
+type StreamCommandProvider interface {
+	GetStreamCommand() caches.StreamCommand
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// RunStreamCommandTests runs all StreamCommand tests
+func RunStreamCommandTests(t *testing.T, provider StreamCommandProvider) {
+	t.Run("XAdd", func(t *testing.T) {
+		testXAdd(t, provider)
+	})
+	t.Run("XAdd_NoMkStream", func(t *testing.T) {
+		testXAddNoMkStream(t, provider)
+	})
+	t.Run("XAdd_MaxLen", func(t *testing.T) {
+		testXAddMaxLen(t, provider)
+	})
+	t.Run("XRange", func(t *testing.T) {
+		testXRange(t, provider)
+	})
+	t.Run("XRevRange", func(t *testing.T) {
+		testXRevRange(t, provider)
+	})
+	t.Run("XDel", func(t *testing.T) {
+		testXDel(t, provider)
+	})
+	t.Run("XTrim", func(t *testing.T) {
+		testXTrim(t, provider)
+	})
+	t.Run("XRead", func(t *testing.T) {
+		testXRead(t, provider)
+	})
+	t.Run("XRead_Block", func(t *testing.T) {
+		testXReadBlock(t, provider)
+	})
+	t.Run("XGroupCreate", func(t *testing.T) {
+		testXGroupCreate(t, provider)
+	})
+	t.Run("XReadGroup", func(t *testing.T) {
+		testXReadGroup(t, provider)
+	})
+	t.Run("XPending", func(t *testing.T) {
+		testXPending(t, provider)
+	})
+	t.Run("XClaim", func(t *testing.T) {
+		testXClaim(t, provider)
+	})
+	t.Run("XAutoClaim", func(t *testing.T) {
+		testXAutoClaim(t, provider)
+	})
+	t.Run("Del", func(t *testing.T) {
+		testStreamDel(t, provider)
+	})
+	t.Run("Exists", func(t *testing.T) {
+		testStreamExists(t, provider)
+	})
+}
+
+// clearStream removes the stream stored at key along with its consumer groups
+func clearStream(t *testing.T, provider StreamCommandProvider, key string, groups ...string) {
+	t.Helper()
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	provider.GetCache().Del(ctx, key)
+	for _, group := range groups {
+		streams.XGroupDestroy(ctx, key, group)
+	}
+	require.NoError(t, streams.XTrimMaxLen(ctx, key, 0).Err())
+}
+
+// addEntries adds entries with the given IDs and a single "n" field set to their position
+func addEntries(t *testing.T, provider StreamCommandProvider, key string, ids ...string) {
+	t.Helper()
+	for i, id := range ids {
+		result := provider.GetStreamCommand().XAdd(provider.GetContext(), key, caches.XAddArgs{
+			ID:     id,
+			Values: map[string]any{"n": i + 1},
+		})
+		require.NoError(t, result.Err())
+		require.Equal(t, id, result.Val())
+	}
+}
+
+// messageIDs returns the IDs of msgs
+func messageIDs(msgs []caches.XMessage) []string {
+	ids := make([]string, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.ID
+	}
+	return ids
+}
+
+// testXAdd tests adding entries with generated and explicit IDs
+func testXAdd(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:xadd"
+	clearStream(t, provider, key)
+
+	id := streams.XAdd(ctx, key, caches.XAddArgs{
+		Values: map[string]any{"event": "signup", "user": "1"},
+	})
+	require.NoError(t, id.Err())
+	require.Regexp(t, `^\d+-\d+$`, id.Val())
+
+	next := streams.XAdd(ctx, key, caches.XAddArgs{
+		ID:     "*",
+		Values: map[string]any{"event": "login"},
+	})
+	require.NoError(t, next.Err())
+	require.NotEqual(t, id.Val(), next.Val())
+
+	// IDs must grow
+	require.Error(t, streams.XAdd(ctx, key, caches.XAddArgs{ID: "1-1", Values: map[string]any{"a": "b"}}).Err())
+
+	length := streams.XLen(ctx, key)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(2), length.Val())
+
+	msgs := streams.XRange(ctx, key, "-", "+")
+	require.NoError(t, msgs.Err())
+	require.Len(t, msgs.Val(), 2)
+	require.Equal(t, id.Val(), msgs.Val()[0].ID)
+	require.Equal(t, map[string][]byte{"event": []byte("signup"), "user": []byte("1")}, msgs.Val()[0].Values)
+
+	length = streams.XLen(ctx, "test:stream:missing")
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(0), length.Val())
+}
+
+// testXAddNoMkStream tests that NoMkStream does not create the stream
+func testXAddNoMkStream(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:nomkstream"
+	clearStream(t, provider, key)
+
+	result := streams.XAdd(ctx, key, caches.XAddArgs{
+		NoMkStream: true,
+		Values:     map[string]any{"a": "b"},
+	})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+
+	length := streams.XLen(ctx, key)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(0), length.Val())
+}
+
+// testXAddMaxLen tests trimming the stream while adding entries
+func testXAddMaxLen(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:maxlen"
+	clearStream(t, provider, key)
+
+	for i := 1; i <= 5; i++ {
+		result := streams.XAdd(ctx, key, caches.XAddArgs{
+			ID:     "1-" + strconv.Itoa(i),
+			Values: map[string]any{"n": i},
+			MaxLen: 3,
+		})
+		require.NoError(t, result.Err())
+	}
+
+	msgs := streams.XRange(ctx, key, "-", "+")
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"1-3", "1-4", "1-5"}, messageIDs(msgs.Val()))
+}
+
+// testXRange tests range bounds and counts
+func testXRange(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:xrange"
+	clearStream(t, provider, key)
+	addEntries(t, provider, key, "1-1", "1-2", "2-1", "3-1")
+
+	msgs := streams.XRange(ctx, key, "1-2", "2")
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"1-2", "2-1"}, messageIDs(msgs.Val()))
+	require.Equal(t, []byte("2"), msgs.Val()[0].Values["n"])
+
+	msgs = streams.XRange(ctx, key, "(1-2", "+")
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"2-1", "3-1"}, messageIDs(msgs.Val()))
+
+	msgs = streams.XRangeN(ctx, key, "-", "+", 2)
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"1-1", "1-2"}, messageIDs(msgs.Val()))
+
+	msgs = streams.XRange(ctx, "test:stream:missing", "-", "+")
+	require.NoError(t, msgs.Err())
+	require.Empty(t, msgs.Val())
+}
+
+// testXRevRange tests reading a range in reverse order
+func testXRevRange(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:xrevrange"
+	clearStream(t, provider, key)
+	addEntries(t, provider, key, "1-1", "1-2", "2-1", "3-1")
+
+	msgs := streams.XRevRange(ctx, key, "+", "-")
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"3-1", "2-1", "1-2", "1-1"}, messageIDs(msgs.Val()))
+
+	msgs = streams.XRevRangeN(ctx, key, "2-1", "-", 2)
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"2-1", "1-2"}, messageIDs(msgs.Val()))
+}
+
+// testXDel tests removing entries
+func testXDel(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:xdel"
+	clearStream(t, provider, key)
+	addEntries(t, provider, key, "1-1", "1-2", "1-3")
+
+	removed := streams.XDel(ctx, key, "1-2", "9-9")
+	require.NoError(t, removed.Err())
+	require.Equal(t, int64(1), removed.Val())
+
+	msgs := streams.XRange(ctx, key, "-", "+")
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"1-1", "1-3"}, messageIDs(msgs.Val()))
+}
+
+// testXTrim tests trimming by length and by minimum ID
+func testXTrim(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:xtrim"
+	clearStream(t, provider, key)
+	addEntries(t, provider, key, "1-1", "2-1", "3-1", "4-1", "5-1")
+
+	removed := streams.XTrimMaxLen(ctx, key, 4)
+	require.NoError(t, removed.Err())
+	require.Equal(t, int64(1), removed.Val())
+
+	removed = streams.XTrimMinID(ctx, key, "4")
+	require.NoError(t, removed.Err())
+	require.Equal(t, int64(2), removed.Val())
+
+	msgs := streams.XRange(ctx, key, "-", "+")
+	require.NoError(t, msgs.Err())
+	require.Equal(t, []string{"4-1", "5-1"}, messageIDs(msgs.Val()))
+}
+
+// testXRead tests reading entries after an ID from several streams
+func testXRead(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:stream:xread1"
+	key2 := "test:stream:xread2"
+	clearStream(t, provider, key1)
+	clearStream(t, provider, key2)
+	addEntries(t, provider, key1, "1-1", "1-2", "1-3")
+	addEntries(t, provider, key2, "2-1")
+
+	result := streams.XRead(ctx, caches.XReadArgs{
+		Streams: []string{key1, key2},
+		IDs:     []string{"1-1", "0"},
+		Count:   1,
+	})
+	require.NoError(t, result.Err())
+	require.Len(t, result.Val(), 2)
+	require.Equal(t, key1, result.Val()[0].Stream)
+	require.Equal(t, []string{"1-2"}, messageIDs(result.Val()[0].Messages))
+	require.Equal(t, key2, result.Val()[1].Stream)
+	require.Equal(t, []string{"2-1"}, messageIDs(result.Val()[1].Messages))
+
+	// Nothing after the last entry
+	result = streams.XRead(ctx, caches.XReadArgs{
+		Streams: []string{key1},
+		IDs:     []string{"$"},
+	})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testXReadBlock tests that a blocked XRead returns entries added while waiting
+func testXReadBlock(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:block"
+	clearStream(t, provider, key)
+	addEntries(t, provider, key, "1-1")
+
+	start := time.Now()
+	result := streams.XRead(ctx, caches.XReadArgs{
+		Streams: []string{key},
+		IDs:     []string{"$"},
+		Block:   200 * time.Millisecond,
+	})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		streams.XAdd(ctx, key, caches.XAddArgs{ID: "2-1", Values: map[string]any{"n": 2}})
+	}()
+
+	result = streams.XRead(ctx, caches.XReadArgs{
+		Streams: []string{key},
+		IDs:     []string{"$"},
+		Block:   5 * time.Second,
+	})
+	require.NoError(t, result.Err())
+	require.Len(t, result.Val(), 1)
+	require.Equal(t, []string{"2-1"}, messageIDs(result.Val()[0].Messages))
+}
+
+// testXGroupCreate tests creating consumer groups
+func testXGroupCreate(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:group"
+	clearStream(t, provider, key, "workers")
+
+	require.Error(t, streams.XGroupCreate(ctx, key, "workers", "$").Err())
+
+	status := streams.XGroupCreateMkStream(ctx, key, "workers", "$")
+	require.NoError(t, status.Err())
+	require.Equal(t, "OK", status.Val())
+
+	// The group already exists
+	require.Error(t, streams.XGroupCreate(ctx, key, "workers", "0").Err())
+
+	destroyed := streams.XGroupDestroy(ctx, key, "workers")
+	require.NoError(t, destroyed.Err())
+	require.Equal(t, int64(1), destroyed.Val())
+
+	destroyed = streams.XGroupDestroy(ctx, key, "workers")
+	require.NoError(t, destroyed.Err())
+	require.Equal(t, int64(0), destroyed.Val())
+}
+
+// testXReadGroup tests delivering entries to the consumers of a group
+func testXReadGroup(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:readgroup"
+	clearStream(t, provider, key, "workers")
+	addEntries(t, provider, key, "1-1", "1-2", "1-3")
+	require.NoError(t, streams.XGroupCreate(ctx, key, "workers", "0").Err())
+
+	result := streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "workers",
+		Consumer: "alice",
+		Streams:  []string{key},
+		IDs:      []string{">"},
+		Count:    2,
+	})
+	require.NoError(t, result.Err())
+	require.Len(t, result.Val(), 1)
+	require.Equal(t, key, result.Val()[0].Stream)
+	require.Equal(t, []string{"1-1", "1-2"}, messageIDs(result.Val()[0].Messages))
+
+	// Entries are delivered once per group
+	result = streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "workers",
+		Consumer: "bob",
+		Streams:  []string{key},
+		IDs:      []string{">"},
+	})
+	require.NoError(t, result.Err())
+	require.Equal(t, []string{"1-3"}, messageIDs(result.Val()[0].Messages))
+
+	result = streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "workers",
+		Consumer: "bob",
+		Streams:  []string{key},
+		IDs:      []string{">"},
+	})
+	require.ErrorIs(t, result.Err(), caches.Nil)
+
+	// Reading from an ID returns the pending entries of the consumer
+	acked := streams.XAck(ctx, key, "workers", "1-1")
+	require.NoError(t, acked.Err())
+	require.Equal(t, int64(1), acked.Val())
+
+	result = streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "workers",
+		Consumer: "alice",
+		Streams:  []string{key},
+		IDs:      []string{"0"},
+	})
+	require.NoError(t, result.Err())
+	require.Equal(t, []string{"1-2"}, messageIDs(result.Val()[0].Messages))
+	require.Equal(t, []byte("2"), result.Val()[0].Messages[0].Values["n"])
+
+	// Unknown group
+	result = streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "missing",
+		Consumer: "alice",
+		Streams:  []string{key},
+		IDs:      []string{">"},
+	})
+	require.Error(t, result.Err())
+	require.NotErrorIs(t, result.Err(), caches.Nil)
+}
+
+// testXPending tests inspecting the pending entries of a group
+func testXPending(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:pending"
+	clearStream(t, provider, key, "workers")
+	addEntries(t, provider, key, "1-1", "1-2", "1-3")
+	require.NoError(t, streams.XGroupCreate(ctx, key, "workers", "0").Err())
+
+	pending := streams.XPending(ctx, key, "workers")
+	require.NoError(t, pending.Err())
+	require.Equal(t, int64(0), pending.Val().Count)
+
+	for _, consumer := range []string{"alice", "bob"} {
+		require.NoError(t, streams.XReadGroup(ctx, caches.XReadGroupArgs{
+			Group:    "workers",
+			Consumer: consumer,
+			Streams:  []string{key},
+			IDs:      []string{">"},
+			Count:    2,
+		}).Err())
+	}
+
+	pending = streams.XPending(ctx, key, "workers")
+	require.NoError(t, pending.Err())
+	require.Equal(t, caches.XPending{
+		Count:     3,
+		Lower:     "1-1",
+		Higher:    "1-3",
+		Consumers: map[string]int64{"alice": 2, "bob": 1},
+	}, pending.Val())
+
+	ext := streams.XPendingExt(ctx, key, "workers", caches.XPendingExtArgs{
+		Start:    "-",
+		End:      "+",
+		Count:    10,
+		Consumer: "alice",
+	})
+	require.NoError(t, ext.Err())
+	require.Len(t, ext.Val(), 2)
+	require.Equal(t, "1-1", ext.Val()[0].ID)
+	require.Equal(t, "alice", ext.Val()[0].Consumer)
+	require.Equal(t, int64(1), ext.Val()[0].RetryCount)
+}
+
+// testXClaim tests transferring pending entries to another consumer
+func testXClaim(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:claim"
+	clearStream(t, provider, key, "workers")
+	addEntries(t, provider, key, "1-1", "1-2")
+	require.NoError(t, streams.XGroupCreate(ctx, key, "workers", "0").Err())
+	require.NoError(t, streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "workers",
+		Consumer: "alice",
+		Streams:  []string{key},
+		IDs:      []string{">"},
+	}).Err())
+
+	// Not idle for long enough
+	claimed := streams.XClaim(ctx, key, "workers", "bob", time.Hour, "1-1")
+	require.NoError(t, claimed.Err())
+	require.Empty(t, claimed.Val())
+
+	time.Sleep(50 * time.Millisecond)
+	claimed = streams.XClaim(ctx, key, "workers", "bob", 10*time.Millisecond, "1-1")
+	require.NoError(t, claimed.Err())
+	require.Equal(t, []string{"1-1"}, messageIDs(claimed.Val()))
+	require.Equal(t, []byte("1"), claimed.Val()[0].Values["n"])
+
+	ext := streams.XPendingExt(ctx, key, "workers", caches.XPendingExtArgs{Count: 10, Consumer: "bob"})
+	require.NoError(t, ext.Err())
+	require.Len(t, ext.Val(), 1)
+	require.Equal(t, int64(2), ext.Val()[0].RetryCount)
+}
+
+// testXAutoClaim tests claiming idle pending entries by scanning the group
+func testXAutoClaim(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	ctx := provider.GetContext()
+
+	key := "test:stream:autoclaim"
+	clearStream(t, provider, key, "workers")
+	addEntries(t, provider, key, "1-1", "1-2", "1-3")
+	require.NoError(t, streams.XGroupCreate(ctx, key, "workers", "0").Err())
+	require.NoError(t, streams.XReadGroup(ctx, caches.XReadGroupArgs{
+		Group:    "workers",
+		Consumer: "alice",
+		Streams:  []string{key},
+		IDs:      []string{">"},
+	}).Err())
+
+	time.Sleep(50 * time.Millisecond)
+	result := streams.XAutoClaim(ctx, key, "workers", "bob", 10*time.Millisecond, "0-0", 2)
+	require.NoError(t, result.Err())
+	require.Equal(t, []string{"1-1", "1-2"}, messageIDs(result.Val().Messages))
+	require.Equal(t, "1-3", result.Val().Next)
+
+	result = streams.XAutoClaim(ctx, key, "workers", "bob", 10*time.Millisecond, result.Val().Next, 2)
+	require.NoError(t, result.Err())
+	require.Equal(t, []string{"1-3"}, messageIDs(result.Val().Messages))
+	require.Equal(t, "0-0", result.Val().Next)
+
+	pending := streams.XPending(ctx, key, "workers")
+	require.NoError(t, pending.Err())
+	require.Equal(t, map[string]int64{"bob": 3}, pending.Val().Consumers)
+}
+
+// testStreamDel tests that deleting a stream key deletes its entries and consumer groups
+func testStreamDel(t *testing.T, provider StreamCommandProvider) {
+	streams := provider.GetStreamCommand()
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:stream:del"
+	clearStream(t, provider, key, "group")
+
+	addEntries(t, provider, key, "1-1", "1-2")
+	require.NoError(t, streams.XGroupCreate(ctx, key, "group", "0").Err())
+
+	deleted := cache.Del(ctx, key)
+	require.NoError(t, deleted.Err())
+	require.Equal(t, int64(1), deleted.Val())
+
+	length := streams.XLen(ctx, key)
+	require.NoError(t, length.Err())
+	require.Equal(t, int64(0), length.Val())
+
+	// The consumer group is deleted along with the stream
+	require.Error(t, streams.XGroupCreate(ctx, key, "group", "0").Err())
+	require.NoError(t, streams.XGroupCreateMkStream(ctx, key, "group", "0").Err())
+
+	pending := streams.XPending(ctx, key, "group")
+	require.NoError(t, pending.Err())
+	require.Equal(t, int64(0), pending.Val().Count)
+}
+
+// testStreamExists tests that key commands see stream keys
+func testStreamExists(t *testing.T, provider StreamCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:stream:exists"
+	clearStream(t, provider, key)
+
+	exists := cache.Exists(ctx, key)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(0), exists.Val())
+
+	addEntries(t, provider, key, "1-1")
+
+	exists = cache.Exists(ctx, key)
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(1), exists.Val())
+
+	typ := cache.Type(ctx, key)
+	require.NoError(t, typ.Err())
+	require.Equal(t, "stream", typ.Val())
+
+	// Commands of other types fail on a stream key
+	require.Error(t, cache.LPush(ctx, key, "value").Err())
+}
@@ -12,6 +12,7 @@ import (
 // StringCommandProvider defines the interface for testing StringCommand implementations
 type StringCommandProvider interface {
 	GetStringCommand() caches.StringCommand
+	GetKeyCommand() caches.KeyCommand
 	GetContext() context.Context
 }
 
@@ -101,6 +102,30 @@ func RunStringCommandTests(t *testing.T, provider StringCommandProvider) {
 	t.Run("MSetNX_SomeExist", func(t *testing.T) {
 		testMSetNXSomeExist(t, provider)
 	})
+	t.Run("GetDel", func(t *testing.T) {
+		testGetDel(t, provider)
+	})
+	t.Run("GetEx", func(t *testing.T) {
+		testGetEx(t, provider)
+	})
+	t.Run("GetEx_ExpireAtPast", func(t *testing.T) {
+		testGetExExpireAtPast(t, provider)
+	})
+	t.Run("GetRange", func(t *testing.T) {
+		testGetRange(t, provider)
+	})
+	t.Run("SetRange", func(t *testing.T) {
+		testSetRange(t, provider)
+	})
+	t.Run("Append", func(t *testing.T) {
+		testAppend(t, provider)
+	})
+	t.Run("LCS", func(t *testing.T) {
+		testLCS(t, provider)
+	})
+	t.Run("LCS_Idx", func(t *testing.T) {
+		testLCSIdx(t, provider)
+	})
 }
 
 // testSetAndGet tests basic Set and Get operations
@@ -735,3 +760,231 @@ func testMSetNXSomeExist(t *testing.T, provider StringCommandProvider) {
 	getResult3 := cmd.Get(ctx, "test:string:msetnx_exist3")
 	require.Equal(t, caches.Nil, getResult3.Err())
 }
+
+// testGetDel tests GetDel returns the value and deletes the key
+func testGetDel(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	key := "test:string:getdel"
+	require.NoError(t, cmd.Set(ctx, key, "value", 0).Err())
+
+	val, err := cmd.GetDel(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	require.Equal(t, caches.Nil, cmd.Get(ctx, key).Err())
+	require.Equal(t, caches.Nil, cmd.GetDel(ctx, key).Err())
+}
+
+// testGetEx tests GetEx sets, keeps and removes the expiration of a key
+func testGetEx(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:string:getex"
+	require.NoError(t, cmd.Set(ctx, key, "value", 0).Err())
+
+	val, err := cmd.GetEx(ctx, key, caches.GetExArgs{TTL: time.Minute}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	ttl, err := keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, 50*time.Second)
+	require.LessOrEqual(t, ttl, time.Minute)
+
+	// Without options the expiration is left unchanged
+	val, err = cmd.GetEx(ctx, key, caches.GetExArgs{}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	ttl, err = keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, 50*time.Second)
+
+	require.NoError(t, cmd.GetEx(ctx, key, caches.GetExArgs{ExpireAt: time.Now().Add(time.Hour)}).Err())
+
+	ttl, err = keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, 59*time.Minute)
+	require.LessOrEqual(t, ttl, time.Hour)
+
+	require.NoError(t, cmd.GetEx(ctx, key, caches.GetExArgs{Persist: true}).Err())
+
+	ttl, err = keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(-1), ttl)
+
+	// The key expires with a short TTL
+	require.NoError(t, cmd.GetEx(ctx, key, caches.GetExArgs{TTL: 100 * time.Millisecond}).Err())
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, caches.Nil, cmd.Get(ctx, key).Err())
+
+	require.Equal(t, caches.Nil, cmd.GetEx(ctx, "test:string:getex_missing", caches.GetExArgs{TTL: time.Minute}).Err())
+}
+
+// testGetExExpireAtPast tests GetEx with a time in the past returns the value and deletes the key
+func testGetExExpireAtPast(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	key := "test:string:getex_past"
+	require.NoError(t, cmd.Set(ctx, key, "value", 0).Err())
+
+	val, err := cmd.GetEx(ctx, key, caches.GetExArgs{ExpireAt: time.Now().Add(-time.Hour)}).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	require.Equal(t, caches.Nil, cmd.Get(ctx, key).Err())
+}
+
+// testGetRange tests GetRange with positive and negative offsets
+func testGetRange(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	key := "test:string:getrange"
+	require.NoError(t, cmd.Set(ctx, key, "This is a string", 0).Err())
+
+	tests := []struct {
+		start, end int64
+		expected   string
+	}{
+		{0, 3, "This"},
+		{-3, -1, "ing"},
+		{0, -1, "This is a string"},
+		{10, 100, "string"},
+		{5, 3, ""},
+		{-1, -5, ""},
+		{-100, 3, "This"},
+	}
+	for _, tt := range tests {
+		val, err := cmd.GetRange(ctx, key, tt.start, tt.end).Result()
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, string(val), "GETRANGE %d %d", tt.start, tt.end)
+	}
+
+	val, err := cmd.GetRange(ctx, "test:string:getrange_missing", 0, -1).Result()
+	require.NoError(t, err)
+	require.Empty(t, val)
+}
+
+// testSetRange tests SetRange overwrites, pads and keeps the expiration
+func testSetRange(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:string:setrange"
+	require.NoError(t, cmd.Set(ctx, key, "Hello World", time.Minute).Err())
+
+	n, err := cmd.SetRange(ctx, key, 6, "Redis").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(11), n)
+
+	val, err := cmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello Redis"), val)
+
+	ttl, err := keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, time.Duration(0))
+
+	// A missing key is padded with zero bytes
+	padded := "test:string:setrange_padded"
+	n, err = cmd.SetRange(ctx, padded, 3, "ab").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), n)
+
+	val, err = cmd.Get(ctx, padded).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x00\x00\x00ab"), val)
+
+	// An empty value does not create the key
+	empty := "test:string:setrange_empty"
+	n, err = cmd.SetRange(ctx, empty, 10, "").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+	require.Equal(t, caches.Nil, cmd.Get(ctx, empty).Err())
+
+	require.Error(t, cmd.SetRange(ctx, key, -1, "x").Err())
+}
+
+// testAppend tests Append creates and extends a string, keeping its expiration
+func testAppend(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	keyCmd := provider.GetKeyCommand()
+	ctx := provider.GetContext()
+
+	key := "test:string:append"
+
+	n, err := cmd.Append(ctx, key, "Hello").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), n)
+
+	require.NoError(t, keyCmd.Expire(ctx, key, time.Minute).Err())
+
+	n, err = cmd.Append(ctx, key, " World").Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(11), n)
+
+	val, err := cmd.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello World"), val)
+
+	ttl, err := keyCmd.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, time.Duration(0))
+}
+
+// testLCS tests LCS returns the longest common subsequence and its length
+func testLCS(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:string:lcs1"
+	key2 := "test:string:lcs2"
+	require.NoError(t, cmd.MSet(ctx, map[string]any{key1: "ohmytext", key2: "mynewtext"}).Err())
+
+	match, err := cmd.LCS(ctx, &caches.LCSQuery{Key1: key1, Key2: key2}).Result()
+	require.NoError(t, err)
+	require.Equal(t, "mytext", match.MatchString)
+
+	match, err = cmd.LCS(ctx, &caches.LCSQuery{Key1: key1, Key2: key2, Len: true}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(6), match.Len)
+
+	// Missing keys are empty strings
+	match, err = cmd.LCS(ctx, &caches.LCSQuery{Key1: key1, Key2: "test:string:lcs_missing"}).Result()
+	require.NoError(t, err)
+	require.Equal(t, "", match.MatchString)
+
+	require.Error(t, cmd.LCS(ctx, &caches.LCSQuery{Key1: key1, Key2: key2, Len: true, Idx: true}).Err())
+}
+
+// testLCSIdx tests LCS returns the matching ranges with the IDX option
+func testLCSIdx(t *testing.T, provider StringCommandProvider) {
+	cmd := provider.GetStringCommand()
+	ctx := provider.GetContext()
+
+	key1 := "test:string:lcs_idx1"
+	key2 := "test:string:lcs_idx2"
+	require.NoError(t, cmd.MSet(ctx, map[string]any{key1: "ohmytext", key2: "mynewtext"}).Err())
+
+	match, err := cmd.LCS(ctx, &caches.LCSQuery{Key1: key1, Key2: key2, Idx: true}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(6), match.Len)
+	require.Equal(t, []caches.LCSMatchedPosition{
+		{Key1: caches.LCSPosition{Start: 4, End: 7}, Key2: caches.LCSPosition{Start: 5, End: 8}},
+		{Key1: caches.LCSPosition{Start: 2, End: 3}, Key2: caches.LCSPosition{Start: 0, End: 1}},
+	}, match.Matches)
+
+	match, err = cmd.LCS(ctx, &caches.LCSQuery{Key1: key1, Key2: key2, Idx: true, MinMatchLen: 4, WithMatchLen: true}).Result()
+	require.NoError(t, err)
+	require.Equal(t, int64(6), match.Len)
+	require.Equal(t, []caches.LCSMatchedPosition{
+		{Key1: caches.LCSPosition{Start: 4, End: 7}, Key2: caches.LCSPosition{Start: 5, End: 8}, MatchLen: 4},
+	}, match.Matches)
+}
@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/stretchr/testify/require"
+)
+
+// TxCommandProvider defines the interface for testing TxCommand implementations
+type TxCommandProvider interface {
+	GetTxCommand() caches.TxCommand
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// RunTxCommandTests runs all TxCommand tests
+func RunTxCommandTests(t *testing.T, provider TxCommandProvider) {
+	t.Run("TxPipelined", func(t *testing.T) {
+		testTxPipelined(t, provider)
+	})
+	t.Run("Watch", func(t *testing.T) {
+		testWatch(t, provider)
+	})
+	t.Run("Watch_Conflict", func(t *testing.T) {
+		testWatchConflict(t, provider)
+	})
+	t.Run("Watch_DeletedKey", func(t *testing.T) {
+		testWatchDeletedKey(t, provider)
+	})
+	t.Run("Watch_Retry", func(t *testing.T) {
+		testWatchRetry(t, provider)
+	})
+	t.Run("Watch_HExpire", func(t *testing.T) {
+		testWatchHExpire(t, provider)
+	})
+}
+
+// testTxPipelined tests that queued commands are executed and resolved
+func testTxPipelined(t *testing.T, provider TxCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:tx:pipelined"
+	cache.Del(ctx, key)
+
+	var incr caches.Result[int64]
+	err := provider.GetTxCommand().TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+		pipe.Set(ctx, key, "10", 0)
+		incr = pipe.IncrBy(ctx, key, 5)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, incr.Err())
+	require.Equal(t, int64(15), incr.Val())
+}
+
+// testWatch tests a read-modify-write sequence without conflicts
+func testWatch(t *testing.T, provider TxCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:tx:watch"
+	cache.Del(ctx, key)
+	cache.HSet(ctx, key, map[string]any{"count": "1"})
+
+	err := provider.GetTxCommand().Watch(ctx, func(tx caches.Tx) error {
+		val, err := tx.HGet(ctx, key, "count").Result()
+		if err != nil {
+			return err
+		}
+
+		n, err := strconv.Atoi(string(val))
+		if err != nil {
+			return err
+		}
+
+		return tx.TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+			pipe.HSet(ctx, key, map[string]any{"count": n * 2})
+			return nil
+		})
+	}, key)
+	require.NoError(t, err)
+
+	result := cache.HGet(ctx, key, "count")
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("2"), result.Val())
+}
+
+// testWatchConflict tests that a modified watched key aborts the transaction
+func testWatchConflict(t *testing.T, provider TxCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:tx:conflict"
+	cache.Del(ctx, key)
+	cache.Set(ctx, key, "original", 0)
+
+	var set caches.StatusResult
+	err := provider.GetTxCommand().Watch(ctx, func(tx caches.Tx) error {
+		// Modified outside the transaction
+		cache.Set(ctx, key, "concurrent", 0)
+
+		return tx.TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+			set = pipe.Set(ctx, key, "transaction", 0)
+			return nil
+		})
+	}, key)
+	require.ErrorIs(t, err, caches.ErrTxFailed)
+	require.ErrorIs(t, set.Err(), caches.ErrTxFailed)
+
+	result := cache.Get(ctx, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("concurrent"), result.Val())
+}
+
+// testWatchDeletedKey tests that deleting a watched key aborts the transaction
+func testWatchDeletedKey(t *testing.T, provider TxCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:tx:deleted"
+	cache.Del(ctx, key)
+	cache.Set(ctx, key, "value", 0)
+
+	err := provider.GetTxCommand().Watch(ctx, func(tx caches.Tx) error {
+		cache.Del(ctx, key)
+
+		return tx.TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+			pipe.Set(ctx, key+":copy", "value", 0)
+			return nil
+		})
+	}, key)
+	require.ErrorIs(t, err, caches.ErrTxFailed)
+
+	exists := cache.Exists(ctx, key+":copy")
+	require.NoError(t, exists.Err())
+	require.Equal(t, int64(0), exists.Val())
+}
+
+// testWatchRetry tests retrying a transaction after a conflict
+func testWatchRetry(t *testing.T, provider TxCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:tx:retry"
+	cache.Del(ctx, key)
+	cache.Set(ctx, key, "1", 0)
+
+	attempts := 0
+	increment := func(tx caches.Tx) error {
+		attempts++
+
+		val, err := tx.Get(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+
+		n, err := strconv.Atoi(string(val))
+		if err != nil {
+			return err
+		}
+
+		// Conflict on the first attempt only
+		if attempts == 1 {
+			cache.Set(ctx, key, "10", 0)
+		}
+
+		return tx.TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+			pipe.Set(ctx, key, n+1, 0)
+			return nil
+		})
+	}
+
+	var err error
+	for i := 0; i < 3; i++ {
+		err = provider.GetTxCommand().Watch(ctx, increment, key)
+		if err != caches.ErrTxFailed {
+			break
+		}
+	}
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+
+	result := cache.Get(ctx, key)
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("11"), result.Val())
+}
+
+// testWatchHExpire tests that only the field expirations which change a watched hash abort the transaction
+func testWatchHExpire(t *testing.T, provider TxCommandProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:tx:hexpire"
+	cache.Del(ctx, key, key+":copy")
+	require.NoError(t, cache.HSet(ctx, key, map[string]any{"a": "1"}).Err())
+	require.NoError(t, cache.HExpire(ctx, key, time.Hour, "a").Err())
+
+	err := provider.GetTxCommand().Watch(ctx, func(tx caches.Tx) error {
+		// Neither the condition nor a missing field updates the hash
+		require.Equal(t, []int64{0}, cache.HExpireNX(ctx, key, 2*time.Hour, "a").Val())
+		require.Equal(t, []int64{-2}, cache.HExpire(ctx, key, 2*time.Hour, "missing").Val())
+
+		return tx.TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+			pipe.Set(ctx, key+":copy", "value", 0)
+			return nil
+		})
+	}, key)
+	require.NoError(t, err)
+
+	err = provider.GetTxCommand().Watch(ctx, func(tx caches.Tx) error {
+		require.Equal(t, []int64{1}, cache.HExpire(ctx, key, 2*time.Hour, "a").Val())
+
+		return tx.TxPipelined(ctx, func(pipe caches.TxPipeliner) error {
+			pipe.Set(ctx, key+":copy", "other", 0)
+			return nil
+		})
+	}, key)
+	require.ErrorIs(t, err, caches.ErrTxFailed)
+
+	result := cache.Get(ctx, key+":copy")
+	require.NoError(t, result.Err())
+	require.Equal(t, []byte("value"), result.Val())
+	cache.Del(ctx, key, key+":copy")
+}
@@ -0,0 +1,637 @@
+package tests
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+	"github.com/rockcookies/go-caches/typed"
+	"github.com/stretchr/testify/require"
+)
+
+// TypedProvider provides the commands wrapped by the typed package
+type TypedProvider interface {
+	GetCache() caches.Cache
+	GetContext() context.Context
+}
+
+// typedUser is the value stored by the typed tests
+type typedUser struct {
+	Name  string
+	Age   int
+	Tags  []string
+	Admin bool
+}
+
+// typedPoint implements encoding.BinaryMarshaler and encoding.BinaryUnmarshaler
+type typedPoint struct {
+	X, Y int32
+}
+
+func (p typedPoint) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data, uint32(p.X))
+	binary.BigEndian.PutUint32(data[4:], uint32(p.Y))
+	return data, nil
+}
+
+func (p *typedPoint) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return errors.New("invalid point")
+	}
+	p.X = int32(binary.BigEndian.Uint32(data))
+	p.Y = int32(binary.BigEndian.Uint32(data[4:]))
+	return nil
+}
+
+// typedAudit is embedded in typedProfile, its fields are promoted
+type typedAudit struct {
+	UpdatedBy string `cache:"updated_by"`
+}
+
+// typedProfile is the struct stored by the struct hash tests
+type typedProfile struct {
+	typedAudit
+	Name     string            `cache:"name"`
+	Age      int               `cache:"age,omitempty"`
+	Score    float64           `cache:"score"`
+	Active   bool              `cache:"active"`
+	Birthday time.Time         `cache:"birthday"`
+	Avatar   *string           `cache:"avatar"`
+	Settings map[string]string `cache:"settings,omitempty"`
+	Address  *typedAddress     `cache:"address"`
+	Raw      []byte            `cache:"raw,omitempty"`
+	Nickname string
+	Secret   string `cache:"-"`
+	internal string
+}
+
+// typedAddress is a nested struct stored as JSON
+type typedAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+// RunTypedTests runs all typed package tests
+func RunTypedTests(t *testing.T, provider TypedProvider) {
+	t.Run("String_JSON", func(t *testing.T) {
+		testTypedStringJSON(t, provider)
+	})
+	t.Run("String_Gob", func(t *testing.T) {
+		testTypedStringGob(t, provider)
+	})
+	t.Run("String_Binary", func(t *testing.T) {
+		testTypedStringBinary(t, provider)
+	})
+	t.Run("String_Func", func(t *testing.T) {
+		testTypedStringFunc(t, provider)
+	})
+	t.Run("String_Missing", func(t *testing.T) {
+		testTypedStringMissing(t, provider)
+	})
+	t.Run("String_SetNX", func(t *testing.T) {
+		testTypedStringSetNX(t, provider)
+	})
+	t.Run("String_MGetMSet", func(t *testing.T) {
+		testTypedStringMGetMSet(t, provider)
+	})
+	t.Run("String_DecodeError", func(t *testing.T) {
+		testTypedStringDecodeError(t, provider)
+	})
+	t.Run("Hash_GetSet", func(t *testing.T) {
+		testTypedHashGetSet(t, provider)
+	})
+	t.Run("Hash_GetAll", func(t *testing.T) {
+		testTypedHashGetAll(t, provider)
+	})
+	t.Run("Hash_MGet", func(t *testing.T) {
+		testTypedHashMGet(t, provider)
+	})
+	t.Run("Hash_SetNX", func(t *testing.T) {
+		testTypedHashSetNX(t, provider)
+	})
+	t.Run("Hash_Vals", func(t *testing.T) {
+		testTypedHashVals(t, provider)
+	})
+	t.Run("HSetStruct_RoundTrip", func(t *testing.T) {
+		testTypedHSetStructRoundTrip(t, provider)
+	})
+	t.Run("HSetStruct_StoredValues", func(t *testing.T) {
+		testTypedHSetStructStoredValues(t, provider)
+	})
+	t.Run("HSetStruct_OmitEmpty", func(t *testing.T) {
+		testTypedHSetStructOmitEmpty(t, provider)
+	})
+	t.Run("HSetStruct_NilPointer", func(t *testing.T) {
+		testTypedHSetStructNilPointer(t, provider)
+	})
+	t.Run("HSetStruct_NotStruct", func(t *testing.T) {
+		testTypedHSetStructNotStruct(t, provider)
+	})
+	t.Run("HGetStruct_Missing", func(t *testing.T) {
+		testTypedHGetStructMissing(t, provider)
+	})
+	t.Run("HMGetInto", func(t *testing.T) {
+		testTypedHMGetInto(t, provider)
+	})
+	t.Run("HMGetInto_UnknownField", func(t *testing.T) {
+		testTypedHMGetIntoUnknownField(t, provider)
+	})
+}
+
+// testTypedStringJSON tests a struct round trip through the JSON codec
+func testTypedStringJSON(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewString[typedUser](cache, typed.JSON[typedUser]{})
+
+	key := "test:typed:json"
+	defer cache.Del(ctx, key)
+
+	user := typedUser{Name: "alice", Age: 30, Tags: []string{"a", "b"}, Admin: true}
+	require.NoError(t, users.Set(ctx, key, user, time.Minute))
+
+	got, err := users.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, user, got)
+
+	// The raw value is the JSON encoding
+	raw, err := cache.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Name":"alice","Age":30,"Tags":["a","b"],"Admin":true}`, string(raw))
+
+	ttl, err := cache.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	require.Greater(t, ttl, time.Duration(0))
+}
+
+// testTypedStringGob tests a struct round trip through the gob codec
+func testTypedStringGob(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewString[typedUser](cache, typed.Gob[typedUser]{})
+
+	key := "test:typed:gob"
+	defer cache.Del(ctx, key)
+
+	user := typedUser{Name: "bob", Age: 41, Tags: []string{"x"}}
+	require.NoError(t, users.Set(ctx, key, user, 0))
+
+	got, err := users.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, user, got)
+}
+
+// testTypedStringBinary tests a round trip through the binary codec
+func testTypedStringBinary(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	points := typed.NewString[typedPoint](cache, typed.Binary[typedPoint]{})
+
+	key := "test:typed:binary"
+	defer cache.Del(ctx, key)
+
+	require.NoError(t, points.Set(ctx, key, typedPoint{X: -3, Y: 7}, 0))
+
+	got, err := points.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, typedPoint{X: -3, Y: 7}, got)
+
+	raw, err := cache.Get(ctx, key).Result()
+	require.NoError(t, err)
+	require.Len(t, raw, 8)
+
+	// Types without binary methods are rejected
+	_, err = typed.Binary[typedUser]{}.Marshal(typedUser{})
+	require.Error(t, err)
+}
+
+// testTypedStringFunc tests a round trip through a codec made of functions
+func testTypedStringFunc(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	numbers := typed.NewString[int](cache, typed.Func[int]{
+		MarshalFunc: func(v int) ([]byte, error) {
+			return []byte(strconv.Itoa(v)), nil
+		},
+		UnmarshalFunc: func(data []byte, v *int) (err error) {
+			*v, err = strconv.Atoi(string(data))
+			return
+		},
+	})
+
+	key := "test:typed:func"
+	defer cache.Del(ctx, key)
+
+	require.NoError(t, numbers.Set(ctx, key, 41, 0))
+	require.NoError(t, cache.Incr(ctx, key).Err())
+
+	got, err := numbers.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, 42, got)
+}
+
+// testTypedStringMissing tests Get on a missing key
+func testTypedStringMissing(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewString[typedUser](cache, typed.JSON[typedUser]{})
+
+	got, err := users.Get(ctx, "test:typed:missing")
+	require.ErrorIs(t, err, caches.Nil)
+	require.Equal(t, typedUser{}, got)
+}
+
+// testTypedStringSetNX tests SetNX and SetXX
+func testTypedStringSetNX(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewString[typedUser](cache, typed.JSON[typedUser]{})
+
+	key := "test:typed:setnx"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	ok, err := users.SetXX(ctx, key, typedUser{Name: "xx"}, 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = users.SetNX(ctx, key, typedUser{Name: "first"}, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = users.SetNX(ctx, key, typedUser{Name: "second"}, 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = users.SetXX(ctx, key, typedUser{Name: "third"}, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	got, err := users.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "third", got.Name)
+}
+
+// testTypedStringMGetMSet tests MSet and MGet with a missing key
+func testTypedStringMGetMSet(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewString[typedUser](cache, typed.JSON[typedUser]{})
+
+	keys := []string{"test:typed:mset1", "test:typed:mset2", "test:typed:mset_missing"}
+	cache.Del(ctx, keys...)
+	defer cache.Del(ctx, keys...)
+
+	values := map[string]typedUser{
+		keys[0]: {Name: "one", Age: 1},
+		keys[1]: {Name: "two", Age: 2},
+	}
+	require.NoError(t, users.MSet(ctx, values))
+
+	got, err := users.MGet(ctx, keys...)
+	require.NoError(t, err)
+	require.Equal(t, values, got)
+
+	ok, err := users.MSetNX(ctx, map[string]typedUser{keys[1]: {}, keys[2]: {}})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+// testTypedStringDecodeError tests Get on a value the codec cannot decode
+func testTypedStringDecodeError(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewString[typedUser](cache, typed.JSON[typedUser]{})
+
+	key := "test:typed:invalid"
+	defer cache.Del(ctx, key)
+
+	require.NoError(t, cache.Set(ctx, key, "not json", 0).Err())
+
+	_, err := users.Get(ctx, key)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, caches.Nil)
+}
+
+// testTypedHashGetSet tests Set and Get on hash fields
+func testTypedHashGetSet(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewHash[typedUser](cache, typed.JSON[typedUser]{})
+
+	key := "test:typed:hash"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	added, err := users.Set(ctx, key, map[string]typedUser{
+		"alice": {Name: "alice", Age: 30},
+		"bob":   {Name: "bob", Age: 41},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), added)
+
+	got, err := users.Get(ctx, key, "bob")
+	require.NoError(t, err)
+	require.Equal(t, typedUser{Name: "bob", Age: 41}, got)
+
+	_, err = users.Get(ctx, key, "carol")
+	require.ErrorIs(t, err, caches.Nil)
+}
+
+// testTypedHashGetAll tests GetAll on an existing and a missing hash
+func testTypedHashGetAll(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	scores := typed.NewHash[float64](cache, typed.JSON[float64]{})
+
+	key := "test:typed:hash_all"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	values := map[string]float64{"a": 1.5, "b": -2}
+	_, err := scores.Set(ctx, key, values)
+	require.NoError(t, err)
+
+	got, err := scores.GetAll(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, values, got)
+
+	got, err = scores.GetAll(ctx, "test:typed:hash_all_missing")
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+// testTypedHashMGet tests MGet skips missing fields
+func testTypedHashMGet(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewHash[typedUser](cache, typed.Gob[typedUser]{})
+
+	key := "test:typed:hash_mget"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	_, err := users.Set(ctx, key, map[string]typedUser{
+		"alice": {Name: "alice"},
+		"bob":   {Name: "bob"},
+	})
+	require.NoError(t, err)
+
+	got, err := users.MGet(ctx, key, "alice", "carol")
+	require.NoError(t, err)
+	require.Equal(t, map[string]typedUser{"alice": {Name: "alice"}}, got)
+}
+
+// testTypedHashSetNX tests SetNX on hash fields
+func testTypedHashSetNX(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	users := typed.NewHash[typedUser](cache, typed.JSON[typedUser]{})
+
+	key := "test:typed:hash_setnx"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	ok, err := users.SetNX(ctx, key, "alice", typedUser{Age: 1})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = users.SetNX(ctx, key, "alice", typedUser{Age: 2})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	got, err := users.Get(ctx, key, "alice")
+	require.NoError(t, err)
+	require.Equal(t, 1, got.Age)
+}
+
+// testTypedHashVals tests Vals decodes every value
+func testTypedHashVals(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+	points := typed.NewHash[typedPoint](cache, typed.Binary[typedPoint]{})
+
+	key := "test:typed:hash_vals"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	_, err := points.Set(ctx, key, map[string]typedPoint{
+		"a": {X: 1, Y: 2},
+		"b": {X: 3, Y: 4},
+	})
+	require.NoError(t, err)
+
+	got, err := points.Vals(ctx, key)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []typedPoint{{X: 1, Y: 2}, {X: 3, Y: 4}}, got)
+}
+
+// testTypedHSetStructRoundTrip tests a struct round trip through a hash
+func testTypedHSetStructRoundTrip(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:typed:struct"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	avatar := "avatar.png"
+	profile := typedProfile{
+		typedAudit: typedAudit{UpdatedBy: "admin"},
+		Name:       "alice",
+		Age:        30,
+		Score:      9.5,
+		Active:     true,
+		Birthday:   time.Date(1990, 5, 17, 8, 30, 0, 0, time.UTC),
+		Avatar:     &avatar,
+		Settings:   map[string]string{"theme": "dark"},
+		Address:    &typedAddress{City: "Paris", Zip: "75001"},
+		Raw:        []byte{0, 1, 2},
+		Nickname:   "ali",
+		Secret:     "hidden",
+		internal:   "hidden",
+	}
+
+	added, err := typed.HSetStruct(ctx, cache, key, &profile)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), added)
+
+	var got typedProfile
+	require.NoError(t, typed.HGetStruct(ctx, cache, key, &got))
+
+	profile.Secret = ""
+	profile.internal = ""
+	require.Equal(t, profile, got)
+}
+
+// testTypedHSetStructStoredValues tests the representation of the stored fields
+func testTypedHSetStructStoredValues(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:typed:struct_values"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	profile := typedProfile{
+		Name:     "bob",
+		Age:      41,
+		Score:    0.25,
+		Active:   false,
+		Birthday: time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC),
+		Address:  &typedAddress{City: "Lyon"},
+	}
+	_, err := typed.HSetStruct(ctx, cache, key, profile)
+	require.NoError(t, err)
+
+	values, err := cache.HGetAll(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, "bob", string(values["name"]))
+	require.Equal(t, "41", string(values["age"]))
+	require.Equal(t, "0.25", string(values["score"]))
+	require.Equal(t, "0", string(values["active"]))
+	require.Equal(t, "2000-01-02T03:04:05Z", string(values["birthday"]))
+	require.JSONEq(t, `{"city":"Lyon","zip":""}`, string(values["address"]))
+	require.Contains(t, values, "Nickname")
+	require.NotContains(t, values, "Secret")
+	require.NotContains(t, values, "internal")
+
+	// Numbers are stored as text, so they can be incremented
+	require.NoError(t, cache.HIncrBy(ctx, key, "age", 1).Err())
+
+	var got typedProfile
+	require.NoError(t, typed.HGetStruct(ctx, cache, key, &got))
+	require.Equal(t, 42, got.Age)
+}
+
+// testTypedHSetStructOmitEmpty tests empty fields tagged with omitempty are not set
+func testTypedHSetStructOmitEmpty(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:typed:struct_omitempty"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	require.NoError(t, cache.HSet(ctx, key, map[string]any{"age": "25"}).Err())
+
+	_, err := typed.HSetStruct(ctx, cache, key, typedProfile{Name: "carol"})
+	require.NoError(t, err)
+
+	values, err := cache.HGetAll(ctx, key).Result()
+	require.NoError(t, err)
+	require.Equal(t, "25", string(values["age"]))
+	require.NotContains(t, values, "settings")
+	require.NotContains(t, values, "raw")
+
+	// Zero values without omitempty are stored
+	require.Equal(t, "0", string(values["score"]))
+	require.Equal(t, "0001-01-01T00:00:00Z", string(values["birthday"]))
+}
+
+// testTypedHSetStructNilPointer tests nil pointers delete their hash field
+func testTypedHSetStructNilPointer(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:typed:struct_nil"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	avatar := "avatar.png"
+	_, err := typed.HSetStruct(ctx, cache, key, typedProfile{Name: "dave", Avatar: &avatar})
+	require.NoError(t, err)
+
+	var got typedProfile
+	require.NoError(t, typed.HGetStruct(ctx, cache, key, &got))
+	require.NotNil(t, got.Avatar)
+	require.Equal(t, avatar, *got.Avatar)
+
+	_, err = typed.HSetStruct(ctx, cache, key, typedProfile{Name: "dave"})
+	require.NoError(t, err)
+
+	exists, err := cache.HExists(ctx, key, "avatar").Result()
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	got = typedProfile{}
+	require.NoError(t, typed.HGetStruct(ctx, cache, key, &got))
+	require.Nil(t, got.Avatar)
+	require.Nil(t, got.Address)
+}
+
+// testTypedHSetStructNotStruct tests the helpers reject values that are not structs
+func testTypedHSetStructNotStruct(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:typed:struct_invalid"
+
+	_, err := typed.HSetStruct(ctx, cache, key, map[string]string{"name": "eve"})
+	require.Error(t, err)
+
+	_, err = typed.HSetStruct(ctx, cache, key, (*typedProfile)(nil))
+	require.Error(t, err)
+
+	var profile typedProfile
+	require.Error(t, typed.HGetStruct(ctx, cache, key, profile))
+	require.Error(t, typed.HMGetInto(ctx, cache, key, (*typedProfile)(nil)))
+}
+
+// testTypedHGetStructMissing tests HGetStruct on a missing key
+func testTypedHGetStructMissing(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	got := typedProfile{Name: "unchanged"}
+	err := typed.HGetStruct(ctx, cache, "test:typed:struct_missing", &got)
+	require.ErrorIs(t, err, caches.Nil)
+	require.Equal(t, "unchanged", got.Name)
+}
+
+// testTypedHMGetInto tests HMGetInto only reads the given fields
+func testTypedHMGetInto(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	key := "test:typed:struct_mget"
+	cache.Del(ctx, key)
+	defer cache.Del(ctx, key)
+
+	_, err := typed.HSetStruct(ctx, cache, key, typedProfile{
+		Name:    "frank",
+		Age:     50,
+		Active:  true,
+		Address: &typedAddress{City: "Nice"},
+	})
+	require.NoError(t, err)
+
+	var got typedProfile
+	require.NoError(t, typed.HMGetInto(ctx, cache, key, &got, "name", "address", "avatar"))
+	require.Equal(t, "frank", got.Name)
+	require.Equal(t, &typedAddress{City: "Nice"}, got.Address)
+	require.Nil(t, got.Avatar)
+	require.Zero(t, got.Age)
+	require.False(t, got.Active)
+
+	// Without fields, all the struct fields are read
+	got = typedProfile{}
+	require.NoError(t, typed.HMGetInto(ctx, cache, key, &got))
+	require.Equal(t, 50, got.Age)
+	require.True(t, got.Active)
+}
+
+// testTypedHMGetIntoUnknownField tests HMGetInto rejects fields missing from the struct
+func testTypedHMGetIntoUnknownField(t *testing.T, provider TypedProvider) {
+	cache := provider.GetCache()
+	ctx := provider.GetContext()
+
+	var got typedProfile
+	err := typed.HMGetInto(ctx, cache, "test:typed:struct_mget", &got, "name", "unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package caches
+
+import "context"
+
+// TxPipeliner is a Pipeliner whose queued commands are executed atomically,
+// as if they were wrapped in MULTI/EXEC.
+type TxPipeliner interface {
+	Pipeliner
+}
+
+// Tx is passed to the Watch callback.
+// Commands called on Tx directly are executed immediately, so they can be used
+// to read the watched keys. Commands queued through TxPipeline or TxPipelined
+// are only applied when none of the watched keys changed since Watch was called,
+// otherwise Exec returns ErrTxFailed.
+type Tx interface {
+	Cache
+
+	// TxPipeline returns a new TxPipeliner bound to the watched keys.
+	TxPipeline() TxPipeliner
+
+	// TxPipelined queues the commands issued by fn and executes them atomically.
+	TxPipelined(ctx context.Context, fn func(pipe TxPipeliner) error) error
+}
+
+// TxCommand is implemented by providers that support transactions.
+type TxCommand interface {
+	// TxPipeline returns a new TxPipeliner bound to the provider.
+	TxPipeline() TxPipeliner
+
+	// TxPipelined queues the commands issued by fn and executes them atomically.
+	// Nothing is executed when fn returns an error.
+	TxPipelined(ctx context.Context, fn func(pipe TxPipeliner) error) error
+
+	// Watch calls fn with a Tx that watches keys for changes.
+	// It returns ErrTxFailed when a watched key was modified before the
+	// transaction queued by fn was executed, in which case the whole
+	// read-modify-write sequence can be retried.
+	Watch(ctx context.Context, fn func(tx Tx) error, keys ...string) error
+}
@@ -0,0 +1,116 @@
+// Package typed provides generic wrappers over the string and hash commands,
+// encoding and decoding the stored values with a Codec.
+//
+// The wrapped commands are run immediately, so they must not be bound to a pipeline or a transaction.
+package typed
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Codec encodes values of type T into bytes and decodes them back.
+type Codec[T any] interface {
+	// Marshal returns the encoding of v.
+	Marshal(v T) ([]byte, error)
+
+	// Unmarshal decodes data into v.
+	Unmarshal(data []byte, v *T) error
+}
+
+var errNotBinary = errors.New("typed: type does not implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler")
+
+// JSON encodes values with encoding/json.
+type JSON[T any] struct{}
+
+var _ Codec[any] = JSON[any]{}
+
+// Marshal implements Codec.
+func (JSON[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements Codec.
+func (JSON[T]) Unmarshal(data []byte, v *T) error {
+	return json.Unmarshal(data, v)
+}
+
+// Gob encodes values with encoding/gob.
+// Each value is encoded with its own type information, which makes it larger than a gob stream item.
+type Gob[T any] struct{}
+
+var _ Codec[any] = Gob[any]{}
+
+// Marshal implements Codec.
+func (Gob[T]) Marshal(v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements Codec.
+func (Gob[T]) Unmarshal(data []byte, v *T) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// Binary encodes values implementing encoding.BinaryMarshaler,
+// with *T implementing encoding.BinaryUnmarshaler.
+// This is the case of the types generated by most binary serialization tools, such as msgpack ones.
+type Binary[T any] struct{}
+
+var _ Codec[any] = Binary[any]{}
+
+// Marshal implements Codec.
+func (Binary[T]) Marshal(v T) ([]byte, error) {
+	m, ok := any(v).(encoding.BinaryMarshaler)
+	if !ok {
+		if m, ok = any(&v).(encoding.BinaryMarshaler); !ok {
+			return nil, fmt.Errorf("%w: %T", errNotBinary, v)
+		}
+	}
+	return m.MarshalBinary()
+}
+
+// Unmarshal implements Codec.
+func (Binary[T]) Unmarshal(data []byte, v *T) error {
+	u, ok := any(v).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotBinary, *v)
+	}
+	return u.UnmarshalBinary(data)
+}
+
+// Func is a Codec made of two functions.
+// It plugs in encodings such as protobuf without this package depending on them:
+//
+//	codec := typed.Func[*pb.User]{
+//		MarshalFunc: func(v *pb.User) ([]byte, error) {
+//			return proto.Marshal(v)
+//		},
+//		UnmarshalFunc: func(data []byte, v **pb.User) error {
+//			*v = new(pb.User)
+//			return proto.Unmarshal(data, *v)
+//		},
+//	}
+type Func[T any] struct {
+	MarshalFunc   func(v T) ([]byte, error)
+	UnmarshalFunc func(data []byte, v *T) error
+}
+
+var _ Codec[any] = Func[any]{}
+
+// Marshal implements Codec.
+func (c Func[T]) Marshal(v T) ([]byte, error) {
+	return c.MarshalFunc(v)
+}
+
+// Unmarshal implements Codec.
+func (c Func[T]) Unmarshal(data []byte, v *T) error {
+	return c.UnmarshalFunc(data, v)
+}
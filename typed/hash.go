@@ -0,0 +1,94 @@
+package typed
+
+import (
+	"context"
+
+	"github.com/rockcookies/go-caches"
+)
+
+// Hash stores values of type T in the fields of hash keys.
+type Hash[T any] struct {
+	cmd   caches.HashCommand
+	codec Codec[T]
+}
+
+// NewHash returns a Hash running its commands with cmd and encoding values with codec.
+func NewHash[T any](cmd caches.HashCommand, codec Codec[T]) *Hash[T] {
+	if cmd == nil {
+		panic("cmd is nil")
+	}
+	if codec == nil {
+		panic("codec is nil")
+	}
+
+	return &Hash[T]{cmd: cmd, codec: codec}
+}
+
+// Get returns the value of field in the hash stored at key.
+// Returns caches.Nil if the key or the field does not exist.
+func (h *Hash[T]) Get(ctx context.Context, key, field string) (T, error) {
+	var v T
+	data, err := h.cmd.HGet(ctx, key, field).Result()
+	if err != nil {
+		return v, err
+	}
+
+	err = h.codec.Unmarshal(data, &v)
+	return v, err
+}
+
+// GetAll returns all the fields and values of the hash stored at key.
+// Returns an empty map if the key does not exist.
+func (h *Hash[T]) GetAll(ctx context.Context, key string) (map[string]T, error) {
+	values, err := h.cmd.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	return decodeMap(h.codec, values)
+}
+
+// MGet returns the values of multiple fields in the hash stored at key.
+// Fields that do not exist are missing from the result.
+func (h *Hash[T]) MGet(ctx context.Context, key string, fields ...string) (map[string]T, error) {
+	values, err := h.cmd.HMGet(ctx, key, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+	return decodeMap(h.codec, values)
+}
+
+// Set sets the fields of the hash stored at key to their values.
+// Returns the number of fields that were added.
+func (h *Hash[T]) Set(ctx context.Context, key string, values map[string]T) (int64, error) {
+	encoded, err := encodeMap(h.codec, values)
+	if err != nil {
+		return 0, err
+	}
+	return h.cmd.HSet(ctx, key, encoded).Result()
+}
+
+// SetNX sets field of the hash stored at key to v only if the field does not exist.
+// Returns true if the field was set.
+func (h *Hash[T]) SetNX(ctx context.Context, key, field string, v T) (bool, error) {
+	data, err := h.codec.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	return h.cmd.HSetNX(ctx, key, field, data).Result()
+}
+
+// Vals returns all the values of the hash stored at key.
+func (h *Hash[T]) Vals(ctx context.Context, key string) ([]T, error) {
+	values, err := h.cmd.HVals(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]T, len(values))
+	for i, data := range values {
+		if err := h.codec.Unmarshal(data, &decoded[i]); err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
@@ -0,0 +1,127 @@
+package typed
+
+import (
+	"context"
+	"time"
+
+	"github.com/rockcookies/go-caches"
+)
+
+// String stores values of type T in string keys.
+type String[T any] struct {
+	cmd   caches.StringCommand
+	codec Codec[T]
+}
+
+// NewString returns a String running its commands with cmd and encoding values with codec.
+func NewString[T any](cmd caches.StringCommand, codec Codec[T]) *String[T] {
+	if cmd == nil {
+		panic("cmd is nil")
+	}
+	if codec == nil {
+		panic("codec is nil")
+	}
+
+	return &String[T]{cmd: cmd, codec: codec}
+}
+
+// Get returns the value of key.
+// Returns caches.Nil if the key does not exist.
+func (s *String[T]) Get(ctx context.Context, key string) (T, error) {
+	var v T
+	data, err := s.cmd.Get(ctx, key).Result()
+	if err != nil {
+		return v, err
+	}
+
+	err = s.codec.Unmarshal(data, &v)
+	return v, err
+}
+
+// Set sets key to hold v, with an optional expiration.
+func (s *String[T]) Set(ctx context.Context, key string, v T, expiration time.Duration) error {
+	data, err := s.codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.cmd.Set(ctx, key, data, expiration).Err()
+}
+
+// SetNX sets key to hold v only if the key does not exist.
+// Returns true if the key was set.
+func (s *String[T]) SetNX(ctx context.Context, key string, v T, expiration time.Duration) (bool, error) {
+	data, err := s.codec.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	return s.cmd.SetNX(ctx, key, data, expiration).Result()
+}
+
+// SetXX sets key to hold v only if the key already exists.
+// Returns true if the key was set.
+func (s *String[T]) SetXX(ctx context.Context, key string, v T, expiration time.Duration) (bool, error) {
+	data, err := s.codec.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	return s.cmd.SetXX(ctx, key, data, expiration).Result()
+}
+
+// MGet returns the values of multiple keys.
+// Keys that do not exist are missing from the result.
+func (s *String[T]) MGet(ctx context.Context, keys ...string) (map[string]T, error) {
+	values, err := s.cmd.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	return decodeMap(s.codec, values)
+}
+
+// MSet sets multiple keys to their values.
+func (s *String[T]) MSet(ctx context.Context, values map[string]T) error {
+	encoded, err := encodeMap(s.codec, values)
+	if err != nil {
+		return err
+	}
+	return s.cmd.MSet(ctx, encoded).Err()
+}
+
+// MSetNX sets multiple keys to their values only if none of the keys exist.
+// Returns true if the keys were set.
+func (s *String[T]) MSetNX(ctx context.Context, values map[string]T) (bool, error) {
+	encoded, err := encodeMap(s.codec, values)
+	if err != nil {
+		return false, err
+	}
+	return s.cmd.MSetNX(ctx, encoded).Result()
+}
+
+// encodeMap encodes the values of m, as expected by the commands setting multiple keys or fields.
+func encodeMap[T any](codec Codec[T], m map[string]T) (map[string]any, error) {
+	encoded := make(map[string]any, len(m))
+	for k, v := range m {
+		data, err := codec.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		encoded[k] = data
+	}
+	return encoded, nil
+}
+
+// decodeMap decodes the values of m, skipping the nil ones of missing keys or fields.
+func decodeMap[T any](codec Codec[T], m map[string][]byte) (map[string]T, error) {
+	decoded := make(map[string]T, len(m))
+	for k, data := range m {
+		if data == nil {
+			continue
+		}
+
+		var v T
+		if err := codec.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		decoded[k] = v
+	}
+	return decoded, nil
+}
@@ -0,0 +1,329 @@
+package typed
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rockcookies/go-caches"
+)
+
+// The hash helpers below map the exported fields of a struct to hash fields.
+// The hash field name is given by the `cache` tag, or is the struct field name:
+//
+//	type Profile struct {
+//		Name     string            `cache:"name"`
+//		Age      int               `cache:"age,omitempty"`
+//		Birthday time.Time         `cache:"birthday"`
+//		Avatar   *string           `cache:"avatar"`
+//		Settings map[string]string `cache:"settings"`
+//		Secret   string            `cache:"-"`
+//	}
+//
+// Strings, byte slices, numbers and bools are stored as text, bools as "1" and "0".
+// Types implementing encoding.TextMarshaler, such as time.Time, are stored as their text,
+// and other types, such as nested structs, maps and slices, as JSON.
+// Fields of embedded structs are promoted, as with encoding/json.
+
+var (
+	errNotStruct    = errors.New("typed: expected a struct or a pointer to a struct")
+	errNotStructPtr = errors.New("typed: expected a non-nil pointer to a struct")
+)
+
+// structField is a struct field mapped to a hash field.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the hash fields of the struct type t.
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+
+	fields := appendStructFields(nil, t, nil)
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+func appendStructFields(fields []structField, t reflect.Type, index []int) []structField {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("cache")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			fields = appendStructFields(fields, f.Type, fieldIndex)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{
+			name:      name,
+			index:     fieldIndex,
+			omitEmpty: opts == "omitempty",
+		})
+	}
+	return fields
+}
+
+// HSetStruct sets the fields of the hash stored at key from the struct v, or the struct v points to.
+// Empty fields tagged with omitempty are skipped, and nil pointers not tagged with it delete the hash field.
+// Fields are set then deleted with two commands, which are not run atomically.
+// Returns the number of fields that were added.
+func HSetStruct(ctx context.Context, cmd caches.HashCommand, key string, v any) (int64, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return 0, errNotStruct
+	}
+
+	values := make(map[string]any)
+	var deleted []string
+	for _, f := range structFields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			deleted = append(deleted, f.name)
+			continue
+		}
+
+		data, err := encodeField(fv)
+		if err != nil {
+			return 0, fmt.Errorf("typed: field %s: %w", f.name, err)
+		}
+		values[f.name] = data
+	}
+
+	var added int64
+	if len(values) > 0 {
+		n, err := cmd.HSet(ctx, key, values).Result()
+		if err != nil {
+			return 0, err
+		}
+		added = n
+	}
+
+	if len(deleted) > 0 {
+		if err := cmd.HDel(ctx, key, deleted...).Err(); err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
+
+// HGetStruct fills the struct dst points to from the fields of the hash stored at key.
+// Struct fields without a hash field are left untouched.
+// Returns caches.Nil if the key does not exist.
+func HGetStruct(ctx context.Context, cmd caches.HashCommand, key string, dst any) error {
+	rv, err := structPtr(dst)
+	if err != nil {
+		return err
+	}
+
+	values, err := cmd.HGetAll(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return caches.Nil
+	}
+
+	return decodeStruct(rv, structFields(rv.Type()), values)
+}
+
+// HMGetInto fills the struct dst points to from the given fields of the hash stored at key.
+// Without fields, all the fields of the struct are read.
+// Struct fields without a hash field are left untouched.
+func HMGetInto(ctx context.Context, cmd caches.HashCommand, key string, dst any, fields ...string) error {
+	rv, err := structPtr(dst)
+	if err != nil {
+		return err
+	}
+
+	all := structFields(rv.Type())
+	selected := all
+	if len(fields) > 0 {
+		selected = make([]structField, 0, len(fields))
+		for _, name := range fields {
+			i := slices.IndexFunc(all, func(f structField) bool { return f.name == name })
+			if i < 0 {
+				return fmt.Errorf("typed: unknown field %s in %s", name, rv.Type())
+			}
+			selected = append(selected, all[i])
+		}
+	}
+
+	names := make([]string, len(selected))
+	for i, f := range selected {
+		names[i] = f.name
+	}
+
+	values, err := cmd.HMGet(ctx, key, names...).Result()
+	if err != nil {
+		return err
+	}
+
+	return decodeStruct(rv, selected, values)
+}
+
+// structPtr returns the struct dst points to.
+func structPtr(dst any) (reflect.Value, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, errNotStructPtr
+	}
+	return rv.Elem(), nil
+}
+
+// decodeStruct sets the fields of rv from values, skipping the missing ones.
+func decodeStruct(rv reflect.Value, fields []structField, values map[string][]byte) error {
+	for _, f := range fields {
+		data, ok := values[f.name]
+		if !ok || data == nil {
+			continue
+		}
+
+		if err := decodeField(rv.FieldByIndex(f.index), data); err != nil {
+			return fmt.Errorf("typed: field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// encodeField returns the stored representation of the field value v.
+func encodeField(v reflect.Value) ([]byte, error) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		v = v.Elem()
+	}
+
+	if m, ok := textMarshaler(v); ok {
+		return m.MarshalText()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+	}
+	return json.Marshal(v.Interface())
+}
+
+// decodeField sets the field value v from its stored representation.
+func decodeField(v reflect.Value, data []byte) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeField(v.Elem(), data)
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(data)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(data))
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(string(data))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(string(data), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(string(data), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(string(data), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(append([]byte(nil), data...))
+			return nil
+		}
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+// textMarshaler returns v as an encoding.TextMarshaler, when v or its address implements it.
+func textMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		return m, true
+	}
+	if v.CanAddr() {
+		m, ok := v.Addr().Interface().(encoding.TextMarshaler)
+		return m, ok
+	}
+	return nil, false
+}
+
+// isEmptyValue reports whether v is empty as defined by the omitempty option of encoding/json,
+// with zero structs such as time.Time also being empty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}